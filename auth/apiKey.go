@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// apiKeyPrefix로 API 키를 알아볼 수 있게 해서 로그나 저장소에 새어 나갔을 때 찾기 쉽게 합니다.
+	apiKeyPrefix     = "gk_"
+	apiKeyIDSize     = 6
+	apiKeySecretSize = 32
+)
+
+// GenerateAPIKey는 "gk_<공개 식별자>_<비밀>" 형식의 새 키와 그 공개 식별자를 만듭니다.
+func GenerateAPIKey() (key string, prefix string, err error) {
+	id := make([]byte, apiKeyIDSize)
+	if _, err = rand.Read(id); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, apiKeySecretSize)
+	if _, err = rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	prefix = hex.EncodeToString(id)
+	return apiKeyPrefix + prefix + "_" + encoding.EncodeToString(secret), prefix, nil
+}
+
+// ParseAPIKey는 키에서 공개 식별자를 꺼냅니다. 형식이 다르면 false입니다.
+func ParseAPIKey(key string) (prefix string, ok bool) {
+	rest, ok := strings.CutPrefix(key, apiKeyPrefix)
+	if !ok || len(rest) <= hex.EncodedLen(apiKeyIDSize)+1 || rest[hex.EncodedLen(apiKeyIDSize)] != '_' {
+		return "", false
+	}
+	return rest[:hex.EncodedLen(apiKeyIDSize)], true
+}
+
+// HashAPIKey는 저장할 키 해시입니다. 키는 충분히 긴 난수라서 비밀번호와 달리 느린 해시가 필요 없습니다.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func CheckAPIKey(hash string, key string) bool {
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(HashAPIKey(key))) == 1
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAPIKey(t *testing.T) {
+	// 테스트 실행
+	key, prefix, err := GenerateAPIKey()
+	require.NoError(t, err)
+	other, _, err := GenerateAPIKey()
+	require.NoError(t, err)
+
+	// 검증
+	assert.True(t, strings.HasPrefix(key, "gk_"+prefix+"_"))
+	assert.NotEqual(t, key, other)
+	parsed, ok := ParseAPIKey(key)
+	assert.True(t, ok)
+	assert.Equal(t, prefix, parsed)
+	assert.True(t, CheckAPIKey(HashAPIKey(key), key))
+	assert.False(t, CheckAPIKey(HashAPIKey(key), other))
+}
+
+func TestParseAPIKey_Invalid(t *testing.T) {
+	for _, key := range []string{"", "gk_", "abc_0123456789ab_secret", "gk_0123456789ab", "gk_0123456789ab_", "gk_0123456789abXsecret"} {
+		// 테스트 실행
+		_, ok := ParseAPIKey(key)
+
+		// 검증
+		assert.False(t, ok, key)
+	}
+}
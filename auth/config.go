@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"os"
+	"time"
+)
+
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// AccessTokenTTL은 JWT_ACCESS_TTL이고 기본값은 15분입니다.
+func AccessTokenTTL() time.Duration {
+	return durationEnv("JWT_ACCESS_TTL", defaultAccessTokenTTL)
+}
+
+// RefreshTokenTTL은 JWT_REFRESH_TTL이고 기본값은 30일입니다.
+func RefreshTokenTTL() time.Duration {
+	return durationEnv("JWT_REFRESH_TTL", defaultRefreshTokenTTL)
+}
+
+// Issuer는 발급하는 토큰의 iss(JWT_ISSUER)입니다. 비어 있으면 iss를 넣지도 확인하지도 않습니다.
+func Issuer() string {
+	return os.Getenv("JWT_ISSUER")
+}
+
+func durationEnv(name string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(os.Getenv(name))
+	if err != nil || duration <= 0 {
+		return fallback
+	}
+	return duration
+}
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"golang.org/x/sync/singleflight"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultJWKSRefreshInterval = time.Hour
+	// jwksRefetchInterval보다 자주는 모르는 kid 때문에 JWKS를 다시 받지 않습니다. 잘못된 kid로 IdP를 두드리는 것을 막습니다.
+	jwksRefetchInterval = 30 * time.Second
+	jwksMaxSize         = 1 << 20
+)
+
+// JWKS는 URL이나 로컬 파일에서 읽은 검증 키입니다. RefreshInterval마다 다시 읽고,
+// 모르는 kid가 오면 키가 교체되었을 수 있으므로 바로 다시 읽습니다.
+type JWKS struct {
+	// Source는 http(s) URL이나 파일 경로입니다.
+	Source          string
+	Client          *http.Client
+	RefreshInterval time.Duration
+
+	// mu는 keys와 fetchedAt만 지킵니다. 다시 읽는 동안에는 잡지 않아서 다른 요청은 기존 키로 계속 검증합니다.
+	mu        sync.Mutex
+	keys      map[string]*Key
+	fetchedAt time.Time
+	fetches   singleflight.Group
+}
+
+func NewJWKS(source string, refreshInterval time.Duration) *JWKS {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultJWKSRefreshInterval
+	}
+	return &JWKS{
+		Source:          source,
+		Client:          &http.Client{Timeout: 10 * time.Second},
+		RefreshInterval: refreshInterval,
+	}
+}
+
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+}
+
+// Key는 kid의 키입니다. 다시 읽지 못하면 이전에 읽은 키로 계속 검증합니다.
+func (j *JWKS) Key(ctx context.Context, keyID string) (*Key, error) {
+	now := time.Now()
+	keys, fetchedAt := j.current()
+	if keys == nil || now.Sub(fetchedAt) >= j.RefreshInterval {
+		if err := j.refresh(ctx); err != nil && keys == nil {
+			return nil, err
+		}
+		keys, fetchedAt = j.current()
+	}
+	if key, ok := keys[keyID]; ok {
+		return key, nil
+	}
+
+	if now.Sub(fetchedAt) >= jwksRefetchInterval {
+		if err := j.refresh(ctx); err != nil {
+			return nil, err
+		}
+		keys, _ = j.current()
+		if key, ok := keys[keyID]; ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownKey, keyID)
+}
+
+func (j *JWKS) current() (map[string]*Key, time.Time) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.keys, j.fetchedAt
+}
+
+// refresh는 동시에 들어온 요청끼리 한 번만 읽고 결과를 나눠 씁니다. 먼저 온 요청이 취소되어도 나머지가 실패하지 않도록
+// 요청의 취소는 따르지 않습니다. 실패해도 fetchedAt을 갱신해서 IdP 장애 중에 매 요청마다 다시 시도하지 않습니다.
+func (j *JWKS) refresh(ctx context.Context) error {
+	_, err, _ := j.fetches.Do(j.Source, func() (interface{}, error) {
+		data, err := j.read(context.WithoutCancel(ctx))
+		var keys map[string]*Key
+		if err != nil {
+			err = fmt.Errorf("failed to load jwks: %w", err)
+		} else {
+			keys, err = parseJWKS(data)
+		}
+
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		j.fetchedAt = time.Now()
+		if err != nil {
+			return nil, err
+		}
+		j.keys = keys
+		return nil, nil
+	})
+	return err
+}
+
+func (j *JWKS) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(j.Source, "http://") && !strings.HasPrefix(j.Source, "https://") {
+		return os.ReadFile(j.Source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.Source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := j.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", j.Source, resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, jwksMaxSize))
+}
+
+// parseJWKS는 서명용 RSA 키만 읽습니다. 다른 종류의 키는 건너뜁니다.
+func parseJWKS(data []byte) (map[string]*Key, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid jwks: %w", err)
+	}
+
+	keys := make(map[string]*Key, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") || (jwk.Algorithm != "" && jwk.Algorithm != AlgorithmRS256) {
+			continue
+		}
+		n, err := encoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwks key %q: %w", jwk.KeyID, err)
+		}
+		e, err := encoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwks key %q: %w", jwk.KeyID, err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid jwks key %q: bad exponent", jwk.KeyID)
+		}
+		keys[jwk.KeyID] = &Key{
+			ID:        jwk.KeyID,
+			Algorithm: AlgorithmRS256,
+			public:    &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())},
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks has no RSA signing keys")
+	}
+	return keys, nil
+}
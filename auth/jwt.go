@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token is expired")
+	ErrUnknownKey   = errors.New("token was signed with an unknown key")
+)
+
+var encoding = base64.RawURLEncoding
+
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// Claims는 이 서버가 발급하는 토큰의 클레임입니다. TokenType으로 access와 refresh 토큰을 구분합니다.
+type Claims struct {
+	Issuer    string   `json:"iss,omitempty"`
+	Subject   string   `json:"sub"`
+	ID        string   `json:"jti"`
+	IssuedAt  int64    `json:"iat"`
+	ExpiresAt int64    `json:"exp"`
+	TokenType string   `json:"token_type"`
+	TenantID  string   `json:"tenant_id,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
+func (c *Claims) Expiry() time.Time {
+	return time.Unix(c.ExpiresAt, 0)
+}
+
+// Sign은 활성 키로 서명한 compact JWS를 돌려줍니다. 헤더의 kid로 검증할 키를 찾습니다.
+func (k *KeySet) Sign(claims *Claims) (string, error) {
+	key := k.keys[k.activeKeyID]
+	headerJSON, err := json.Marshal(header{Algorithm: key.Algorithm, Type: "JWT", KeyID: key.ID})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encoding.EncodeToString(headerJSON) + "." + encoding.EncodeToString(claimsJSON)
+	signature, err := key.sign([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + encoding.EncodeToString(signature), nil
+}
+
+// Verify는 kid의 키로 서명을 확인하고 now 기준으로 만료를 확인합니다. issuer가 있으면 iss도 같아야 합니다.
+// 헤더의 alg는 키의 알고리즘과 같아야 하므로 "none"이나 RS256 공개 키를 HS256 비밀 키로 쓰는 토큰은 거절합니다.
+func (k *KeySet) Verify(token string, issuer string, now time.Time) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, err
+	}
+	key, ok := k.keys[h.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, h.KeyID)
+	}
+	if h.Algorithm != key.Algorithm {
+		return nil, fmt.Errorf("%w: unexpected alg %q", ErrInvalidToken, h.Algorithm)
+	}
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if err = key.verify([]byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{}
+	if err = decodeSegment(parts[1], claims); err != nil {
+		return nil, err
+	}
+	if issuer != "" && claims.Issuer != issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	if !now.Before(claims.Expiry()) {
+		return nil, ErrTokenExpired
+	}
+	return claims, nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := encoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if err = json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return nil
+}
+
+func (k *Key) sign(signingInput []byte) ([]byte, error) {
+	switch k.Algorithm {
+	case AlgorithmHS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(signingInput)
+		return mac.Sum(nil), nil
+	case AlgorithmRS256:
+		digest := sha256.Sum256(signingInput)
+		return rsa.SignPKCS1v15(rand.Reader, k.private, crypto.SHA256, digest[:])
+	}
+	return nil, fmt.Errorf("unsupported alg %q", k.Algorithm)
+}
+
+func (k *Key) verify(signingInput []byte, signature []byte) error {
+	switch k.Algorithm {
+	case AlgorithmHS256:
+		expected, _ := k.sign(signingInput)
+		if !hmac.Equal(expected, signature) {
+			return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+		}
+		return nil
+	case AlgorithmRS256:
+		digest := sha256.Sum256(signingInput)
+		if err := rsa.VerifyPKCS1v15(k.public, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("%w: signature mismatch", ErrInvalidToken)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: unsupported alg %q", ErrInvalidToken, k.Algorithm)
+}
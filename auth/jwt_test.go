@@ -0,0 +1,187 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = bytes.Repeat([]byte("s"), minSecretSize)
+
+func generateRSAKey(t *testing.T) (privatePEM []byte, publicPEM []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+}
+
+func testClaims(now time.Time) *Claims {
+	return &Claims{
+		Issuer:    "test",
+		Subject:   "user-1",
+		ID:        "token-1",
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Minute).Unix(),
+		TokenType: TokenTypeAccess,
+		TenantID:  "tenant-a",
+		Roles:     []string{"admin"},
+	}
+}
+
+func TestKeySet_SignAndVerify(t *testing.T) {
+	// 테스트 설정
+	privatePEM, _ := generateRSAKey(t)
+	now := time.Now()
+
+	for _, keys := range []map[string][]byte{{"hs": testSecret}, {"rs": privatePEM}} {
+		var activeKeyID string
+		for keyID := range keys {
+			activeKeyID = keyID
+		}
+		set, err := NewKeySet(activeKeyID, keys)
+		require.NoError(t, err)
+
+		// 테스트 실행
+		token, err := set.Sign(testClaims(now))
+		require.NoError(t, err)
+		claims, err := set.Verify(token, "test", now)
+
+		// 검증
+		require.NoError(t, err, activeKeyID)
+		assert.Equal(t, testClaims(now), claims, activeKeyID)
+	}
+}
+
+func TestKeySet_VerifyRotatedKey(t *testing.T) {
+	// 테스트 설정 - old로 서명한 토큰을 new가 활성 키인 키 셋으로 검증합니다.
+	now := time.Now()
+	newSecret := bytes.Repeat([]byte("n"), minSecretSize)
+	old, err := NewKeySet("old", map[string][]byte{"old": testSecret})
+	require.NoError(t, err)
+	token, err := old.Sign(testClaims(now))
+	require.NoError(t, err)
+
+	rotated, err := NewKeySet("new", map[string][]byte{"old": testSecret, "new": newSecret})
+	require.NoError(t, err)
+	removed, err := NewKeySet("new", map[string][]byte{"new": newSecret})
+	require.NoError(t, err)
+
+	// 테스트 실행
+	_, rotatedErr := rotated.Verify(token, "", now)
+	_, removedErr := removed.Verify(token, "", now)
+
+	// 검증
+	assert.NoError(t, rotatedErr)
+	assert.ErrorIs(t, removedErr, ErrUnknownKey)
+}
+
+func TestKeySet_VerifyRejectsInvalidTokens(t *testing.T) {
+	// 테스트 설정
+	privatePEM, publicPEM := generateRSAKey(t)
+	now := time.Now()
+	set, err := NewKeySet("hs", map[string][]byte{"hs": testSecret})
+	require.NoError(t, err)
+	token, err := set.Sign(testClaims(now))
+	require.NoError(t, err)
+	parts := strings.Split(token, ".")
+
+	// RS256 공개 키를 HS256 비밀 키로 쓴 토큰
+	rsSet, err := NewKeySet("rs", map[string][]byte{"rs": privatePEM})
+	require.NoError(t, err)
+	verifyOnly := &KeySet{activeKeyID: "rs", keys: map[string]*Key{"rs": {ID: "rs", Algorithm: AlgorithmHS256, secret: publicPEM}}}
+	confused, err := verifyOnly.Sign(testClaims(now))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		set      *KeySet
+		token    string
+		issuer   string
+		now      time.Time
+		expected error
+	}{
+		{"malformed", set, "abc", "", now, ErrInvalidToken},
+		{"tampered claims", set, parts[0] + "." + encoding.EncodeToString([]byte(`{"sub":"other","exp":9999999999}`)) + "." + parts[2], "", now, ErrInvalidToken},
+		{"alg none", set, encoding.EncodeToString([]byte(`{"alg":"none","kid":"hs"}`)) + "." + parts[1] + ".", "", now, ErrInvalidToken},
+		{"alg confusion", rsSet, confused, "", now, ErrInvalidToken},
+		{"wrong issuer", set, token, "other", now, ErrInvalidToken},
+		{"expired", set, token, "", now.Add(time.Minute), ErrTokenExpired},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행
+		_, err := tt.set.Verify(tt.token, tt.issuer, tt.now)
+
+		// 검증
+		assert.ErrorIs(t, err, tt.expected, tt.name)
+	}
+}
+
+func TestNewKeySet_Errors(t *testing.T) {
+	// 테스트 설정
+	_, publicPEM := generateRSAKey(t)
+
+	// 테스트 실행
+	_, shortSecret := NewKeySet("hs", map[string][]byte{"hs": []byte("short")})
+	_, missingActive := NewKeySet("other", map[string][]byte{"hs": testSecret})
+	_, publicActive := NewKeySet("rs", map[string][]byte{"rs": publicPEM})
+	verifyOnly, publicInactive := NewKeySet("hs", map[string][]byte{"hs": testSecret, "rs": publicPEM})
+
+	// 검증
+	assert.Error(t, shortSecret)
+	assert.Error(t, missingActive)
+	assert.Error(t, publicActive)
+	assert.NoError(t, publicInactive)
+	key, ok := verifyOnly.Key("rs")
+	assert.True(t, ok)
+	assert.Equal(t, AlgorithmRS256, key.Algorithm)
+}
+
+func TestLoadKeySet(t *testing.T) {
+	// 테스트 설정
+	privatePEM, _ := generateRSAKey(t)
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret"), append(testSecret, '\n'), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "rsa.pem"), privatePEM, 0o600))
+	t.Setenv("JWT_KEYS", "k1:"+filepath.Join(dir, "secret")+", k2:"+filepath.Join(dir, "rsa.pem"))
+	t.Setenv("JWT_ACTIVE_KEY", "k2")
+
+	// 테스트 실행
+	set, err := LoadKeySet()
+
+	// 검증
+	require.NoError(t, err)
+	assert.Equal(t, "k2", set.ActiveKeyID())
+	key, ok := set.Key("k1")
+	assert.True(t, ok)
+	assert.Equal(t, AlgorithmHS256, key.Algorithm)
+
+	t.Setenv("JWT_KEYS", "")
+	set, err = LoadKeySet()
+	assert.NoError(t, err)
+	assert.Nil(t, set)
+}
+
+func TestCheckPassword(t *testing.T) {
+	// 테스트 설정
+	hash, err := HashPassword("correct horse")
+	require.NoError(t, err)
+
+	// 검증
+	assert.True(t, CheckPassword(hash, "correct horse"))
+	assert.False(t, CheckPassword(hash, "wrong"))
+	assert.False(t, CheckPassword("", "correct horse"))
+}
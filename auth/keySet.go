@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+
+	// minSecretSize는 HS256 비밀 키의 최소 길이입니다. (RFC 7518 3.2)
+	minSecretSize = 32
+)
+
+var ErrKeySetNotConfigured = errors.New("jwt key set is not configured")
+
+// Key는 kid 하나의 서명 키입니다. HS256은 secret, RS256은 RSA 키를 씁니다.
+// RS256 공개 키만 있으면 검증에만 쓸 수 있습니다.
+type Key struct {
+	ID        string
+	Algorithm string
+
+	secret  []byte
+	private *rsa.PrivateKey
+	public  *rsa.PublicKey
+}
+
+// KeySet은 kid별 서명 키이고, 새 토큰은 활성 키로만 서명합니다.
+// 활성 키를 바꿔도 예전 키를 남겨두면 그 키로 서명한 토큰은 만료될 때까지 검증됩니다.
+type KeySet struct {
+	activeKeyID string
+	keys        map[string]*Key
+}
+
+// NewKeySet은 kid별 키 파일 내용으로 키 셋을 만듭니다. PEM RSA 개인 키(또는 공개 키)면 RS256, 그 밖의 내용은 HS256 비밀 키입니다.
+func NewKeySet(activeKeyID string, keys map[string][]byte) (*KeySet, error) {
+	set := &KeySet{activeKeyID: activeKeyID, keys: make(map[string]*Key, len(keys))}
+	for keyID, data := range keys {
+		if keyID == "" {
+			return nil, errors.New("jwt key id must not be empty")
+		}
+		key, err := parseKey(keyID, data)
+		if err != nil {
+			return nil, err
+		}
+		set.keys[keyID] = key
+	}
+
+	active, ok := set.keys[activeKeyID]
+	if !ok {
+		return nil, fmt.Errorf("active jwt key %q is not in the key set", activeKeyID)
+	}
+	if active.Algorithm == AlgorithmRS256 && active.private == nil {
+		return nil, fmt.Errorf("active jwt key %q is a public key and cannot sign", activeKeyID)
+	}
+
+	return set, nil
+}
+
+// LoadKeySet은 JWT_KEYS("kid1:/path/to/key.pem,kid2:/path/to/secret")의 파일과 JWT_ACTIVE_KEY를 읽습니다.
+// JWT_KEYS가 비어 있으면 nil을 돌려줍니다.
+func LoadKeySet() (*KeySet, error) {
+	raw := strings.TrimSpace(os.Getenv("JWT_KEYS"))
+	if raw == "" {
+		return nil, nil
+	}
+
+	keys := map[string][]byte{}
+	for _, entry := range strings.Split(raw, ",") {
+		keyID, path, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("JWT_KEYS entry %q must be <kid>:<key file>", entry)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwt key %q: %w", keyID, err)
+		}
+		keys[keyID] = data
+	}
+
+	return NewKeySet(os.Getenv("JWT_ACTIVE_KEY"), keys)
+}
+
+func parseKey(keyID string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		secret := []byte(strings.TrimSpace(string(data)))
+		if len(secret) < minSecretSize {
+			return nil, fmt.Errorf("jwt key %q: HS256 secret must be at least %d bytes", keyID, minSecretSize)
+		}
+		return &Key{ID: keyID, Algorithm: AlgorithmHS256, secret: secret}, nil
+	}
+
+	key := &Key{ID: keyID, Algorithm: AlgorithmRS256}
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		private, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", keyID, err)
+		}
+		key.private, key.public = private, &private.PublicKey
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", keyID, err)
+		}
+		private, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt key %q: only RSA private keys are supported", keyID)
+		}
+		key.private, key.public = private, &private.PublicKey
+	case "PUBLIC KEY":
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", keyID, err)
+		}
+		public, ok := parsed.(*rsa.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt key %q: only RSA public keys are supported", keyID)
+		}
+		key.public = public
+	default:
+		return nil, fmt.Errorf("jwt key %q: unsupported PEM block %q", keyID, block.Type)
+	}
+	return key, nil
+}
+
+func (k *KeySet) ActiveKeyID() string {
+	return k.activeKeyID
+}
+
+// Key는 kid의 키입니다.
+func (k *KeySet) Key(keyID string) (*Key, bool) {
+	key, ok := k.keys[keyID]
+	return key, ok
+}
@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRolesClaim  = "roles"
+	defaultTenantClaim = "tenant_id"
+	// oidcLeeway는 IdP와 이 서버의 시계 차이를 허용하는 범위입니다.
+	oidcLeeway = 30 * time.Second
+)
+
+// OIDCVerifier는 외부 IdP가 발급한 토큰을 JWKS로 확인합니다.
+type OIDCVerifier struct {
+	Keys     *JWKS
+	Issuer   string
+	Audience string
+	// RolesClaim과 TenantClaim은 역할과 테넌트를 읽을 클레임입니다. "realm_access.roles"처럼 점으로 중첩된 클레임을 가리킬 수 있습니다.
+	RolesClaim  string
+	TenantClaim string
+}
+
+// LoadOIDCVerifier는 OIDC_ISSUER, OIDC_AUDIENCE, OIDC_JWKS(URL 또는 파일 경로)로 검증기를 만듭니다.
+// OIDC_ISSUER가 비어 있으면 nil이고 OIDC 토큰을 받지 않습니다. OIDC_JWKS가 비어 있으면 발급자의 discovery 문서에서 찾습니다.
+func LoadOIDCVerifier(ctx context.Context) (*OIDCVerifier, error) {
+	issuer := os.Getenv("OIDC_ISSUER")
+	if issuer == "" {
+		return nil, nil
+	}
+	audience := os.Getenv("OIDC_AUDIENCE")
+	if audience == "" {
+		return nil, fmt.Errorf("OIDC_AUDIENCE is required when OIDC_ISSUER is set")
+	}
+
+	source := os.Getenv("OIDC_JWKS")
+	keys := NewJWKS(source, durationEnv("OIDC_JWKS_REFRESH", defaultJWKSRefreshInterval))
+	if source == "" {
+		var err error
+		if keys.Source, err = discoverJWKS(ctx, keys, issuer); err != nil {
+			return nil, err
+		}
+	}
+
+	return &OIDCVerifier{
+		Keys:        keys,
+		Issuer:      issuer,
+		Audience:    audience,
+		RolesClaim:  envOr("OIDC_ROLES_CLAIM", defaultRolesClaim),
+		TenantClaim: envOr("OIDC_TENANT_CLAIM", defaultTenantClaim),
+	}, nil
+}
+
+func discoverJWKS(ctx context.Context, keys *JWKS, issuer string) (string, error) {
+	discovery := &JWKS{Source: strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration", Client: keys.Client}
+	data, err := discovery.read(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to discover jwks: %w", err)
+	}
+	var configuration struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err = json.Unmarshal(data, &configuration); err != nil || configuration.JWKSURI == "" {
+		return "", fmt.Errorf("failed to discover jwks: no jwks_uri in %s", discovery.Source)
+	}
+	return configuration.JWKSURI, nil
+}
+
+func envOr(name string, fallback string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// TokenIssuer는 서명을 확인하지 않고 토큰의 iss를 읽습니다. 어느 검증기로 확인할지 고를 때만 씁니다.
+func TokenIssuer(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if decodeSegment(parts[1], &claims) != nil {
+		return ""
+	}
+	return claims.Issuer
+}
+
+// Verify는 서명, 발급자, 대상, 유효 기간을 확인하고 클레임을 요청 주체로 옮깁니다.
+func (v *OIDCVerifier) Verify(ctx context.Context, token string, now time.Time) (*Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, err
+	}
+	key, err := v.Keys.Key(ctx, h.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	if h.Algorithm != key.Algorithm {
+		return nil, fmt.Errorf("%w: unexpected alg %q", ErrInvalidToken, h.Algorithm)
+	}
+	signature, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if err = key.verify([]byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	claims := map[string]interface{}{}
+	if err = decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if issuer, _ := claims["iss"].(string); issuer != v.Issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, issuer)
+	}
+	if !containsAudience(claims["aud"], v.Audience) {
+		return nil, fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	expiresAt, ok := numericDate(claims["exp"])
+	if !ok {
+		return nil, fmt.Errorf("%w: missing exp", ErrInvalidToken)
+	}
+	if !now.Add(-oidcLeeway).Before(expiresAt) {
+		return nil, ErrTokenExpired
+	}
+	if notBefore, ok := numericDate(claims["nbf"]); ok && now.Add(oidcLeeway).Before(notBefore) {
+		return nil, fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidToken)
+	}
+	tokenID, _ := claims["jti"].(string)
+	// 테넌트가 없는 토큰을 받으면 Tenant 미들웨어가 요청의 테넌트를 정할 수 없으므로 여기서 거절합니다.
+	tenantID, _ := claimPath(claims, v.TenantClaim).(string)
+	if tenantID == "" {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidToken, v.TenantClaim)
+	}
+
+	return &Principal{
+		UserID:    subject,
+		TenantID:  tenantID,
+		Roles:     stringList(claimPath(claims, v.RolesClaim)),
+		TokenID:   tokenID,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// containsAudience는 aud가 문자열이거나 문자열 배열인 경우를 모두 받습니다.
+func containsAudience(aud interface{}, audience string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, value := range aud {
+			if value == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func numericDate(value interface{}) (time.Time, bool) {
+	seconds, ok := value.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(seconds), 0), true
+}
+
+func claimPath(claims map[string]interface{}, path string) interface{} {
+	var value interface{} = claims
+	for _, name := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[name]
+	}
+	return value
+}
+
+// stringList는 배열과 공백으로 구분한 문자열("editor viewer")을 모두 받습니다.
+func stringList(value interface{}) []string {
+	switch value := value.(type) {
+	case string:
+		return strings.Fields(value)
+	case []interface{}:
+		list := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	}
+	return nil
+}
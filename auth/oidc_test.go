@@ -0,0 +1,298 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOIDCIssuer   = "https://idp.example.com"
+	testOIDCAudience = "products-api"
+)
+
+// idpStub는 JWKS를 내려주는 IdP 대역입니다. keys를 바꾸면 키 교체를 흉내 낼 수 있습니다.
+type idpStub struct {
+	server *httptest.Server
+	keys   atomic.Pointer[[]*Key]
+	hits   atomic.Int32
+}
+
+func newIDPStub(t *testing.T, keys ...*Key) *idpStub {
+	stub := &idpStub{}
+	stub.keys.Store(&keys)
+	stub.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stub.hits.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(jwksJSON(t, *stub.keys.Load()...))
+	}))
+	t.Cleanup(stub.server.Close)
+	return stub
+}
+
+func newOIDCKey(t *testing.T, keyID string) *Key {
+	t.Helper()
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return &Key{ID: keyID, Algorithm: AlgorithmRS256, private: private, public: &private.PublicKey}
+}
+
+func jwksJSON(t *testing.T, keys ...*Key) []byte {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	for _, key := range keys {
+		set.Keys = append(set.Keys, jsonWebKey{
+			KeyType:   "RSA",
+			KeyID:     key.ID,
+			Use:       "sig",
+			Algorithm: AlgorithmRS256,
+			N:         encoding.EncodeToString(key.public.N.Bytes()),
+			E:         encoding.EncodeToString(big.NewInt(int64(key.public.E)).Bytes()),
+		})
+	}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	return data
+}
+
+func signOIDCToken(t *testing.T, key *Key, claims map[string]interface{}) string {
+	t.Helper()
+	headerJSON, err := json.Marshal(header{Algorithm: key.Algorithm, Type: "JWT", KeyID: key.ID})
+	require.NoError(t, err)
+	claimsJSON, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signingInput := encoding.EncodeToString(headerJSON) + "." + encoding.EncodeToString(claimsJSON)
+	signature, err := key.sign([]byte(signingInput))
+	require.NoError(t, err)
+	return signingInput + "." + encoding.EncodeToString(signature)
+}
+
+func oidcClaims(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":          testOIDCIssuer,
+		"aud":          []string{"other", testOIDCAudience},
+		"sub":          "user-1",
+		"exp":          now.Add(time.Minute).Unix(),
+		"tenant_id":    "tenant-a",
+		"realm_access": map[string]interface{}{"roles": []string{"editor"}},
+	}
+}
+
+func newTestVerifier(source string) *OIDCVerifier {
+	return &OIDCVerifier{
+		Keys:        NewJWKS(source, time.Hour),
+		Issuer:      testOIDCIssuer,
+		Audience:    testOIDCAudience,
+		RolesClaim:  "realm_access.roles",
+		TenantClaim: defaultTenantClaim,
+	}
+}
+
+func TestOIDCVerifier_Verify(t *testing.T) {
+	// 테스트 설정
+	key := newOIDCKey(t, "k1")
+	idp := newIDPStub(t, key)
+	verifier := newTestVerifier(idp.server.URL)
+	now := time.Now()
+
+	// 테스트 실행
+	principal, err := verifier.Verify(context.Background(), signOIDCToken(t, key, oidcClaims(now)), now)
+	_, again := verifier.Verify(context.Background(), signOIDCToken(t, key, oidcClaims(now)), now)
+
+	// 검증 - 두 번째 검증은 캐시된 키를 씁니다.
+	require.NoError(t, err)
+	require.NoError(t, again)
+	assert.Equal(t, "user-1", principal.UserID)
+	assert.Equal(t, "tenant-a", principal.TenantID)
+	assert.Equal(t, []string{"editor"}, principal.Roles)
+	assert.Equal(t, int32(1), idp.hits.Load())
+}
+
+func TestOIDCVerifier_Verify_Rejected(t *testing.T) {
+	// 테스트 설정
+	key := newOIDCKey(t, "k1")
+	verifier := newTestVerifier(newIDPStub(t, key).server.URL)
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		mutate func(claims map[string]interface{})
+		signer *Key
+	}{
+		{"wrong issuer", func(claims map[string]interface{}) { claims["iss"] = "https://evil.example.com" }, key},
+		{"wrong audience", func(claims map[string]interface{}) { claims["aud"] = "other" }, key},
+		{"missing audience", func(claims map[string]interface{}) { delete(claims, "aud") }, key},
+		{"expired", func(claims map[string]interface{}) { claims["exp"] = now.Add(-time.Minute).Unix() }, key},
+		{"missing exp", func(claims map[string]interface{}) { delete(claims, "exp") }, key},
+		{"not yet valid", func(claims map[string]interface{}) { claims["nbf"] = now.Add(time.Minute).Unix() }, key},
+		{"missing tenant", func(claims map[string]interface{}) { delete(claims, "tenant_id") }, key},
+		{"empty tenant", func(claims map[string]interface{}) { claims["tenant_id"] = "" }, key},
+		{"forged signature", func(claims map[string]interface{}) {}, newOIDCKey(t, "k1")},
+	}
+
+	for _, tt := range tests {
+		claims := oidcClaims(now)
+		tt.mutate(claims)
+
+		// 테스트 실행
+		_, err := verifier.Verify(context.Background(), signOIDCToken(t, tt.signer, claims), now)
+
+		// 검증
+		assert.Error(t, err, tt.name)
+	}
+}
+
+func TestOIDCVerifier_Verify_KeyRotation(t *testing.T) {
+	// 테스트 설정
+	oldKey, newKey := newOIDCKey(t, "k1"), newOIDCKey(t, "k2")
+	idp := newIDPStub(t, oldKey)
+	verifier := newTestVerifier(idp.server.URL)
+	now := time.Now()
+	_, err := verifier.Verify(context.Background(), signOIDCToken(t, oldKey, oidcClaims(now)), now)
+	require.NoError(t, err)
+
+	// 테스트 실행 - IdP가 키를 바꾼 뒤 새 kid로 서명한 토큰이 옵니다.
+	idp.keys.Store(&[]*Key{newKey})
+	_, throttled := verifier.Verify(context.Background(), signOIDCToken(t, newKey, oidcClaims(now)), now)
+	verifier.Keys.fetchedAt = time.Now().Add(-jwksRefetchInterval)
+	_, err = verifier.Verify(context.Background(), signOIDCToken(t, newKey, oidcClaims(now)), now)
+
+	// 검증 - 방금 받은 JWKS는 바로 다시 받지 않고, 잠시 뒤 모르는 kid가 오면 다시 받습니다.
+	assert.ErrorIs(t, throttled, ErrUnknownKey)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), idp.hits.Load())
+}
+
+func TestOIDCVerifier_Verify_KeepsKeysWhenRefreshFails(t *testing.T) {
+	// 테스트 설정
+	key := newOIDCKey(t, "k1")
+	idp := newIDPStub(t, key)
+	verifier := newTestVerifier(idp.server.URL)
+	now := time.Now()
+	_, err := verifier.Verify(context.Background(), signOIDCToken(t, key, oidcClaims(now)), now)
+	require.NoError(t, err)
+
+	// 테스트 실행 - 갱신할 때가 되었지만 IdP가 내려가 있습니다.
+	idp.server.Close()
+	verifier.Keys.fetchedAt = time.Now().Add(-2 * time.Hour)
+	_, err = verifier.Verify(context.Background(), signOIDCToken(t, key, oidcClaims(now)), now)
+
+	// 검증
+	assert.NoError(t, err)
+}
+
+func TestJWKS_Key_SingleFetch(t *testing.T) {
+	// 테스트 설정 - IdP가 느리게 응답하는 동안 여러 요청이 처음으로 키를 찾습니다.
+	key := newOIDCKey(t, "k1")
+	release := make(chan struct{})
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.Write(jwksJSON(t, key))
+	}))
+	t.Cleanup(server.Close)
+	jwks := NewJWKS(server.URL, time.Hour)
+
+	// 테스트 실행
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := jwks.Key(context.Background(), "k1")
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	// 검증 - JWKS는 한 번만 받습니다.
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), hits.Load())
+}
+
+func TestJWKS_Key_DoesNotBlockDuringRefresh(t *testing.T) {
+	// 테스트 설정 - 모르는 kid 때문에 다시 받는 중에 IdP가 멈춥니다.
+	key := newOIDCKey(t, "k1")
+	release := make(chan struct{})
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) > 1 {
+			<-release
+		}
+		w.Write(jwksJSON(t, key))
+	}))
+	t.Cleanup(server.Close)
+	// Cleanup은 역순으로 돌기 때문에 서버를 닫기 전에 멈춘 응답을 먼저 풀어줍니다.
+	t.Cleanup(func() { close(release) })
+	jwks := NewJWKS(server.URL, time.Hour)
+	_, err := jwks.Key(context.Background(), "k1")
+	require.NoError(t, err)
+	jwks.fetchedAt = time.Now().Add(-jwksRefetchInterval)
+	go jwks.Key(context.Background(), "unknown")
+	require.Eventually(t, func() bool { return hits.Load() == 2 }, time.Second, 5*time.Millisecond)
+
+	// 테스트 실행
+	done := make(chan error, 1)
+	go func() {
+		_, err := jwks.Key(context.Background(), "k1")
+		done <- err
+	}()
+
+	// 검증 - 이미 받은 키는 기다리지 않고 바로 돌려줍니다.
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Key blocked while the JWKS was being refreshed")
+	}
+}
+
+func TestOIDCVerifier_Verify_FileSource(t *testing.T) {
+	// 테스트 설정
+	key := newOIDCKey(t, "k1")
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, jwksJSON(t, key), 0o600))
+	verifier := newTestVerifier(path)
+	verifier.RolesClaim = "scope"
+	now := time.Now()
+	claims := oidcClaims(now)
+	claims["aud"] = testOIDCAudience
+	claims["scope"] = "viewer editor"
+
+	// 테스트 실행
+	principal, err := verifier.Verify(context.Background(), signOIDCToken(t, key, claims), now)
+
+	// 검증 - aud는 문자열, 역할은 공백으로 구분한 문자열도 받습니다.
+	require.NoError(t, err)
+	assert.Equal(t, []string{"viewer", "editor"}, principal.Roles)
+}
+
+func TestTokenIssuer(t *testing.T) {
+	// 테스트 설정
+	key := newOIDCKey(t, "k1")
+
+	// 테스트 실행 및 검증
+	assert.Equal(t, testOIDCIssuer, TokenIssuer(signOIDCToken(t, key, oidcClaims(time.Now()))))
+	assert.Empty(t, TokenIssuer("not-a-token"))
+}
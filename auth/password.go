@@ -0,0 +1,25 @@
+package auth
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+// dummyHash는 없는 사용자로 로그인할 때도 bcrypt 비교를 한 번 해서 응답 시간으로 사용자 존재 여부를 알 수 없게 합니다.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword는 hash가 비어 있으면(없는 사용자) 더미 해시와 비교하고 false를 돌려줍니다.
+func CheckPassword(hash string, password string) bool {
+	if hash == "" {
+		_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
@@ -0,0 +1,58 @@
+package auth
+
+import "strings"
+
+// 권한은 "<리소스>:<동작>" 형식입니다. 역할은 정책 파일에서 권한 목록으로 정의하고, API 키는 범위(scopes)로 권한을 직접 가집니다.
+const (
+	PermissionProductRead   = "product:read"
+	PermissionProductWrite  = "product:write"
+	PermissionProductDelete = "product:delete"
+	PermissionReportRead    = "report:read"
+	PermissionWebhookRead   = "webhook:read"
+	PermissionWebhookWrite  = "webhook:write"
+)
+
+// Permissions는 라우트가 요구할 수 있는 권한입니다. API 키의 범위도 이 중에서 고릅니다.
+var Permissions = []string{
+	PermissionProductRead,
+	PermissionProductWrite,
+	PermissionProductDelete,
+	PermissionReportRead,
+	PermissionWebhookRead,
+	PermissionWebhookWrite,
+}
+
+func ValidPermission(permission string) bool {
+	for _, p := range Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPermission은 정책의 권한(pattern)이 permission을 포함하는지 확인합니다. "*"는 모든 권한, "product:*"는 product의 모든 동작입니다.
+func matchPermission(pattern string, permission string) bool {
+	if pattern == "*" || pattern == permission {
+		return true
+	}
+	resource, ok := strings.CutSuffix(pattern, ":*")
+	return ok && strings.HasPrefix(permission, resource+":")
+}
+
+// validPattern은 정책 파일에 쓸 수 있는 권한인지 확인합니다.
+func validPattern(pattern string) bool {
+	if pattern == "*" || ValidPermission(pattern) {
+		return true
+	}
+	resource, ok := strings.CutSuffix(pattern, ":*")
+	if !ok {
+		return false
+	}
+	for _, p := range Permissions {
+		if strings.HasPrefix(p, resource+":") {
+			return true
+		}
+	}
+	return false
+}
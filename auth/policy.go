@@ -0,0 +1,96 @@
+package auth
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// defaultPolicy는 RBAC_POLICY_FILE이 없을 때 쓰는 정책입니다.
+//
+//go:embed policy.json
+var defaultPolicy []byte
+
+// Policy는 역할별 권한입니다. 정책 파일은 {"roles": {"<역할>": ["<권한>", ...]}} 형식입니다.
+type Policy struct {
+	Roles map[string][]string `json:"roles"`
+}
+
+func ParsePolicy(data []byte) (*Policy, error) {
+	policy := &Policy{}
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, fmt.Errorf("invalid rbac policy: %w", err)
+	}
+	for role, permissions := range policy.Roles {
+		for _, permission := range permissions {
+			if !validPattern(permission) {
+				return nil, fmt.Errorf("invalid rbac policy: role %q has unknown permission %q", role, permission)
+			}
+		}
+	}
+	return policy, nil
+}
+
+// Allows는 principal이 permission을 가졌는지 확인합니다. 사용자는 역할의 권한을, API 키는 범위를 봅니다.
+func (p *Policy) Allows(principal *Principal, permission string) bool {
+	if principal.APIKeyID != "" {
+		for _, scope := range principal.Scopes {
+			if scope == permission {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, role := range principal.Roles {
+		for _, pattern := range p.Roles[role] {
+			if matchPermission(pattern, permission) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PolicyStore는 정책 파일을 다시 읽을 수 있게 현재 정책을 들고 있습니다. 요청 처리 중에도 Reload할 수 있습니다.
+type PolicyStore struct {
+	path    string
+	current atomic.Pointer[Policy]
+}
+
+// LoadPolicyStore는 RBAC_POLICY_FILE을 읽습니다. 비어 있으면 내장 기본 정책을 씁니다.
+func LoadPolicyStore() (*PolicyStore, error) {
+	return NewPolicyStore(os.Getenv("RBAC_POLICY_FILE"))
+}
+
+func NewPolicyStore(path string) (*PolicyStore, error) {
+	store := &PolicyStore{path: path}
+	if err := store.Reload(); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Reload는 정책 파일을 다시 읽습니다. 파일이 잘못되었으면 오류를 돌려주고 이전 정책을 그대로 씁니다.
+func (s *PolicyStore) Reload() error {
+	data := defaultPolicy
+	if s.path != "" {
+		var err error
+		if data, err = os.ReadFile(s.path); err != nil {
+			return fmt.Errorf("failed to read rbac policy: %w", err)
+		}
+	}
+
+	policy, err := ParsePolicy(data)
+	if err != nil {
+		return err
+	}
+	s.current.Store(policy)
+	return nil
+}
+
+func (s *PolicyStore) Allows(principal *Principal, permission string) bool {
+	return s.current.Load().Allows(principal, permission)
+}
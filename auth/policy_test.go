@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicy_Allows(t *testing.T) {
+	// 테스트 설정
+	policy, err := ParsePolicy([]byte(`{"roles": {
+		"viewer": ["product:read"],
+		"manager": ["product:*"],
+		"admin": ["*"]
+	}}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		principal  *Principal
+		permission string
+		expected   bool
+	}{
+		{"viewer reads", &Principal{Roles: []string{"viewer"}}, PermissionProductRead, true},
+		{"viewer deletes", &Principal{Roles: []string{"viewer"}}, PermissionProductDelete, false},
+		{"resource wildcard", &Principal{Roles: []string{"manager"}}, PermissionProductDelete, true},
+		{"resource wildcard other resource", &Principal{Roles: []string{"manager"}}, PermissionWebhookRead, false},
+		{"wildcard", &Principal{Roles: []string{"admin"}}, PermissionWebhookWrite, true},
+		{"any of roles", &Principal{Roles: []string{"unknown", "viewer"}}, PermissionProductRead, true},
+		{"no roles", &Principal{}, PermissionProductRead, false},
+		// API 키는 역할이 아니라 범위를 봅니다.
+		{"api key scope", &Principal{APIKeyID: "k", Scopes: []string{PermissionProductRead}}, PermissionProductRead, true},
+		{"api key ignores roles", &Principal{APIKeyID: "k", Roles: []string{"admin"}}, PermissionProductRead, false},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행
+		allowed := policy.Allows(tt.principal, tt.permission)
+
+		// 검증
+		assert.Equal(t, tt.expected, allowed, tt.name)
+	}
+}
+
+func TestParsePolicy_Invalid(t *testing.T) {
+	for _, data := range []string{`{`, `{"roles": {"r": ["product:fly"]}}`, `{"roles": {"r": ["nothing:*"]}}`} {
+		// 테스트 실행
+		_, err := ParsePolicy([]byte(data))
+
+		// 검증
+		assert.Error(t, err, data)
+	}
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	// 테스트 실행
+	store, err := NewPolicyStore("")
+
+	// 검증
+	require.NoError(t, err)
+	assert.True(t, store.Allows(&Principal{Roles: []string{"editor"}}, PermissionProductWrite))
+	assert.False(t, store.Allows(&Principal{Roles: []string{"editor"}}, PermissionProductDelete))
+}
+
+func TestPolicyStore_Reload(t *testing.T) {
+	// 테스트 설정
+	path := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"roles": {"editor": ["product:read"]}}`), 0o600))
+	store, err := NewPolicyStore(path)
+	require.NoError(t, err)
+	editor := &Principal{Roles: []string{"editor"}}
+	assert.False(t, store.Allows(editor, PermissionProductWrite))
+
+	// 테스트 실행 - 권한을 추가한 뒤 다시 읽고, 잘못된 파일은 무시합니다.
+	require.NoError(t, os.WriteFile(path, []byte(`{"roles": {"editor": ["product:read", "product:write"]}}`), 0o600))
+	reloadErr := store.Reload()
+	allowedAfterReload := store.Allows(editor, PermissionProductWrite)
+	require.NoError(t, os.WriteFile(path, []byte(`{"roles": {"editor": ["product:fly"]}}`), 0o600))
+	invalidErr := store.Reload()
+
+	// 검증
+	assert.NoError(t, reloadErr)
+	assert.True(t, allowedAfterReload)
+	assert.Error(t, invalidErr)
+	assert.True(t, store.Allows(editor, PermissionProductWrite))
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+type principalKey struct{}
+
+// Principal은 인증된 요청의 주체입니다. 인증 미들웨어가 요청 컨텍스트에 넣습니다.
+type Principal struct {
+	UserID   string
+	TenantID string
+	Roles    []string
+	// TokenID와 ExpiresAt은 인증에 쓴 access 토큰의 jti와 만료 시각입니다. 로그아웃할 때 이 토큰을 폐기합니다.
+	TokenID   string
+	ExpiresAt time.Time
+	// APIKeyID는 API 키로 인증했을 때만 있습니다. 이때는 역할 대신 Scopes의 권한만 가집니다.
+	APIKeyID string
+	Scopes   []string
+}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -1,15 +1,33 @@
 package cmd
 
 import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/controller"
 	"Go-Gin-Basic-Template/database"
+	"Go-Gin-Basic-Template/grpcHandler"
+	"Go-Gin-Basic-Template/logging"
+	productv1 "Go-Gin-Basic-Template/proto/product/v1"
+	"Go-Gin-Basic-Template/repository"
 	"Go-Gin-Basic-Template/router"
+	"Go-Gin-Basic-Template/webhook"
+	"context"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+const webhookDeliveryBatch = 100
+
 type Cmd struct {
 	router *router.Router
 }
 
 func NewCmd() {
+	logging.Setup()
+
 	db, err := database.InitDatabase()
 	if err != nil {
 		panic(err)
@@ -24,9 +42,124 @@ func NewCmd() {
 		panic(err)
 	}
 
+	go c.purgeIdempotencyKeys(c.router.IdempotencyRepository, time.Hour)
+	go c.purgeRevokedTokens(c.router.AuthController.RevokedTokenRepository, time.Hour)
+	if rateLimitRepository, ok := c.router.RateLimitStore.(*repository.RateLimitRepository); ok {
+		go c.purgeRateLimitBuckets(rateLimitRepository, time.Hour)
+	}
+	go c.router.WebhookController.Dispatch(context.Background())
+	go c.deliverWebhooks(c.router.WebhookController, webhook.PollInterval())
+	if interval := database.ReportRefreshInterval(); interval > 0 {
+		go c.refreshReportViews(c.router.ReportRepository, interval)
+	}
+
+	go c.serveGRPC(grpcHandler.Addr())
+	go c.reloadPolicyOnHangup(c.router.Policies)
+
 	c.router.SetupRoutes()
 	err = c.router.ServerStart()
 	if err != nil {
 		panic(err)
 	}
 }
+
+// serveGRPC는 REST와 같은 인증, 테넌트, 권한 규칙으로 gRPC 호출을 받습니다.
+func (c *Cmd) serveGRPC(addr string) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		panic(err)
+	}
+
+	server := grpcHandler.NewServer(&grpcHandler.Authenticator{
+		AuthController:     c.router.AuthController,
+		APIKeyController:   c.router.APIKeyController,
+		OIDCVerifier:       c.router.OIDCVerifier,
+		Policies:           c.router.Policies,
+		TenantRepository:   c.router.TenantRepository,
+		AuditLogRepository: c.router.Authorizer.AuditLogRepository,
+		Permissions:        grpcHandler.ProductPermissions,
+	})
+	productv1.RegisterProductServiceServer(server, &grpcHandler.ProductService{
+		ProductController: c.router.ProductHandler.ProductController,
+	})
+
+	if err = server.Serve(listener); err != nil {
+		panic(err)
+	}
+}
+
+// reloadPolicyOnHangup은 SIGHUP을 받으면 RBAC 정책 파일을 다시 읽습니다. 잘못된 파일이면 이전 정책을 계속 씁니다.
+func (c *Cmd) reloadPolicyOnHangup(policies *auth.PolicyStore) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	for range hangup {
+		if err := policies.Reload(); err != nil {
+			slog.Error("failed to reload rbac policy", "error", err)
+			continue
+		}
+		slog.Info("rbac policy reloaded")
+	}
+}
+
+func (c *Cmd) purgeIdempotencyKeys(idempotencyRepository *repository.IdempotencyRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := idempotencyRepository.PurgeExpired(context.Background()); err != nil {
+			slog.Error("failed to purge idempotency keys", "error", err)
+		}
+	}
+}
+
+func (c *Cmd) purgeRevokedTokens(revokedTokenRepository *repository.RevokedTokenRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := revokedTokenRepository.PurgeExpired(context.Background()); err != nil {
+			slog.Error("failed to purge revoked tokens", "error", err)
+		}
+	}
+}
+
+func (c *Cmd) purgeRateLimitBuckets(rateLimitRepository *repository.RateLimitRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if _, err := rateLimitRepository.PurgeFull(context.Background()); err != nil {
+			slog.Error("failed to purge rate limit buckets", "error", err)
+		}
+	}
+}
+
+func (c *Cmd) refreshReportViews(reportRepository *repository.ReportRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := reportRepository.RefreshViews(context.Background()); err != nil {
+			slog.Error("failed to refresh report views", "error", err)
+		}
+	}
+}
+
+func (c *Cmd) deliverWebhooks(webhookController *controller.WebhookController, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		// 한 번에 가져온 만큼 다 보냈다면 밀린 전송이 더 있을 수 있으니 다음 주기를 기다리지 않고 이어서 보냅니다.
+		for {
+			processed, err := webhookController.DeliverDue(context.Background(), webhookDeliveryBatch)
+			if err != nil {
+				slog.Error("failed to deliver webhooks", "error", err)
+			}
+			if processed < webhookDeliveryBatch {
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"Go-Gin-Basic-Template/database"
+	"Go-Gin-Basic-Template/encryption"
+	"Go-Gin-Basic-Template/repository"
+	"context"
+	"flag"
+	"github.com/joho/godotenv"
+	"log"
+)
+
+// ENCRYPTION_ACTIVE_KEY를 새 키로 바꾼 뒤 실행하면 예전 키로 암호화된 값을 모두 새 키로 다시 암호화합니다.
+// -all이면 활성 키로 암호화된 값도 다시 암호화해서 암호문을 행의 기본 키에 묶습니다.
+func main() {
+	all := flag.Bool("all", false, "re-encrypt values already encrypted with the active key")
+	flag.Parse()
+
+	err := godotenv.Load("./secret/.env")
+	if err != nil {
+		panic(err)
+	}
+
+	db, err := database.InitDatabase()
+	if err != nil {
+		panic(err)
+	}
+
+	productRepository := &repository.ProductRepository{DB: db}
+	updated, err := productRepository.Reencrypt(context.Background(), 500, *all)
+	if err != nil {
+		panic(err)
+	}
+	log.Printf("re-encrypted %d products with key %q", updated, encryption.CurrentKeyRing().ActiveKeyID())
+
+	webhookRepository := &repository.WebhookRepository{DB: db}
+	updated, err = webhookRepository.Reencrypt(context.Background(), 500, *all)
+	if err != nil {
+		panic(err)
+	}
+	log.Printf("re-encrypted %d webhook subscriptions with key %q", updated, encryption.CurrentKeyRing().ActiveKeyID())
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/domainErrors"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidAPIKey = domainErrors.Unauthorized("auth.invalid_api_key", "API 키가 올바르지 않거나 만료되었습니다")
+	ErrInvalidScope  = domainErrors.Validation("api_key.invalid_scope", "지원하지 않는 범위입니다: "+strings.Join(auth.Permissions, ", "))
+	ErrExpiryInPast  = domainErrors.Validation("api_key.expiry_in_past", "만료 시각은 현재보다 뒤여야 합니다")
+)
+
+type APIKeyController struct {
+	APIKeyRepository *repository.APIKeyRepository
+	TenantRepository *repository.TenantRepository
+}
+
+// Insert는 키를 만들고 원문을 한 번만 돌려줍니다. 저장소에는 해시만 남습니다.
+func (c *APIKeyController) Insert(ctx context.Context, input *requestTypes.APIKeyRequest) (statusCode int, apiKey *types.APIKey, key string, err error) {
+	for _, scope := range input.Scopes {
+		if !auth.ValidPermission(scope) {
+			return http.StatusUnprocessableEntity, nil, "", ErrInvalidScope
+		}
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return http.StatusUnprocessableEntity, nil, "", ErrExpiryInPast
+	}
+	exists, err := c.TenantRepository.Exists(ctx, input.TenantID)
+	if err != nil {
+		return http.StatusInternalServerError, nil, "", err
+	}
+	if !exists {
+		return http.StatusNotFound, nil, "", ErrTenantNotFound
+	}
+
+	key, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		return http.StatusInternalServerError, nil, "", err
+	}
+	apiKey, err = c.APIKeyRepository.Insert(ctx, input, prefix, auth.HashAPIKey(key))
+	if err != nil {
+		return http.StatusInternalServerError, nil, "", err
+	}
+
+	return http.StatusCreated, apiKey, key, nil
+}
+
+func (c *APIKeyController) GetAll(ctx context.Context, filter *requestTypes.APIKeyFilter) (statusCode int, apiKeys *[]types.APIKey, err error) {
+	apiKeys, err = c.APIKeyRepository.GetAll(ctx, filter)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	return http.StatusOK, apiKeys, nil
+}
+
+// Rotate는 이름, 범위, 만료는 그대로 두고 새 원문을 발급합니다.
+func (c *APIKeyController) Rotate(ctx context.Context, id string) (statusCode int, apiKey *types.APIKey, key string, err error) {
+	key, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		return http.StatusInternalServerError, nil, "", err
+	}
+	apiKey, err = c.APIKeyRepository.Rotate(ctx, id, prefix, auth.HashAPIKey(key))
+	if err != nil {
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), nil, "", err
+	}
+
+	return http.StatusOK, apiKey, key, nil
+}
+
+func (c *APIKeyController) Revoke(ctx context.Context, id string) (statusCode int, err error) {
+	if err = c.APIKeyRepository.Revoke(ctx, id); err != nil {
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), err
+	}
+
+	return http.StatusOK, nil
+}
+
+// Authenticate는 API 키를 확인해서 요청 주체를 돌려줍니다. 키가 없든 해시가 다르든 같은 오류입니다.
+func (c *APIKeyController) Authenticate(ctx context.Context, key string) (statusCode int, principal *auth.Principal, err error) {
+	prefix, ok := auth.ParseAPIKey(key)
+	if !ok {
+		return http.StatusUnauthorized, nil, ErrInvalidAPIKey
+	}
+	apiKey, err := c.APIKeyRepository.GetByPrefix(ctx, prefix)
+	if domainErrors.Is(err, domainErrors.KindNotFound) {
+		return http.StatusUnauthorized, nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	now := time.Now()
+	if !auth.CheckAPIKey(apiKey.Hash, key) || !apiKey.Usable(now) {
+		return http.StatusUnauthorized, nil, ErrInvalidAPIKey
+	}
+	if err = c.APIKeyRepository.TouchLastUsed(ctx, apiKey.ID, now); err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	principal = &auth.Principal{
+		TenantID: apiKey.TenantID,
+		APIKeyID: apiKey.ID.String(),
+		Scopes:   apiKey.Scopes,
+	}
+	if apiKey.ExpiresAt != nil {
+		principal.ExpiresAt = *apiKey.ExpiresAt
+	}
+	return http.StatusOK, principal, nil
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	apiKeyByPrefixQuery = `SELECT * FROM "api_keys" WHERE prefix = $1`
+	touchAPIKeyExec     = `UPDATE "api_keys" SET "last_used_at"=$1 WHERE id = $2 AND (last_used_at IS NULL OR last_used_at < $3)`
+)
+
+func setupMockAPIKeyController(t *testing.T) (*APIKeyController, sqlmock.Sqlmock) {
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	// GORM 설정
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, PreferSimpleProtocol: true}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, tenancy.Register(db))
+
+	return &APIKeyController{
+		APIKeyRepository: &repository.APIKeyRepository{DB: db},
+		TenantRepository: &repository.TenantRepository{DB: db},
+	}, mock
+}
+
+func expectAPIKey(mock sqlmock.Sqlmock, id uuid.UUID, prefix string, key string, expiresAt *time.Time, revokedAt *time.Time) {
+	mock.ExpectQuery(regexp.QuoteMeta(apiKeyByPrefixQuery)).
+		WithArgs(prefix, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "prefix", "hash", "scopes", "expires_at", "revoked_at"}).
+			AddRow(id, "tenant-a", prefix, auth.HashAPIKey(key), `["product:read"]`, expiresAt, revokedAt))
+}
+
+func TestAPIKeyController_Insert(t *testing.T) {
+	// 테스트 설정
+	c, mock := setupMockAPIKeyController(t)
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "tenants" WHERE id = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("tenant-a"))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "api_keys"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	statusCode, apiKey, key, err := c.Insert(context.Background(), &requestTypes.APIKeyRequest{
+		Name:     "batch",
+		TenantID: "tenant-a",
+		Scopes:   []string{auth.PermissionProductRead},
+	})
+
+	// 검증 - 원문이 아니라 해시를 저장합니다.
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, statusCode)
+	prefix, ok := auth.ParseAPIKey(key)
+	assert.True(t, ok)
+	assert.Equal(t, prefix, apiKey.Prefix)
+	assert.Equal(t, auth.HashAPIKey(key), apiKey.Hash)
+	assert.NotContains(t, apiKey.Hash, key)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyController_Insert_Invalid(t *testing.T) {
+	// 테스트 설정
+	c, mock := setupMockAPIKeyController(t)
+	past := time.Now().Add(-time.Hour)
+
+	// 테스트 실행
+	scopeStatus, _, _, scopeErr := c.Insert(context.Background(), &requestTypes.APIKeyRequest{Name: "batch", TenantID: "tenant-a", Scopes: []string{"product:everything"}})
+	expiryStatus, _, _, expiryErr := c.Insert(context.Background(), &requestTypes.APIKeyRequest{Name: "batch", TenantID: "tenant-a", Scopes: []string{auth.PermissionProductRead}, ExpiresAt: &past})
+
+	// 검증 - DB를 조회하지 않습니다.
+	assert.Equal(t, http.StatusUnprocessableEntity, scopeStatus)
+	assertErrorCode(t, scopeErr, ErrInvalidScope)
+	assert.Equal(t, http.StatusUnprocessableEntity, expiryStatus)
+	assertErrorCode(t, expiryErr, ErrExpiryInPast)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyController_Authenticate(t *testing.T) {
+	// 테스트 설정
+	c, mock := setupMockAPIKeyController(t)
+	id := uuid.New()
+	key, prefix, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+
+	// SQL 쿼리 모의 설정
+	expectAPIKey(mock, id, prefix, key, nil, nil)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(touchAPIKeyExec)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	statusCode, principal, err := c.Authenticate(context.Background(), key)
+
+	// 검증
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, id.String(), principal.APIKeyID)
+	assert.Equal(t, "tenant-a", principal.TenantID)
+	assert.Equal(t, []string{auth.PermissionProductRead}, principal.Scopes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyController_Authenticate_Rejected(t *testing.T) {
+	// 테스트 설정
+	c, mock := setupMockAPIKeyController(t)
+	key, prefix, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Minute)
+
+	// SQL 쿼리 모의 설정 - 다른 비밀, 만료된 키, 폐기된 키, 없는 키 순서입니다.
+	expectAPIKey(mock, uuid.New(), prefix, key+"x", nil, nil)
+	expectAPIKey(mock, uuid.New(), prefix, key, &past, nil)
+	expectAPIKey(mock, uuid.New(), prefix, key, nil, &past)
+	mock.ExpectQuery(regexp.QuoteMeta(apiKeyByPrefixQuery)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	for _, name := range []string{"wrong secret", "expired", "revoked", "unknown", "malformed"} {
+		given := key
+		if name == "malformed" {
+			given = "not-a-key"
+		}
+
+		// 테스트 실행
+		statusCode, _, err := c.Authenticate(context.Background(), given)
+
+		// 검증 - 모두 같은 오류이고 last_used_at을 기록하지 않습니다.
+		assert.Equal(t, http.StatusUnauthorized, statusCode, name)
+		assertErrorCode(t, err, ErrInvalidAPIKey)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
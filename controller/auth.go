@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/domainErrors"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/types/responseTypes"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+)
+
+const tokenTypeBearer = "Bearer"
+
+var (
+	ErrInvalidCredentials = domainErrors.Unauthorized("auth.invalid_credentials", "이메일 또는 비밀번호가 올바르지 않습니다")
+	ErrInvalidToken       = domainErrors.Unauthorized("auth.invalid_token", "토큰이 올바르지 않거나 만료되었습니다")
+	ErrTokenRevoked       = domainErrors.Unauthorized("auth.token_revoked", "폐기된 토큰입니다")
+)
+
+// AuthController는 토큰을 발급하고 확인합니다. Keys가 nil이면(JWT_KEYS가 없으면) 모든 요청이 500입니다.
+type AuthController struct {
+	UserRepository         *repository.UserRepository
+	RevokedTokenRepository *repository.RevokedTokenRepository
+	Keys                   *auth.KeySet
+	Issuer                 string
+	AccessTokenTTL         time.Duration
+	RefreshTokenTTL        time.Duration
+}
+
+func NewAuthController(userRepository *repository.UserRepository, revokedTokenRepository *repository.RevokedTokenRepository, keys *auth.KeySet) *AuthController {
+	return &AuthController{
+		UserRepository:         userRepository,
+		RevokedTokenRepository: revokedTokenRepository,
+		Keys:                   keys,
+		Issuer:                 auth.Issuer(),
+		AccessTokenTTL:         auth.AccessTokenTTL(),
+		RefreshTokenTTL:        auth.RefreshTokenTTL(),
+	}
+}
+
+func (c *AuthController) Login(ctx context.Context, input *requestTypes.LoginRequest) (statusCode int, token *responseTypes.Token, err error) {
+	if c.Keys == nil {
+		return http.StatusInternalServerError, nil, auth.ErrKeySetNotConfigured
+	}
+
+	user, err := c.UserRepository.GetByEmail(ctx, input.Email)
+	if err != nil && !domainErrors.Is(err, domainErrors.KindNotFound) {
+		return http.StatusInternalServerError, nil, err
+	}
+	// 없는 사용자도 같은 시간만큼 비교해서 응답 시간으로 가입 여부를 알 수 없게 합니다.
+	var passwordHash string
+	if user != nil {
+		passwordHash = user.PasswordHash
+	}
+	matched := auth.CheckPassword(passwordHash, input.Password)
+	if user == nil || !matched {
+		return http.StatusUnauthorized, nil, ErrInvalidCredentials
+	}
+
+	return c.issue(user)
+}
+
+// Refresh는 refresh 토큰을 한 번만 쓸 수 있게 폐기하고 새 토큰 쌍을 발급합니다.
+func (c *AuthController) Refresh(ctx context.Context, input *requestTypes.RefreshRequest) (statusCode int, token *responseTypes.Token, err error) {
+	claims, statusCode, err := c.verify(input.RefreshToken, auth.TokenTypeRefresh)
+	if err != nil {
+		return statusCode, nil, err
+	}
+
+	revoked, err := c.RevokedTokenRepository.Revoke(ctx, claims.ID, claims.Expiry())
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	if !revoked {
+		return http.StatusUnauthorized, nil, ErrTokenRevoked
+	}
+
+	user, err := c.UserRepository.GetByID(ctx, claims.Subject)
+	if domainErrors.Is(err, domainErrors.KindNotFound) {
+		return http.StatusUnauthorized, nil, ErrInvalidToken.Wrap(err)
+	}
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	return c.issue(user)
+}
+
+// Revoke는 access 또는 refresh 토큰을 만료 전까지 쓸 수 없게 합니다. 이미 만료된 토큰은 할 일이 없으므로 성공입니다.
+func (c *AuthController) Revoke(ctx context.Context, input *requestTypes.RevokeRequest) (statusCode int, err error) {
+	claims, statusCode, err := c.verify(input.Token, "")
+	if errors.Is(err, auth.ErrTokenExpired) {
+		return http.StatusOK, nil
+	}
+	if err != nil {
+		return statusCode, err
+	}
+
+	if _, err = c.RevokedTokenRepository.Revoke(ctx, claims.ID, claims.Expiry()); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// Authenticate는 access 토큰을 확인해서 요청 주체를 돌려줍니다.
+func (c *AuthController) Authenticate(ctx context.Context, token string) (statusCode int, principal *auth.Principal, err error) {
+	claims, statusCode, err := c.verify(token, auth.TokenTypeAccess)
+	if err != nil {
+		return statusCode, nil, err
+	}
+
+	revoked, err := c.RevokedTokenRepository.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	if revoked {
+		return http.StatusUnauthorized, nil, ErrTokenRevoked
+	}
+
+	return http.StatusOK, &auth.Principal{
+		UserID:    claims.Subject,
+		TenantID:  claims.TenantID,
+		Roles:     claims.Roles,
+		TokenID:   claims.ID,
+		ExpiresAt: claims.Expiry(),
+	}, nil
+}
+
+// verify는 서명, 발급자, 만료를 확인합니다. tokenType이 비어 있으면 종류를 가리지 않습니다.
+func (c *AuthController) verify(token string, tokenType string) (claims *auth.Claims, statusCode int, err error) {
+	if c.Keys == nil {
+		return nil, http.StatusInternalServerError, auth.ErrKeySetNotConfigured
+	}
+
+	claims, err = c.Keys.Verify(token, c.Issuer, time.Now())
+	if err != nil {
+		return nil, http.StatusUnauthorized, ErrInvalidToken.Wrap(err)
+	}
+	if tokenType != "" && claims.TokenType != tokenType {
+		return nil, http.StatusUnauthorized, ErrInvalidToken.Wrap(errors.New("unexpected token type " + claims.TokenType))
+	}
+
+	return claims, http.StatusOK, nil
+}
+
+// issue는 access 토큰과 refresh 토큰을 발급합니다. 역할은 access 토큰에만 넣고, 갱신할 때 DB에서 다시 읽습니다.
+func (c *AuthController) issue(user *types.User) (statusCode int, token *responseTypes.Token, err error) {
+	now := time.Now()
+	access := &auth.Claims{
+		Issuer:    c.Issuer,
+		Subject:   user.ID.String(),
+		ID:        uuid.NewString(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(c.AccessTokenTTL).Unix(),
+		TokenType: auth.TokenTypeAccess,
+		TenantID:  user.TenantID,
+		Roles:     user.Roles,
+	}
+	refresh := &auth.Claims{
+		Issuer:    c.Issuer,
+		Subject:   user.ID.String(),
+		ID:        uuid.NewString(),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(c.RefreshTokenTTL).Unix(),
+		TokenType: auth.TokenTypeRefresh,
+		TenantID:  user.TenantID,
+	}
+
+	accessToken, err := c.Keys.Sign(access)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	refreshToken, err := c.Keys.Sign(refresh)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	return http.StatusOK, &responseTypes.Token{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    tokenTypeBearer,
+		ExpiresIn:    int64(c.AccessTokenTTL.Seconds()),
+	}, nil
+}
@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/domainErrors"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"bytes"
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	userByEmailQuery    = `SELECT * FROM "users" WHERE email = $1 AND "users"."delete_at" IS NULL`
+	userByIDQuery       = `SELECT * FROM "users" WHERE id = $1 AND "users"."delete_at" IS NULL`
+	revokeTokenExec     = `INSERT INTO "revoked_tokens" ("id","expires_at","create_at") VALUES ($1,$2,$3) ON CONFLICT DO NOTHING`
+	isTokenRevokedQuery = `SELECT "id" FROM "revoked_tokens" WHERE id = $1`
+)
+
+func setupMockAuthController(t *testing.T) (*AuthController, sqlmock.Sqlmock) {
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	// GORM 설정
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, PreferSimpleProtocol: true}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, tenancy.Register(db))
+
+	keys, err := auth.NewKeySet("k1", map[string][]byte{"k1": bytes.Repeat([]byte("k"), 32)})
+	require.NoError(t, err)
+
+	return NewAuthController(&repository.UserRepository{DB: db}, &repository.RevokedTokenRepository{DB: db}, keys), mock
+}
+
+func expectUser(t *testing.T, mock sqlmock.Sqlmock, query string, id uuid.UUID, password string) {
+	hash, err := auth.HashPassword(password)
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(query)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "email", "password_hash", "roles"}).
+			AddRow(id, "tenant-a", "user@example.com", hash, `["editor"]`))
+}
+
+// assertErrorCode는 Wrap한 복사본도 같은 오류로 보도록 도메인 오류의 Code를 비교합니다.
+func assertErrorCode(t *testing.T, err error, expected *domainErrors.Error) {
+	t.Helper()
+	domainError, ok := domainErrors.As(err)
+	if assert.True(t, ok, err) {
+		assert.Equal(t, expected.Code, domainError.Code)
+	}
+}
+
+func TestAuthController_Login(t *testing.T) {
+	// 테스트 설정
+	c, mock := setupMockAuthController(t)
+	id := uuid.New()
+
+	// SQL 쿼리 모의 설정
+	expectUser(t, mock, userByEmailQuery, id, "password123")
+
+	// 테스트 실행
+	statusCode, token, err := c.Login(context.Background(), &requestTypes.LoginRequest{Email: " User@Example.com", Password: "password123"})
+
+	// 검증
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "Bearer", token.TokenType)
+	assert.Equal(t, int64(c.AccessTokenTTL.Seconds()), token.ExpiresIn)
+
+	access, err := c.Keys.Verify(token.AccessToken, "", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, id.String(), access.Subject)
+	assert.Equal(t, auth.TokenTypeAccess, access.TokenType)
+	assert.Equal(t, "tenant-a", access.TenantID)
+	assert.Equal(t, []string{"editor"}, access.Roles)
+
+	refresh, err := c.Keys.Verify(token.RefreshToken, "", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, auth.TokenTypeRefresh, refresh.TokenType)
+	assert.Empty(t, refresh.Roles)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthController_Login_InvalidCredentials(t *testing.T) {
+	// 테스트 설정
+	c, mock := setupMockAuthController(t)
+
+	// SQL 쿼리 모의 설정 - 비밀번호가 틀린 사용자와 없는 사용자
+	expectUser(t, mock, userByEmailQuery, uuid.New(), "password123")
+	mock.ExpectQuery(regexp.QuoteMeta(userByEmailQuery)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// 테스트 실행
+	wrongStatus, _, wrongErr := c.Login(context.Background(), &requestTypes.LoginRequest{Email: "user@example.com", Password: "wrong"})
+	unknownStatus, _, unknownErr := c.Login(context.Background(), &requestTypes.LoginRequest{Email: "nobody@example.com", Password: "password123"})
+
+	// 검증 - 두 경우를 구분할 수 없어야 합니다.
+	assert.Equal(t, http.StatusUnauthorized, wrongStatus)
+	assertErrorCode(t, wrongErr, ErrInvalidCredentials)
+	assert.Equal(t, http.StatusUnauthorized, unknownStatus)
+	assertErrorCode(t, unknownErr, ErrInvalidCredentials)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthController_Refresh(t *testing.T) {
+	// 테스트 설정
+	c, mock := setupMockAuthController(t)
+	id := uuid.New()
+	expectUser(t, mock, userByEmailQuery, id, "password123")
+	_, issued, err := c.Login(context.Background(), &requestTypes.LoginRequest{Email: "user@example.com", Password: "password123"})
+	require.NoError(t, err)
+
+	// SQL 쿼리 모의 설정 - 두 번째 갱신은 이미 폐기된 토큰이므로 삽입되지 않습니다.
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(revokeTokenExec)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	expectUser(t, mock, userByIDQuery, id, "password123")
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(revokeTokenExec)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	statusCode, refreshed, err := c.Refresh(context.Background(), &requestTypes.RefreshRequest{RefreshToken: issued.RefreshToken})
+	reusedStatus, _, reusedErr := c.Refresh(context.Background(), &requestTypes.RefreshRequest{RefreshToken: issued.RefreshToken})
+
+	// 검증
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.NotEqual(t, issued.RefreshToken, refreshed.RefreshToken)
+	assert.Equal(t, http.StatusUnauthorized, reusedStatus)
+	assertErrorCode(t, reusedErr, ErrTokenRevoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthController_Refresh_RejectsAccessToken(t *testing.T) {
+	// 테스트 설정
+	c, mock := setupMockAuthController(t)
+	expectUser(t, mock, userByEmailQuery, uuid.New(), "password123")
+	_, issued, err := c.Login(context.Background(), &requestTypes.LoginRequest{Email: "user@example.com", Password: "password123"})
+	require.NoError(t, err)
+
+	// 테스트 실행
+	statusCode, _, err := c.Refresh(context.Background(), &requestTypes.RefreshRequest{RefreshToken: issued.AccessToken})
+
+	// 검증 - DB를 조회하지 않습니다.
+	assert.Equal(t, http.StatusUnauthorized, statusCode)
+	assertErrorCode(t, err, ErrInvalidToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthController_Authenticate(t *testing.T) {
+	// 테스트 설정
+	c, mock := setupMockAuthController(t)
+	id := uuid.New()
+	expectUser(t, mock, userByEmailQuery, id, "password123")
+	_, issued, err := c.Login(context.Background(), &requestTypes.LoginRequest{Email: "user@example.com", Password: "password123"})
+	require.NoError(t, err)
+
+	// SQL 쿼리 모의 설정 - 처음에는 폐기되지 않았고, 두 번째에는 폐기된 토큰입니다.
+	mock.ExpectQuery(regexp.QuoteMeta(isTokenRevokedQuery)).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(isTokenRevokedQuery)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("revoked"))
+
+	// 테스트 실행
+	statusCode, principal, err := c.Authenticate(context.Background(), issued.AccessToken)
+	revokedStatus, _, revokedErr := c.Authenticate(context.Background(), issued.AccessToken)
+	refreshStatus, _, refreshErr := c.Authenticate(context.Background(), issued.RefreshToken)
+
+	// 검증
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, id.String(), principal.UserID)
+	assert.Equal(t, "tenant-a", principal.TenantID)
+	assert.Equal(t, []string{"editor"}, principal.Roles)
+	assert.Equal(t, http.StatusUnauthorized, revokedStatus)
+	assertErrorCode(t, revokedErr, ErrTokenRevoked)
+	assert.Equal(t, http.StatusUnauthorized, refreshStatus)
+	assertErrorCode(t, refreshErr, ErrInvalidToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthController_NotConfigured(t *testing.T) {
+	// 테스트 설정
+	c := &AuthController{}
+
+	// 테스트 실행
+	statusCode, _, err := c.Authenticate(context.Background(), "token")
+
+	// 검증
+	assert.Equal(t, http.StatusInternalServerError, statusCode)
+	assert.ErrorIs(t, err, auth.ErrKeySetNotConfigured)
+}
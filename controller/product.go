@@ -1,45 +1,69 @@
 package controller
 
 import (
+	"Go-Gin-Basic-Template/domainErrors"
+	"Go-Gin-Basic-Template/events"
+	"Go-Gin-Basic-Template/i18n"
 	"Go-Gin-Basic-Template/repository"
 	"Go-Gin-Basic-Template/types"
 	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/validation"
+	"context"
+	"github.com/google/uuid"
 	"net/http"
 )
 
 type ProductController struct {
 	ProductRepository *repository.ProductRepository
+	Events            *events.Broker
 }
 
-func (c *ProductController) Insert(product *requestTypes.ProductRequest) (statusCode int, message string, err error) {
-	err = c.ProductRepository.Insert(product)
+// Insert와 Update는 HTTP 바인딩을 거치지 않는 gRPC, GraphQL 입력도 같은 규칙으로 검증합니다.
+func (c *ProductController) Insert(ctx context.Context, input *requestTypes.ProductRequest) (statusCode int, product *types.Product, err error) {
+	if err = validation.Struct(input); err != nil {
+		return http.StatusUnprocessableEntity, nil, err
+	}
+	product, err = c.ProductRepository.Insert(ctx, input)
 	if err != nil {
-		return http.StatusInternalServerError, "데이터베이스 저장 실패", err
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), nil, err
 	}
+	c.publish(ctx, events.ProductCreated, product)
 
-	return http.StatusCreated, "성공", nil
+	return http.StatusCreated, product, nil
 }
 
-func (c *ProductController) Update(id string, product *requestTypes.ProductRequest) (statusCode int, message string, err error) {
-	err = c.ProductRepository.Update(id, product)
+func (c *ProductController) Update(ctx context.Context, id string, input *requestTypes.ProductRequest) (statusCode int, product *types.Product, err error) {
+	if err = validation.Struct(input); err != nil {
+		return http.StatusUnprocessableEntity, nil, err
+	}
+	if err = checkProductID(id); err != nil {
+		return http.StatusNotFound, nil, err
+	}
+	product, err = c.ProductRepository.Update(ctx, id, input)
 	if err != nil {
-		return http.StatusInternalServerError, "데이터베이스 저장 실패", err
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), nil, err
 	}
+	c.publish(ctx, events.ProductUpdated, product)
 
-	return http.StatusOK, "성공", nil
+	return http.StatusOK, product, nil
 }
 
-func (c *ProductController) Delete(id string) (statusCode int, message string, err error) {
-	err = c.ProductRepository.Delete(id)
+// Delete는 지운 상품이 있을 때만 이벤트를 보냅니다. 없는 상품이면 404입니다.
+func (c *ProductController) Delete(ctx context.Context, id string) (statusCode int, message string, err error) {
+	if err = checkProductID(id); err != nil {
+		return http.StatusNotFound, i18n.DeleteFailed, err
+	}
+	dbRecord, err := c.ProductRepository.Delete(ctx, id)
 	if err != nil {
-		return http.StatusInternalServerError, "데이터베이스 삭제 실패", err
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), i18n.DeleteFailed, err
 	}
+	c.publish(ctx, events.ProductDeleted, dbRecord)
 
 	return http.StatusOK, id, nil
 }
 
-func (c *ProductController) GetAll() (statusCode int, product *[]types.Product, err error) {
-	product, err = c.ProductRepository.GetAll()
+func (c *ProductController) GetAll(ctx context.Context, filter *requestTypes.ProductFilter) (statusCode int, product *[]types.Product, err error) {
+	product, err = c.ProductRepository.GetAll(ctx, filter)
 	if err != nil {
 		return http.StatusInternalServerError, nil, err
 	}
@@ -47,11 +71,115 @@ func (c *ProductController) GetAll() (statusCode int, product *[]types.Product,
 	return http.StatusOK, product, nil
 }
 
-func (c *ProductController) Get(id string) (statusCode int, product *types.Product, err error) {
-	product, err = c.ProductRepository.GetByID(id)
+// Get은 GetByIDs와 같은 조회를 씁니다. 없는 상품이면 404입니다.
+func (c *ProductController) Get(ctx context.Context, id string) (statusCode int, product *types.Product, err error) {
+	statusCode, products, err := c.GetByIDs(ctx, []string{id})
+	if err != nil {
+		return statusCode, nil, err
+	}
+	if len(products) == 0 {
+		return http.StatusNotFound, nil, repository.ErrProductNotFound
+	}
+
+	return http.StatusOK, &products[0], nil
+}
+
+// GetByIDs는 UUID 형식이 아닌 ID를 쿼리 전에 걸러냅니다. 그런 ID는 없는 상품과 같게 취급합니다.
+func (c *ProductController) GetByIDs(ctx context.Context, ids []string) (statusCode int, products []types.Product, err error) {
+	valid := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, err := uuid.Parse(id); err == nil {
+			valid = append(valid, id)
+		}
+	}
+	if len(valid) == 0 {
+		return http.StatusOK, nil, nil
+	}
+
+	products, err = c.ProductRepository.GetByIDs(ctx, valid)
 	if err != nil {
 		return http.StatusInternalServerError, nil, err
 	}
 
-	return http.StatusOK, product, nil
+	return http.StatusOK, products, nil
+}
+
+func (c *ProductController) GetPage(ctx context.Context, filter *requestTypes.ProductFilter, limit int, offset int) (statusCode int, products []types.Product, total int64, err error) {
+	if limit < 0 || offset < 0 {
+		return http.StatusUnprocessableEntity, nil, 0, domainErrors.Validation("page.invalid", "limit과 offset은 0 이상이어야 합니다")
+	}
+
+	products, total, err = c.ProductRepository.GetPage(ctx, filter, limit, offset)
+	if err != nil {
+		return http.StatusInternalServerError, nil, 0, err
+	}
+
+	return http.StatusOK, products, total, nil
+}
+
+func (c *ProductController) GetRevisions(ctx context.Context, id string) (statusCode int, revisions *[]types.ProductRevision, err error) {
+	if err = checkProductID(id); err != nil {
+		return http.StatusNotFound, nil, err
+	}
+	revisions, err = c.ProductRepository.GetRevisions(ctx, id)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	return http.StatusOK, revisions, nil
+}
+
+func (c *ProductController) GetRevisionsByProductIDs(ctx context.Context, ids []uuid.UUID) (statusCode int, revisions []types.ProductRevision, err error) {
+	revisions, err = c.ProductRepository.GetRevisionsByProductIDs(ctx, ids)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	return http.StatusOK, revisions, nil
+}
+
+func (c *ProductController) GetRevision(ctx context.Context, id string, revision int) (statusCode int, result *types.ProductRevision, err error) {
+	if err = checkProductID(id); err != nil {
+		return http.StatusNotFound, nil, err
+	}
+	result, err = c.ProductRepository.GetRevision(ctx, id, revision)
+	if err != nil {
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), nil, err
+	}
+
+	return http.StatusOK, result, nil
+}
+
+func (c *ProductController) DiffRevisions(ctx context.Context, id string, from int, to int) (statusCode int, changes []types.FieldChange, err error) {
+	statusCode, fromRevision, err := c.GetRevision(ctx, id, from)
+	if err != nil {
+		return statusCode, nil, err
+	}
+	statusCode, toRevision, err := c.GetRevision(ctx, id, to)
+	if err != nil {
+		return statusCode, nil, err
+	}
+
+	return http.StatusOK, fromRevision.Snapshot.Diff(toRevision.Snapshot), nil
+}
+
+func (c *ProductController) Revert(ctx context.Context, id string, revision int) (statusCode int, message string, err error) {
+	if err = checkProductID(id); err != nil {
+		return http.StatusNotFound, i18n.SaveFailed, err
+	}
+	dbRecord, err := c.ProductRepository.Revert(ctx, id, revision)
+	if err != nil {
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), i18n.SaveFailed, err
+	}
+	c.publish(ctx, events.ProductUpdated, dbRecord)
+
+	return http.StatusOK, i18n.Success, nil
+}
+
+// checkProductID는 UUID 형식이 아닌 ID를 쿼리 전에 걸러냅니다. GetByIDs와 같이 없는 상품으로 취급합니다.
+func checkProductID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return repository.ErrProductNotFound
+	}
+	return nil
 }
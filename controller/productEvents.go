@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/events"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/responseTypes"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+// publish는 커밋이 끝난 변경만 알립니다. 테넌트가 없는 컨텍스트(관리자/백그라운드 작업)나 대상이 없는 삭제는 건너뜁니다.
+// SSE, 웹훅, gRPC가 REST 응답과 같은 필드를 받도록 모델 대신 responseTypes.Product를 싣습니다.
+func (c *ProductController) publish(ctx context.Context, eventType string, product *types.Product) {
+	if c.Events == nil || product == nil || product.ID == uuid.Nil {
+		return
+	}
+	tenantID, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return
+	}
+
+	c.Events.Publish(events.Event{
+		Type:      eventType,
+		TenantID:  tenantID,
+		ProductID: product.ID.String(),
+		Category:  product.Category,
+		Data:      responseTypes.NewProduct(product),
+	})
+}
+
+func (c *ProductController) Subscribe(ctx context.Context, lastEventID uint64, filter events.Filter) (statusCode int, replay []events.Event, ch <-chan events.Event, cancel func(), err error) {
+	if c.Events == nil {
+		return http.StatusServiceUnavailable, nil, nil, nil, errors.New("product events are not enabled")
+	}
+	tenantID, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return http.StatusBadRequest, nil, nil, nil, tenancy.ErrMissingTenant
+	}
+
+	replay, ch, cancel = c.Events.Subscribe(tenantID, lastEventID, filter)
+	return http.StatusOK, replay, ch, cancel, nil
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/events"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/types/responseTypes"
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductController_Insert_PublishesEvent(t *testing.T) {
+	// 모의 객체 설정
+	controller, mock := setupMockController(t)
+	controller.Events = events.NewBroker(10)
+	ctx := tenancy.WithTenant(context.Background(), "tenant-a")
+	_, ch, cancel := controller.Events.Subscribe("tenant-a", 0, events.Filter{})
+	defer cancel()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(revision), 0)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_revisions"`)).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	statusCode, _, err := controller.Insert(ctx, &requestTypes.ProductRequest{Name: "상품", Price: 1000, Category: "식품"})
+
+	// 검증
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, statusCode)
+	select {
+	case event := <-ch:
+		assert.Equal(t, events.ProductCreated, event.Type)
+		assert.Equal(t, "식품", event.Category)
+		assert.NotEmpty(t, event.ProductID)
+		// 이벤트 본문은 REST 응답과 같은 DTO라서 공급가나 내부 메모가 실리지 않습니다.
+		product, ok := event.Data.(responseTypes.Product)
+		require.True(t, ok)
+		assert.Equal(t, event.ProductID, product.ID)
+		assert.Equal(t, "상품", product.Name)
+	default:
+		t.Fatal("expected a product.created event")
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductController_Delete_MissingProductPublishesNothing(t *testing.T) {
+	// 모의 객체 설정 - 지운 행이 없으면 404이고 이벤트를 보내지 않습니다.
+	controller, mock := setupMockController(t)
+	controller.Events = events.NewBroker(10)
+	ctx := tenancy.WithTenant(context.Background(), "tenant-a")
+	_, ch, cancel := controller.Events.Subscribe("tenant-a", 0, events.Filter{})
+	defer cancel()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "products" SET "delete_at"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	statusCode, _, err := controller.Delete(ctx, uuid.NewString())
+
+	// 검증
+	assert.ErrorIs(t, err, repository.ErrProductNotFound)
+	assert.Equal(t, http.StatusNotFound, statusCode)
+	assert.Empty(t, ch)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductController_MalformedID(t *testing.T) {
+	// 모의 객체 설정 - UUID가 아닌 ID는 DB에 보내지 않고 없는 상품으로 봅니다.
+	controller, mock := setupMockController(t)
+	ctx := tenancy.WithTenant(context.Background(), "tenant-a")
+
+	// 테스트 실행
+	deleteStatus, _, deleteErr := controller.Delete(ctx, "missing")
+	updateStatus, _, updateErr := controller.Update(ctx, "missing", &requestTypes.ProductRequest{Name: "사과", Price: 1000, Category: "식품"})
+	revertStatus, _, revertErr := controller.Revert(ctx, "missing", 1)
+
+	// 검증
+	assert.Equal(t, http.StatusNotFound, deleteStatus)
+	assert.ErrorIs(t, deleteErr, repository.ErrProductNotFound)
+	assert.Equal(t, http.StatusNotFound, updateStatus)
+	assert.ErrorIs(t, updateErr, repository.ErrProductNotFound)
+	assert.Equal(t, http.StatusNotFound, revertStatus)
+	assert.ErrorIs(t, revertErr, repository.ErrProductNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
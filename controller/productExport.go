@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/types/responseTypes"
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson"
+	ExportFormatXLSX   = "xlsx"
+
+	defaultExportBatchSize = 1000
+)
+
+var exportColumns = []string{"id", "name", "price", "category", "sku", "createdAt", "updatedAt"}
+
+type productEncoder interface {
+	Encode(products []types.Product) error
+	Close() error
+}
+
+func ExportContentType(format string) (string, bool) {
+	switch format {
+	case ExportFormatCSV:
+		return "text/csv; charset=utf-8", true
+	case ExportFormatNDJSON:
+		return "application/x-ndjson", true
+	case ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", true
+	default:
+		return "", false
+	}
+}
+
+// Export는 상품을 배치 단위로 읽어서 바로 w에 씁니다. 전체 목록을 메모리에 올리지 않습니다.
+func (c *ProductController) Export(ctx context.Context, filter *requestTypes.ProductFilter, format string, w io.Writer) (statusCode int, err error) {
+	encoder, err := newProductEncoder(format, w)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	err = c.ProductRepository.FindInBatches(ctx, filter, exportBatchSize(), func(products []types.Product) error {
+		if err := encoder.Encode(products); err != nil {
+			return err
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if err = encoder.Close(); err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func exportBatchSize() int {
+	size, err := strconv.Atoi(os.Getenv("EXPORT_BATCH_SIZE"))
+	if err != nil || size <= 0 {
+		return defaultExportBatchSize
+	}
+	return size
+}
+
+func newProductEncoder(format string, w io.Writer) (productEncoder, error) {
+	switch format {
+	case ExportFormatCSV:
+		return newCSVProductEncoder(w)
+	case ExportFormatNDJSON:
+		return &ndjsonProductEncoder{encoder: json.NewEncoder(w)}, nil
+	case ExportFormatXLSX:
+		return newXLSXProductEncoder(w)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// exportRow는 API 응답(responseTypes.Product)과 같은 값을 exportColumns 순서로 나열합니다.
+func exportRow(product *types.Product) []interface{} {
+	response := responseTypes.NewProduct(product)
+	return []interface{}{
+		response.ID,
+		response.Name,
+		response.Price,
+		response.Category,
+		response.SKU,
+		response.CreatedAt.Format(time.RFC3339),
+		response.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+type csvProductEncoder struct {
+	writer *csv.Writer
+}
+
+func newCSVProductEncoder(w io.Writer) (*csvProductEncoder, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(exportColumns); err != nil {
+		return nil, err
+	}
+	return &csvProductEncoder{writer: writer}, nil
+}
+
+func (e *csvProductEncoder) Encode(products []types.Product) error {
+	for i := range products {
+		row := exportRow(&products[i])
+		record := make([]string, len(row))
+		for j, cell := range row {
+			switch value := cell.(type) {
+			case float64:
+				record[j] = strconv.FormatFloat(value, 'f', -1, 64)
+			default:
+				record[j] = escapeCSVFormula(fmt.Sprint(value))
+			}
+		}
+		if err := e.writer.Write(record); err != nil {
+			return err
+		}
+	}
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// escapeCSVFormula는 스프레드시트가 수식으로 실행하지 않도록 =, +, -, @, 탭, CR로 시작하는 글자 셀 앞에 '를 붙입니다.
+// 숫자 셀(가격)은 그대로 둡니다.
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (e *csvProductEncoder) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type ndjsonProductEncoder struct {
+	encoder *json.Encoder
+}
+
+func (e *ndjsonProductEncoder) Encode(products []types.Product) error {
+	for i := range products {
+		if err := e.encoder.Encode(responseTypes.NewProduct(&products[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *ndjsonProductEncoder) Close() error {
+	return nil
+}
+
+// xlsxProductEncoder는 시트 하나짜리 최소한의 OOXML 문서를 zip 스트림으로 바로 씁니다.
+type xlsxProductEncoder struct {
+	archive *zip.Writer
+	sheet   io.Writer
+	row     int
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="products" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+func newXLSXProductEncoder(w io.Writer) (*xlsxProductEncoder, error) {
+	archive := zip.NewWriter(w)
+	for _, part := range []struct{ name, body string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	} {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.WriteString(file, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err = io.WriteString(sheet, xlsxSheetHeader); err != nil {
+		return nil, err
+	}
+
+	e := &xlsxProductEncoder{archive: archive, sheet: sheet}
+	header := make([]interface{}, len(exportColumns))
+	for i, column := range exportColumns {
+		header[i] = column
+	}
+	return e, e.writeRow(header)
+}
+
+func (e *xlsxProductEncoder) Encode(products []types.Product) error {
+	for i := range products {
+		if err := e.writeRow(exportRow(&products[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *xlsxProductEncoder) writeRow(cells []interface{}) error {
+	e.row++
+	if _, err := fmt.Fprintf(e.sheet, `<row r="%d">`, e.row); err != nil {
+		return err
+	}
+	for _, cell := range cells {
+		var err error
+		switch value := cell.(type) {
+		case float64:
+			_, err = fmt.Fprintf(e.sheet, `<c t="n"><v>%s</v></c>`, strconv.FormatFloat(value, 'f', -1, 64))
+		default:
+			if _, err = io.WriteString(e.sheet, `<c t="inlineStr"><is><t>`); err == nil {
+				if err = xml.EscapeText(e.sheet, []byte(fmt.Sprint(value))); err == nil {
+					_, err = io.WriteString(e.sheet, `</t></is></c>`)
+				}
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(e.sheet, `</row>`)
+	return err
+}
+
+func (e *xlsxProductEncoder) Close() error {
+	if _, err := io.WriteString(e.sheet, xlsxSheetFooter); err != nil {
+		return err
+	}
+	return e.archive.Close()
+}
@@ -0,0 +1,164 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func expectExportQuery(mock sqlmock.Sqlmock) (uuid.UUID, uuid.UUID) {
+	testTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	testUUID1 := uuid.New()
+	testUUID2 := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE name ILIKE $1 AND price >= $2 AND "products"."delete_at" IS NULL ORDER BY "products"."id" LIMIT $3`)).
+		WithArgs("%상품%", 100.0, 1000).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "create_at", "update_at", "delete_at", "name", "price", "category", "sku"}).
+			AddRow(testUUID1, "tenant-a", testTime, testTime, nil, "상품1", 10000.0, "식품", "FOOD-001").
+			AddRow(testUUID2, "tenant-a", testTime, testTime, nil, `상품 "2", <특가>`, 20000.5, "", ""))
+
+	return testUUID1, testUUID2
+}
+
+func exportFilter() *requestTypes.ProductFilter {
+	minPrice := 100.0
+	return &requestTypes.ProductFilter{Name: "상품", MinPrice: &minPrice}
+}
+
+func TestProductController_Export_CSV(t *testing.T) {
+	// 모의 객체 설정
+	controller, mock := setupMockController(t)
+	testUUID1, testUUID2 := expectExportQuery(mock)
+
+	// 테스트 실행
+	var buf bytes.Buffer
+	statusCode, err := controller.Export(context.Background(), exportFilter(), ExportFormatCSV, &buf)
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "id,name,price,category,sku,createdAt,updatedAt\n"+
+		testUUID1.String()+",상품1,10000,식품,FOOD-001,2025-01-02T03:04:05Z,2025-01-02T03:04:05Z\n"+
+		testUUID2.String()+`,"상품 ""2"", <특가>",20000.5,,,2025-01-02T03:04:05Z,2025-01-02T03:04:05Z`+"\n", buf.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductController_Export_CSVFormulaInjection(t *testing.T) {
+	// 모의 객체 설정
+	controller, mock := setupMockController(t)
+	testTime := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	testUUID := uuid.New()
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE "products"."delete_at" IS NULL ORDER BY "products"."id" LIMIT $1`)).
+		WithArgs(1000).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "create_at", "update_at", "delete_at", "name", "price", "category", "sku"}).
+			AddRow(testUUID, "tenant-a", testTime, testTime, nil, `=HYPERLINK("http://evil.example","클릭")`, 1000.0, "+식품", "@SKU"))
+
+	// 테스트 실행
+	var buf bytes.Buffer
+	statusCode, err := controller.Export(context.Background(), &requestTypes.ProductFilter{}, ExportFormatCSV, &buf)
+
+	// 검증 - 글자 셀은 '로 시작해서 수식으로 실행되지 않습니다.
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, "id,name,price,category,sku,createdAt,updatedAt\n"+
+		testUUID.String()+`,"'=HYPERLINK(""http://evil.example"",""클릭"")",1000,'+식품,'@SKU,2025-01-02T03:04:05Z,2025-01-02T03:04:05Z`+"\n", buf.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEscapeCSVFormula(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"=1+1", "'=1+1"},
+		{"+82-10", "'+82-10"},
+		{"-2", "'-2"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1", "'\t=1"},
+		{"사과", "사과"},
+		{"a=b", "a=b"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행 및 검증
+		assert.Equal(t, tt.expected, escapeCSVFormula(tt.value), tt.value)
+	}
+}
+
+func TestProductController_Export_NDJSON(t *testing.T) {
+	// 모의 객체 설정
+	controller, mock := setupMockController(t)
+	testUUID1, _ := expectExportQuery(mock)
+
+	// 테스트 실행
+	var buf bytes.Buffer
+	statusCode, err := controller.Export(context.Background(), exportFilter(), ExportFormatNDJSON, &buf)
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.JSONEq(t, `{"id":"`+testUUID1.String()+`","name":"상품1","price":10000,"category":"식품","sku":"FOOD-001","createdAt":"2025-01-02T03:04:05Z","updatedAt":"2025-01-02T03:04:05Z"}`, lines[0])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductController_Export_XLSX(t *testing.T) {
+	// 모의 객체 설정
+	controller, mock := setupMockController(t)
+	expectExportQuery(mock)
+
+	// 테스트 실행
+	var buf bytes.Buffer
+	statusCode, err := controller.Export(context.Background(), exportFilter(), ExportFormatXLSX, &buf)
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	var sheet string
+	for _, file := range archive.File {
+		if file.Name == "xl/worksheets/sheet1.xml" {
+			reader, err := file.Open()
+			require.NoError(t, err)
+			body, _ := io.ReadAll(reader)
+			sheet = string(body)
+		}
+	}
+	assert.Len(t, archive.File, 5)
+	assert.Contains(t, sheet, `<row r="3">`)
+	assert.Contains(t, sheet, `<t>상품 &#34;2&#34;, &lt;특가&gt;</t>`)
+	assert.Contains(t, sheet, `<c t="n"><v>20000.5</v></c>`)
+	assert.Contains(t, sheet, `<t>sku</t>`)
+	assert.Contains(t, sheet, `<t>FOOD-001</t>`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductController_Export_UnsupportedFormat(t *testing.T) {
+	// 모의 객체 설정
+	controller, _ := setupMockController(t)
+
+	// 테스트 실행
+	statusCode, err := controller.Export(context.Background(), nil, "pdf", io.Discard)
+
+	// 검증
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
@@ -0,0 +1,298 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/events"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/validation"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+
+	defaultImportBatchSize = 500
+	maxImportErrors        = 1000
+	maxNDJSONLineSize      = 1 << 20
+)
+
+type productRowReader interface {
+	// Next는 다음 행을 읽습니다. 행 단위 오류는 rowErr로, 파일을 더 읽을 수 없는 오류는 err로 돌려줍니다.
+	Next() (line int, product *requestTypes.ProductRequest, rowErr *types.ImportRowError, err error)
+}
+
+func (c *ProductController) Import(ctx context.Context, body io.Reader, format string, dryRun bool) (statusCode int, report *types.ImportReport, err error) {
+	reader, err := newProductRowReader(body, format)
+	if err != nil {
+		return http.StatusBadRequest, nil, err
+	}
+
+	locale, _ := i18n.FromContext(ctx)
+	report = &types.ImportReport{DryRun: dryRun, Errors: []types.ImportRowError{}}
+	batchSize := importBatchSize()
+	batch := make([]requestTypes.ProductRequest, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if !dryRun {
+			products, err := c.ProductRepository.InsertBatch(ctx, batch)
+			if err != nil {
+				return err
+			}
+			for i := range products {
+				c.publish(ctx, events.ProductCreated, &products[i])
+			}
+		}
+		report.Imported += len(batch)
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		line, product, rowErr, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return http.StatusBadRequest, report, err
+		}
+
+		report.Total++
+		rowErrors := validateImportRow(locale, line, product)
+		if rowErr != nil {
+			rowErrors = []types.ImportRowError{*rowErr}
+		}
+		if len(rowErrors) > 0 {
+			report.Failed++
+			for _, rowError := range rowErrors {
+				if len(report.Errors) >= maxImportErrors {
+					report.Truncated = true
+					break
+				}
+				report.Errors = append(report.Errors, rowError)
+			}
+			continue
+		}
+
+		batch = append(batch, *product)
+		if len(batch) >= batchSize {
+			if err = flush(); err != nil {
+				return http.StatusInternalServerError, report, err
+			}
+		}
+	}
+
+	if err = flush(); err != nil {
+		return http.StatusInternalServerError, report, err
+	}
+
+	return http.StatusOK, report, nil
+}
+
+func importBatchSize() int {
+	size, err := strconv.Atoi(os.Getenv("IMPORT_BATCH_SIZE"))
+	if err != nil || size <= 0 {
+		return defaultImportBatchSize
+	}
+	return size
+}
+
+// validateImportRow는 ProductRequest의 binding 태그로 검증하고 메시지는 locale로 번역합니다. 파싱 오류 메시지는 번역하지 않습니다.
+func validateImportRow(locale string, line int, product *requestTypes.ProductRequest) []types.ImportRowError {
+	if product == nil {
+		return nil
+	}
+
+	var rowErrors []types.ImportRowError
+	err := validation.Struct(product)
+	if fieldErrors, ok := validation.Errors(locale, err); ok {
+		for _, fieldError := range fieldErrors {
+			rowErrors = append(rowErrors, types.ImportRowError{Line: line, Field: fieldError.Field, Message: fieldError.Message})
+		}
+	} else if err != nil {
+		rowErrors = append(rowErrors, types.ImportRowError{Line: line, Message: err.Error()})
+	}
+
+	return rowErrors
+}
+
+func newProductRowReader(body io.Reader, format string) (productRowReader, error) {
+	switch format {
+	case ImportFormatCSV:
+		return newCSVProductReader(body)
+	case ImportFormatNDJSON:
+		scanner := bufio.NewScanner(body)
+		scanner.Buffer(make([]byte, 64*1024), maxNDJSONLineSize)
+		return &ndjsonProductReader{scanner: scanner}, nil
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", format)
+	}
+}
+
+type csvProductReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVProductReader(body io.Reader) (*csvProductReader, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimPrefix(name, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, field := range productRequestFields() {
+		if _, ok := columns[strings.ToLower(field.column)]; !ok && field.required {
+			return nil, fmt.Errorf("CSV header is missing required column %q", field.column)
+		}
+	}
+
+	return &csvProductReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvProductReader) Next() (int, *requestTypes.ProductRequest, *types.ImportRowError, error) {
+	record, err := r.reader.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) && !errors.Is(parseErr.Err, io.ErrUnexpectedEOF) {
+		return parseErr.Line, nil, &types.ImportRowError{Line: parseErr.Line, Message: parseErr.Err.Error()}, nil
+	}
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	line, _ := r.reader.FieldPos(0)
+
+	product := &requestTypes.ProductRequest{}
+	value := reflect.ValueOf(product).Elem()
+	for _, field := range productRequestFields() {
+		index, ok := r.columns[strings.ToLower(field.column)]
+		if !ok || index >= len(record) {
+			continue
+		}
+		if err = setFieldFromString(value.Field(field.index), strings.TrimSpace(record[index])); err != nil {
+			return line, nil, &types.ImportRowError{Line: line, Field: field.column, Message: err.Error()}, nil
+		}
+	}
+
+	return line, product, nil, nil
+}
+
+type ndjsonProductReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *ndjsonProductReader) Next() (int, *requestTypes.ProductRequest, *types.ImportRowError, error) {
+	for r.scanner.Scan() {
+		r.line++
+		raw := bytes.TrimSpace(r.scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		product := &requestTypes.ProductRequest{}
+		if err := json.Unmarshal(raw, product); err != nil {
+			rowErr := &types.ImportRowError{Line: r.line, Message: err.Error()}
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				rowErr.Field = typeErr.Field
+			}
+			return r.line, nil, rowErr, nil
+		}
+
+		return r.line, product, nil, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return 0, nil, nil, err
+	}
+
+	return 0, nil, nil, io.EOF
+}
+
+type importField struct {
+	index    int
+	column   string
+	required bool
+}
+
+// productRequestFields는 ProductRequest의 json 태그를 CSV 컬럼 이름으로 사용합니다.
+func productRequestFields() []importField {
+	requestType := reflect.TypeOf(requestTypes.ProductRequest{})
+	fields := make([]importField, 0, requestType.NumField())
+	for i := 0; i < requestType.NumField(); i++ {
+		field := requestType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		fields = append(fields, importField{
+			index:    i,
+			column:   name,
+			required: name == "name" || name == "price",
+		})
+	}
+	return fields
+}
+
+func setFieldFromString(field reflect.Value, raw string) error {
+	if raw == "" {
+		return nil
+	}
+	if field.Kind() == reflect.Ptr {
+		value := reflect.New(field.Type().Elem())
+		if err := setFieldFromString(value.Elem(), raw); err != nil {
+			return err
+		}
+		field.Set(value)
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Float32, reflect.Float64:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("숫자가 아닙니다: %q", raw)
+		}
+		field.SetFloat(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("정수가 아닙니다: %q", raw)
+		}
+		field.SetInt(value)
+	case reflect.Bool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("true/false 값이 아닙니다: %q", raw)
+		}
+		field.SetBool(value)
+	default:
+		return fmt.Errorf("지원하지 않는 컬럼 타입입니다: %s", field.Kind())
+	}
+
+	return nil
+}
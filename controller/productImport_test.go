@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockController(t *testing.T) (*ProductController, sqlmock.Sqlmock) {
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	// GORM 설정
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		Conn:                 mockDB,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return &ProductController{ProductRepository: &repository.ProductRepository{DB: db}}, mock
+}
+
+func TestProductController_Import_CSVDryRun(t *testing.T) {
+	// 모의 객체 설정 - dry-run은 DB에 쓰지 않아야 합니다.
+	controller, mock := setupMockController(t)
+
+	// 테스트 데이터
+	body := strings.NewReader("Name,Price\n상품1,10000\n,5000\n상품3,abc\n상품4,-1\n")
+
+	// 테스트 실행
+	statusCode, report, err := controller.Import(context.Background(), body, ImportFormatCSV, true)
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 4, report.Total)
+	assert.Equal(t, 1, report.Imported)
+	assert.Equal(t, 3, report.Failed)
+	assert.Equal(t, []types.ImportRowError{
+		{Line: 3, Field: "name", Message: "name은(는) 필수 항목입니다"},
+		{Line: 4, Field: "price", Message: `숫자가 아닙니다: "abc"`},
+		{Line: 5, Field: "price", Message: "price은(는) 0보다 커야 합니다"},
+	}, report.Errors)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductController_Import_CSVMissingColumn(t *testing.T) {
+	// 모의 객체 설정
+	controller, _ := setupMockController(t)
+
+	// 테스트 실행
+	statusCode, report, err := controller.Import(context.Background(), strings.NewReader("name\n상품1\n"), ImportFormatCSV, true)
+
+	// 검증
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+	assert.Nil(t, report)
+}
+
+func TestProductController_Import_NDJSON(t *testing.T) {
+	// 모의 객체 설정
+	controller, mock := setupMockController(t)
+	t.Setenv("IMPORT_BATCH_SIZE", "2")
+
+	// 테스트 데이터
+	body := strings.NewReader(`{"name":"상품1","price":10000}
+{"name":"상품2","price":"비쌈"}
+
+{"name":"상품3","price":30000}
+`)
+
+	// SQL 쿼리 모의 설정 - sqlmock은 COPY를 지원하지 않으므로 일반 INSERT로 적재됩니다.
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_revisions"`)).WillReturnResult(sqlmock.NewResult(2, 2))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	statusCode, report, err := controller.Import(context.Background(), body, ImportFormatNDJSON, false)
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 2, report.Imported)
+	assert.Equal(t, 1, report.Failed)
+	assert.Equal(t, 2, report.Errors[0].Line)
+	assert.Equal(t, "price", report.Errors[0].Field)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductController_Import_UnsupportedFormat(t *testing.T) {
+	// 모의 객체 설정
+	controller, _ := setupMockController(t)
+
+	// 테스트 실행
+	statusCode, _, err := controller.Import(context.Background(), strings.NewReader(""), "xml", true)
+
+	// 검증
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, statusCode)
+}
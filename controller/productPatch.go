@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/domainErrors"
+	"Go-Gin-Basic-Template/events"
+	"Go-Gin-Basic-Template/patch"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/validation"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+var (
+	ErrPatchTestFailed   = domainErrors.Conflict("product.patch_test_failed", "patch의 test 연산이 현재 상품과 맞지 않습니다")
+	ErrPatchPathNotFound = domainErrors.Validation("product.patch_path_not_found", "patch 경로가 상품에 없습니다")
+	ErrPatchInvalidValue = domainErrors.Validation("product.patch_invalid_value", "patch를 적용한 값의 타입이 올바르지 않습니다")
+)
+
+// Patch는 현재 상품의 ProductRequest JSON에 apply(patch.Merge나 patch.Apply)를 적용하고,
+// 결과를 PUT과 같은 규칙으로 검증한 뒤 저장합니다. 잘못된 patch 문서는 400, test 실패는 409, 검증 실패는 422입니다.
+func (c *ProductController) Patch(ctx context.Context, id string, apply func(document []byte) ([]byte, error)) (statusCode int, product *types.Product, err error) {
+	if err = checkProductID(id); err != nil {
+		return http.StatusNotFound, nil, err
+	}
+	product, err = c.ProductRepository.Patch(ctx, id, func(input *requestTypes.ProductRequest) error {
+		document, err := json.Marshal(input)
+		if err != nil {
+			return err
+		}
+		if document, err = apply(document); err != nil {
+			return err
+		}
+
+		patched := requestTypes.ProductRequest{}
+		if err = json.Unmarshal(document, &patched); err != nil {
+			return ErrPatchInvalidValue.Wrap(err)
+		}
+		if err = validation.Struct(&patched); err != nil {
+			return err
+		}
+		*input = patched
+		return nil
+	})
+	if err != nil {
+		err = patchError(err)
+		return patchStatusCode(err), nil, err
+	}
+	c.publish(ctx, events.ProductUpdated, product)
+
+	return http.StatusOK, product, nil
+}
+
+// patchError는 patch 패키지의 오류를 도메인 오류로 바꿉니다.
+func patchError(err error) error {
+	switch {
+	case errors.Is(err, patch.ErrTestFailed):
+		return ErrPatchTestFailed.Wrap(err)
+	case errors.Is(err, patch.ErrPathNotFound):
+		return ErrPatchPathNotFound.Wrap(err)
+	}
+	return err
+}
+
+func patchStatusCode(err error) int {
+	switch {
+	case validation.Is(err):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, patch.ErrInvalidPatch):
+		return http.StatusBadRequest
+	}
+	return domainErrors.StatusCode(err, http.StatusInternalServerError)
+}
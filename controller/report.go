@@ -0,0 +1,42 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type ReportController struct {
+	ReportRepository *repository.ReportRepository
+	// MaterializedViews가 켜져 있으면 주기적으로 갱신되는 뷰에서 읽고, 꺼져 있거나 fresh 요청이면 실시간으로 집계합니다.
+	MaterializedViews bool
+}
+
+func (c *ReportController) Products(ctx context.Context, interval string, fresh bool) (statusCode int, report *types.ProductReport, err error) {
+	if interval != types.ReportIntervalWeek && interval != types.ReportIntervalMonth {
+		return http.StatusBadRequest, nil, fmt.Errorf("interval must be %q or %q", types.ReportIntervalWeek, types.ReportIntervalMonth)
+	}
+
+	materialized := c.MaterializedViews && !fresh
+	report = &types.ProductReport{Source: types.ReportSourceLive, Interval: interval}
+	if materialized {
+		report.Source = types.ReportSourceMaterialized
+	}
+
+	summary, err := c.ReportRepository.ProductSummary(ctx, materialized)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	report.Summary = *summary
+
+	if report.ByCategory, err = c.ReportRepository.ProductsByCategory(ctx, materialized); err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	if report.ByPeriod, err = c.ReportRepository.ProductsByPeriod(ctx, interval, materialized); err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	return http.StatusOK, report, nil
+}
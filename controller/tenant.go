@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/domainErrors"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+)
+
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,62}$`)
+
+// ErrTenantNotFound는 사용자나 API 키를 만들 때 요청 본문의 테넌트가 없으면 돌려줍니다.
+var ErrTenantNotFound = domainErrors.NotFound(i18n.TenantNotFound, "존재하지 않는 테넌트입니다")
+
+type TenantController struct {
+	TenantRepository *repository.TenantRepository
+}
+
+func (c *TenantController) Insert(ctx context.Context, tenant *requestTypes.TenantRequest) (statusCode int, message string, err error) {
+	if !tenantIDPattern.MatchString(tenant.ID) {
+		return http.StatusBadRequest, i18n.InvalidTenantID, errors.New("tenant id must match " + tenantIDPattern.String())
+	}
+
+	err = c.TenantRepository.Insert(ctx, tenant)
+	if err != nil {
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), i18n.SaveFailed, err
+	}
+
+	return http.StatusCreated, i18n.Success, nil
+}
+
+func (c *TenantController) GetAll(ctx context.Context) (statusCode int, tenants *[]types.Tenant, err error) {
+	tenants, err = c.TenantRepository.GetAll(ctx)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	return http.StatusOK, tenants, nil
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/domainErrors"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
+	"net/http"
+)
+
+type UserController struct {
+	UserRepository   *repository.UserRepository
+	TenantRepository *repository.TenantRepository
+}
+
+func (c *UserController) Insert(ctx context.Context, input *requestTypes.UserRequest) (statusCode int, user *types.User, err error) {
+	exists, err := c.TenantRepository.Exists(ctx, input.TenantID)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+	if !exists {
+		return http.StatusNotFound, nil, ErrTenantNotFound
+	}
+
+	passwordHash, err := auth.HashPassword(input.Password)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	user, err = c.UserRepository.Insert(ctx, input, passwordHash)
+	if err != nil {
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), nil, err
+	}
+
+	return http.StatusCreated, user, nil
+}
@@ -0,0 +1,349 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/domainErrors"
+	"Go-Gin-Basic-Template/encryption"
+	"Go-Gin-Basic-Template/events"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/logging"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/webhook"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// webhookQueueSize만큼 쌓이면 Enqueue는 이벤트를 버립니다. 상품 쓰기 요청이 웹훅 저장을 기다리지 않게 합니다.
+	webhookQueueSize = 1024
+	// webhookLease는 전송 한 건을 가져간 뒤 다른 워커가 다시 가져가지 못하는 시간입니다. 전송 타임아웃보다 길어야 합니다.
+	webhookLease       = time.Minute
+	webhookConcurrency = 8
+)
+
+type WebhookController struct {
+	WebhookRepository *repository.WebhookRepository
+	Sender            *webhook.Sender
+
+	queue   chan events.Event
+	dropped atomic.Uint64
+}
+
+func NewWebhookController(webhookRepository *repository.WebhookRepository, sender *webhook.Sender) *WebhookController {
+	return &WebhookController{
+		WebhookRepository: webhookRepository,
+		Sender:            sender,
+		queue:             make(chan events.Event, webhookQueueSize),
+	}
+}
+
+func (c *WebhookController) Insert(ctx context.Context, input *requestTypes.WebhookRequest) (statusCode int, subscription *types.WebhookSubscription, secret string, err error) {
+	if err = validateWebhookRequest(ctx, input); err != nil {
+		return http.StatusBadRequest, nil, "", err
+	}
+	if input.Secret == "" {
+		if input.Secret, err = webhook.NewSecret(); err != nil {
+			return http.StatusInternalServerError, nil, "", err
+		}
+	}
+
+	subscription, err = c.WebhookRepository.Insert(ctx, input)
+	if errors.Is(err, encryption.ErrKeyRingNotConfigured) {
+		return http.StatusServiceUnavailable, nil, "", err
+	}
+	if err != nil {
+		return http.StatusInternalServerError, nil, "", err
+	}
+
+	return http.StatusCreated, subscription, input.Secret, nil
+}
+
+// Update는 PATCH 요청에 있는 필드만 검증하고 바꿉니다.
+func (c *WebhookController) Update(ctx context.Context, id string, input *requestTypes.WebhookPatchRequest) (statusCode int, subscription *types.WebhookSubscription, err error) {
+	if input.URL != nil {
+		if err = webhook.ValidateURL(ctx, *input.URL); err != nil {
+			return http.StatusBadRequest, nil, err
+		}
+	}
+	if input.EventTypes != nil {
+		if err = validateEventTypes(*input.EventTypes); err != nil {
+			return http.StatusBadRequest, nil, err
+		}
+	}
+
+	if err = checkWebhookID(id); err != nil {
+		return http.StatusNotFound, nil, err
+	}
+
+	subscription, err = c.WebhookRepository.Update(ctx, id, input)
+	if errors.Is(err, encryption.ErrKeyRingNotConfigured) {
+		return http.StatusServiceUnavailable, nil, err
+	}
+	if err != nil {
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), nil, err
+	}
+
+	return http.StatusOK, subscription, nil
+}
+
+func (c *WebhookController) Delete(ctx context.Context, id string) (statusCode int, message string, err error) {
+	if err = checkWebhookID(id); err != nil {
+		return http.StatusNotFound, i18n.DeleteFailed, err
+	}
+	err = c.WebhookRepository.Delete(ctx, id)
+	if err != nil {
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), i18n.DeleteFailed, err
+	}
+
+	return http.StatusOK, id, nil
+}
+
+func (c *WebhookController) GetAll(ctx context.Context) (statusCode int, subscriptions *[]types.WebhookSubscription, err error) {
+	subscriptions, err = c.WebhookRepository.GetAll(ctx)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	return http.StatusOK, subscriptions, nil
+}
+
+func (c *WebhookController) Get(ctx context.Context, id string) (statusCode int, subscription *types.WebhookSubscription, err error) {
+	if err = checkWebhookID(id); err != nil {
+		return http.StatusNotFound, nil, err
+	}
+	subscription, err = c.WebhookRepository.GetByID(ctx, id)
+	if err != nil {
+		return domainErrors.StatusCode(err, http.StatusInternalServerError), nil, err
+	}
+
+	return http.StatusOK, subscription, nil
+}
+
+func (c *WebhookController) GetDeliveries(ctx context.Context, id string, filter *requestTypes.WebhookDeliveryFilter) (statusCode int, deliveries *[]types.WebhookDelivery, err error) {
+	switch filter.Status {
+	case "", types.WebhookDeliveryPending, types.WebhookDeliverySucceeded, types.WebhookDeliveryFailed:
+	default:
+		return http.StatusBadRequest, nil, fmt.Errorf("unknown delivery status %q", filter.Status)
+	}
+
+	if err = checkWebhookID(id); err != nil {
+		return http.StatusNotFound, nil, err
+	}
+	deliveries, err = c.WebhookRepository.GetDeliveries(ctx, id, filter)
+	if err != nil {
+		return http.StatusInternalServerError, nil, err
+	}
+
+	return http.StatusOK, deliveries, nil
+}
+
+func validateWebhookRequest(ctx context.Context, input *requestTypes.WebhookRequest) error {
+	if err := webhook.ValidateURL(ctx, input.URL); err != nil {
+		return err
+	}
+	return validateEventTypes(input.EventTypes)
+}
+
+func validateEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		if !contains(events.ProductEventTypes, eventType) {
+			return fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+	return nil
+}
+
+// checkWebhookID는 UUID 형식이 아닌 ID를 쿼리 전에 걸러냅니다. 없는 구독과 같게 취급합니다.
+func checkWebhookID(id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return repository.ErrWebhookNotFound
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Enqueue는 events.Broker.OnPublish에 등록합니다. 실제 저장은 Dispatch 고루틴이 모아서 합니다.
+// Publish는 상품 쓰기 요청 안에서 불리므로 큐가 가득 차면 기다리지 않고 이벤트를 버리고 로그와 Dropped에 남깁니다.
+func (c *WebhookController) Enqueue(event events.Event) {
+	select {
+	case c.queue <- event:
+	default:
+		dropped := c.dropped.Add(1)
+		slog.Error("webhook queue is full, dropping event", "event_type", event.Type, "tenant_id", event.TenantID, "product_id", event.ProductID, "dropped_total", dropped)
+	}
+}
+
+// Dropped는 큐가 가득 차서 버린 이벤트 수입니다.
+func (c *WebhookController) Dropped() uint64 {
+	return c.dropped.Load()
+}
+
+// Dispatch는 큐에 쌓인 이벤트를 꺼내서 구독마다 전송 기록을 만듭니다. ctx가 끝날 때까지 돕니다.
+func (c *WebhookController) Dispatch(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-c.queue:
+			batch := []events.Event{event}
+		drain:
+			for len(batch) < webhookQueueSize {
+				select {
+				case event = <-c.queue:
+					batch = append(batch, event)
+				default:
+					break drain
+				}
+			}
+			if err := c.createDeliveries(ctx, batch); err != nil {
+				logging.FromContext(ctx).Error("failed to enqueue webhook deliveries", "error", err)
+			}
+		}
+	}
+}
+
+// createDeliveries는 테넌트마다 구독을 한 번만 읽고 같은 테넌트의 이벤트 전송 기록을 한 번에 저장합니다.
+func (c *WebhookController) createDeliveries(ctx context.Context, batch []events.Event) error {
+	byTenant := make(map[string][]events.Event)
+	for _, event := range batch {
+		byTenant[event.TenantID] = append(byTenant[event.TenantID], event)
+	}
+
+	var errs []error
+	now := time.Now()
+	for tenantID, tenantEvents := range byTenant {
+		tenantCtx := tenancy.WithTenant(ctx, tenantID)
+		subscriptions, err := c.WebhookRepository.GetActive(tenantCtx)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		var deliveries []types.WebhookDelivery
+		for _, event := range tenantEvents {
+			payload, err := json.Marshal(event)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for i := range subscriptions {
+				if !subscriptions[i].Accepts(event.Type) {
+					continue
+				}
+				deliveries = append(deliveries, types.WebhookDelivery{
+					ID:             uuid.New(),
+					SubscriptionID: subscriptions[i].ID,
+					EventType:      event.Type,
+					Payload:        payload,
+					Status:         types.WebhookDeliveryPending,
+					NextAttemptAt:  now,
+					CreateAt:       now,
+				})
+			}
+		}
+		if len(deliveries) == 0 {
+			continue
+		}
+		if err := c.WebhookRepository.InsertDeliveries(tenantCtx, deliveries); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DeliverDue는 시도할 때가 된 전송을 limit 개까지 가져와서 보내고, 처리한 개수를 돌려줍니다.
+func (c *WebhookController) DeliverDue(ctx context.Context, limit int) (processed int, err error) {
+	deliveries, err := c.WebhookRepository.ClaimDue(ctx, limit, webhookLease)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, webhookConcurrency)
+	)
+	for i := range deliveries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery *types.WebhookDelivery) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := c.deliver(ctx, delivery)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			processed++
+		}(&deliveries[i])
+	}
+	wg.Wait()
+
+	return processed, errors.Join(errs...)
+}
+
+func (c *WebhookController) deliver(ctx context.Context, delivery *types.WebhookDelivery) error {
+	subscription := &delivery.Subscription
+	if !subscription.Active || subscription.DeleteAt.Valid {
+		delivery.Status = types.WebhookDeliveryFailed
+		delivery.LastError = "subscription is disabled or deleted"
+		return c.WebhookRepository.SaveDelivery(ctx, delivery)
+	}
+
+	result := c.Sender.Send(ctx, webhook.Request{
+		DeliveryID: delivery.ID.String(),
+		EventType:  delivery.EventType,
+		URL:        subscription.URL,
+		Secret:     subscription.Secret,
+		Payload:    delivery.Payload,
+	})
+	applyDeliveryResult(delivery, result, time.Now(), webhook.MaxAttempts())
+
+	return c.WebhookRepository.RecordAttempt(ctx, delivery, result.Succeeded(), webhook.DisableAfter())
+}
+
+// applyDeliveryResult는 한 번의 시도 결과로 전송 상태를 바꿉니다. 실패하면 지수 백오프로 다음 시도를 잡고,
+// maxAttempts를 다 쓰면 failed로 끝냅니다.
+func applyDeliveryResult(delivery *types.WebhookDelivery, result webhook.Result, now time.Time, maxAttempts int) {
+	delivery.Attempts++
+	delivery.LastStatusCode = result.StatusCode
+	delivery.LastError = ""
+
+	if result.Succeeded() {
+		delivery.Status = types.WebhookDeliverySucceeded
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	if result.Err != nil {
+		delivery.LastError = result.Err.Error()
+	}
+	if delivery.Attempts >= maxAttempts {
+		delivery.Status = types.WebhookDeliveryFailed
+		return
+	}
+	delivery.NextAttemptAt = now.Add(webhook.Backoff(delivery.Attempts))
+}
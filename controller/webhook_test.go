@@ -0,0 +1,332 @@
+package controller
+
+import (
+	"Go-Gin-Basic-Template/encryption"
+	"Go-Gin-Basic-Template/events"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/webhook"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockWebhookController(t *testing.T) (*WebhookController, sqlmock.Sqlmock) {
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	// GORM 설정
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		Conn:                 mockDB,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, tenancy.Register(db))
+
+	// 서명 키는 암호화해서 저장하므로 키 링이 필요합니다.
+	ring, err := encryption.NewKeyRing("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, 32)})
+	require.NoError(t, err)
+	encryption.SetKeyRing(ring)
+	t.Cleanup(func() { encryption.SetKeyRing(nil) })
+
+	// 받는 쪽이 httptest 서버(127.0.0.1)이므로 주소를 막지 않는 클라이언트로 보냅니다.
+	return NewWebhookController(&repository.WebhookRepository{DB: db}, &webhook.Sender{Client: &http.Client{}}), mock
+}
+
+// expectClaim은 전송 한 건과 그 구독을 가져오는 쿼리를 설정합니다.
+func expectClaim(t *testing.T, mock sqlmock.Sqlmock, deliveryID, subscriptionID uuid.UUID, url string, attempts int) {
+	secret, err := encryption.CurrentKeyRing().Encrypt([]byte(`"secret"`), encryption.AdditionalData("webhook_subscriptions", "secret", subscriptionID))
+	require.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_deliveries" WHERE status = $1 AND next_attempt_at <= $2 ORDER BY next_attempt_at LIMIT $3 FOR UPDATE SKIP LOCKED`)).
+		WithArgs(types.WebhookDeliveryPending, sqlmock.AnyArg(), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "subscription_id", "event_type", "payload", "status", "attempts"}).
+			AddRow(deliveryID, "tenant-a", subscriptionID, events.ProductCreated, []byte(`{"type":"product.created"}`), types.WebhookDeliveryPending, attempts))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_subscriptions" WHERE "webhook_subscriptions"."id" = $1`)).
+		WithArgs(subscriptionID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "url", "secret", "active"}).
+			AddRow(subscriptionID, "tenant-a", url, secret, true))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_deliveries" SET "next_attempt_at"=$1,"update_at"=$2 WHERE id IN ($3)`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), deliveryID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+}
+
+func TestWebhookController_DeliverDue_Success(t *testing.T) {
+	// 모의 객체 설정
+	controller, mock := setupMockWebhookController(t)
+	deliveryID, subscriptionID := uuid.New(), uuid.New()
+
+	var signature string
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		signature = r.Header.Get(webhook.HeaderSignature)
+		assert.NoError(t, webhook.Verify("secret", signature, body, time.Minute, time.Now()))
+		assert.Equal(t, deliveryID.String(), r.Header.Get(webhook.HeaderID))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	expectClaim(t, mock, deliveryID, subscriptionID, receiver.URL, 0)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_deliveries" SET "status"=$1,"attempts"=$2,"next_attempt_at"=$3,"last_status_code"=$4,"last_error"=$5,"delivered_at"=$6,"update_at"=$7 WHERE "id" = $8`)).
+		WithArgs(types.WebhookDeliverySucceeded, 1, sqlmock.AnyArg(), http.StatusOK, "", sqlmock.AnyArg(), sqlmock.AnyArg(), deliveryID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_subscriptions" SET "consecutive_failures"=$1 WHERE id = $2`)).
+		WithArgs(0, subscriptionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	processed, err := controller.DeliverDue(context.Background(), 10)
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.NotEmpty(t, signature)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookController_DeliverDue_FailureSchedulesRetry(t *testing.T) {
+	// 모의 객체 설정
+	controller, mock := setupMockWebhookController(t)
+	deliveryID, subscriptionID := uuid.New(), uuid.New()
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	expectClaim(t, mock, deliveryID, subscriptionID, receiver.URL, 2)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_deliveries" SET "status"=$1,"attempts"=$2`)).
+		WithArgs(types.WebhookDeliveryPending, 3, sqlmock.AnyArg(), http.StatusServiceUnavailable, "receiver responded 503: unavailable", nil, sqlmock.AnyArg(), deliveryID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_subscriptions" SET "active"=CASE WHEN consecutive_failures + 1 >= $1 THEN false ELSE active END,"consecutive_failures"=consecutive_failures + 1,"disabled_at"=CASE WHEN consecutive_failures + 1 >= $2 AND active THEN $3 ELSE disabled_at END WHERE id = $4`)).
+		WithArgs(webhook.DisableAfter(), webhook.DisableAfter(), sqlmock.AnyArg(), subscriptionID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	processed, err := controller.DeliverDue(context.Background(), 10)
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestApplyDeliveryResult(t *testing.T) {
+	now := time.Now()
+
+	t.Run("success", func(t *testing.T) {
+		delivery := &types.WebhookDelivery{Status: types.WebhookDeliveryPending, Attempts: 1, LastError: "earlier"}
+		applyDeliveryResult(delivery, webhook.Result{StatusCode: http.StatusAccepted}, now, 3)
+
+		assert.Equal(t, types.WebhookDeliverySucceeded, delivery.Status)
+		assert.Equal(t, 2, delivery.Attempts)
+		assert.Empty(t, delivery.LastError)
+		assert.Equal(t, &now, delivery.DeliveredAt)
+	})
+
+	t.Run("retry with backoff", func(t *testing.T) {
+		delivery := &types.WebhookDelivery{Status: types.WebhookDeliveryPending, Attempts: 1}
+		applyDeliveryResult(delivery, webhook.Result{Err: errors.New("connection refused")}, now, 3)
+
+		assert.Equal(t, types.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, now.Add(webhook.Backoff(2)), delivery.NextAttemptAt)
+		assert.Equal(t, "connection refused", delivery.LastError)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		delivery := &types.WebhookDelivery{Status: types.WebhookDeliveryPending, Attempts: 2}
+		applyDeliveryResult(delivery, webhook.Result{StatusCode: http.StatusInternalServerError, Err: errors.New("boom")}, now, 3)
+
+		assert.Equal(t, types.WebhookDeliveryFailed, delivery.Status)
+		assert.Equal(t, 3, delivery.Attempts)
+		assert.Nil(t, delivery.DeliveredAt)
+	})
+}
+
+func TestWebhookController_CreateDeliveries_FiltersEventTypes(t *testing.T) {
+	// 모의 객체 설정
+	controller, mock := setupMockWebhookController(t)
+	all, updatesOnly := uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id","event_types" FROM "webhook_subscriptions" WHERE active = $1 AND "webhook_subscriptions"."tenant_id" = $2 AND "webhook_subscriptions"."delete_at" IS NULL`)).
+		WithArgs(true, "tenant-a").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_types"}).
+			AddRow(all, nil).
+			AddRow(updatesOnly, `["product.updated"]`))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "webhook_deliveries"`)).
+		WithArgs(
+			sqlmock.AnyArg(), "tenant-a", all, events.ProductCreated, sqlmock.AnyArg(), types.WebhookDeliveryPending, 0,
+			sqlmock.AnyArg(), 0, "", nil, sqlmock.AnyArg(), sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	err := controller.createDeliveries(context.Background(), []events.Event{
+		{ID: 1, Type: events.ProductCreated, TenantID: "tenant-a", ProductID: "p1"},
+	})
+
+	// 검증
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookController_Enqueue_DropsWhenQueueIsFull(t *testing.T) {
+	// 테스트 설정 - Dispatch가 돌지 않아 한 칸짜리 큐가 비지 않습니다.
+	controller := &WebhookController{queue: make(chan events.Event, 1)}
+
+	// 테스트 실행 - 큐가 가득 차도 Publish 쪽이 멈추지 않아야 합니다.
+	controller.Enqueue(events.Event{ID: 1, Type: events.ProductCreated, TenantID: "tenant-a"})
+	controller.Enqueue(events.Event{ID: 2, Type: events.ProductUpdated, TenantID: "tenant-a"})
+
+	// 검증
+	assert.Len(t, controller.queue, 1)
+	assert.Equal(t, uint64(1), controller.Dropped())
+}
+
+func TestWebhookController_Insert_Validation(t *testing.T) {
+	// 모의 객체 설정 - 잘못된 요청은 DB까지 가지 않습니다.
+	controller, mock := setupMockWebhookController(t)
+	ctx := tenancy.WithTenant(context.Background(), "tenant-a")
+
+	// 테스트 실행
+	badURL, _, _, urlErr := controller.Insert(ctx, &requestTypes.WebhookRequest{URL: "ftp://example.com"})
+	private, _, _, privateErr := controller.Insert(ctx, &requestTypes.WebhookRequest{URL: "http://169.254.169.254/latest/meta-data"})
+	badType, _, _, typeErr := controller.Insert(ctx, &requestTypes.WebhookRequest{URL: "https://example.com/hook", EventTypes: []string{"order.created"}})
+
+	// 검증
+	assert.Equal(t, http.StatusBadRequest, badURL)
+	assert.Error(t, urlErr)
+	assert.Equal(t, http.StatusBadRequest, private)
+	assert.ErrorIs(t, privateErr, webhook.ErrForbiddenAddress)
+	assert.Equal(t, http.StatusBadRequest, badType)
+	assert.EqualError(t, typeErr, `unknown event type "order.created"`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookController_Insert_GeneratesSecret(t *testing.T) {
+	// 모의 객체 설정
+	controller, mock := setupMockWebhookController(t)
+	ctx := tenancy.WithTenant(context.Background(), "tenant-a")
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "webhook_subscriptions"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	statusCode, subscription, secret, err := controller.Insert(ctx, &requestTypes.WebhookRequest{URL: "https://example.com/hook"})
+
+	// 검증
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, statusCode)
+	assert.True(t, subscription.Active)
+	assert.Regexp(t, `^whsec_`, secret)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookController_NotFound(t *testing.T) {
+	// 테스트 설정
+	c, mock := setupMockWebhookController(t)
+	ctx := tenancy.WithTenant(context.Background(), "tenant-a")
+	id := uuid.NewString()
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(`SELECT .* FROM "webhook_subscriptions" WHERE id = \$1`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_subscriptions" SET "delete_at"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	getStatus, _, getErr := c.Get(ctx, id)
+	deleteStatus, _, deleteErr := c.Delete(ctx, id)
+	malformedStatus, _, malformedErr := c.Delete(ctx, "missing")
+
+	// 검증 - UUID가 아닌 ID는 DB에 보내지 않습니다.
+	assert.Equal(t, http.StatusNotFound, getStatus)
+	assert.ErrorIs(t, getErr, gorm.ErrRecordNotFound)
+	assert.Equal(t, http.StatusNotFound, deleteStatus)
+	assert.ErrorIs(t, deleteErr, repository.ErrWebhookNotFound)
+	assert.Equal(t, http.StatusNotFound, malformedStatus)
+	assert.ErrorIs(t, malformedErr, repository.ErrWebhookNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookController_Update_OnlySentFields(t *testing.T) {
+	// 테스트 설정 - 비활성화된 구독을 {"active":true}로 다시 켭니다.
+	c, mock := setupMockWebhookController(t)
+	ctx := tenancy.WithTenant(context.Background(), "tenant-a")
+	id := uuid.New()
+	secret, err := encryption.CurrentKeyRing().Encrypt([]byte(`"secret"`), encryption.AdditionalData("webhook_subscriptions", "secret", id))
+	require.NoError(t, err)
+	active := true
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "webhook_subscriptions" WHERE id = \$1 .* FOR UPDATE`).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "url", "event_types", "secret", "active", "consecutive_failures"}).
+			AddRow(id, "tenant-a", "https://example.com/hook", `["product.created"]`, secret, false, 5))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_subscriptions" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	statusCode, subscription, err := c.Update(ctx, id.String(), &requestTypes.WebhookPatchRequest{Active: &active})
+
+	// 검증 - URL과 이벤트 필터는 그대로입니다.
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.True(t, subscription.Active)
+	assert.Zero(t, subscription.ConsecutiveFailures)
+	assert.Equal(t, "https://example.com/hook", subscription.URL)
+	assert.Equal(t, []string{"product.created"}, subscription.EventTypes)
+	assert.Equal(t, "secret", subscription.Secret)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestWebhookController_Update_ValidatesSentFields(t *testing.T) {
+	// 모의 객체 설정 - 보낸 필드만 검증하고, 잘못되면 DB까지 가지 않습니다.
+	c, mock := setupMockWebhookController(t)
+	ctx := tenancy.WithTenant(context.Background(), "tenant-a")
+	private := "http://10.0.0.1/hook"
+	eventTypes := []string{"order.created"}
+
+	// 테스트 실행
+	urlStatus, _, urlErr := c.Update(ctx, uuid.NewString(), &requestTypes.WebhookPatchRequest{URL: &private})
+	typeStatus, _, typeErr := c.Update(ctx, uuid.NewString(), &requestTypes.WebhookPatchRequest{EventTypes: &eventTypes})
+
+	// 검증
+	assert.Equal(t, http.StatusBadRequest, urlStatus)
+	assert.ErrorIs(t, urlErr, webhook.ErrForbiddenAddress)
+	assert.Equal(t, http.StatusBadRequest, typeStatus)
+	assert.EqualError(t, typeErr, `unknown event type "order.created"`)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
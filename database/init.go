@@ -1,6 +1,9 @@
 package database
 
 import (
+	"Go-Gin-Basic-Template/encryption"
+	"Go-Gin-Basic-Template/logging"
+	"Go-Gin-Basic-Template/tenancy"
 	"fmt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -14,6 +17,20 @@ func InitDatabase() (db *gorm.DB, err error) {
 			os.Getenv("POSTGRES_USER"),
 			os.Getenv("POSTGRES_PASS"),
 			os.Getenv("POSTGRES_DB"),
-			os.Getenv("POSTGRES_PORT"))), &gorm.Config{})
-	return db, err
+			os.Getenv("POSTGRES_PORT"))), &gorm.Config{Logger: logging.NewGormLogger()})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tenancy.Register(db); err != nil {
+		return nil, err
+	}
+
+	keyRing, err := encryption.LoadKeyRing()
+	if err != nil {
+		return nil, err
+	}
+	encryption.SetKeyRing(keyRing)
+
+	return db, nil
 }
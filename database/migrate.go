@@ -6,7 +6,22 @@ import (
 )
 
 func Migration(db *gorm.DB) error {
-	return db.AutoMigrate(
+	err := db.AutoMigrate(
+		&types.Tenant{},
 		&types.Product{},
+		&types.ProductRevision{},
+		&types.IdempotencyRecord{},
+		&types.WebhookSubscription{},
+		&types.WebhookDelivery{},
+		&types.User{},
+		&types.RevokedToken{},
+		&types.APIKey{},
+		&types.AuditLog{},
+		&types.RateLimitBucket{},
 	)
+	if err != nil {
+		return err
+	}
+
+	return CreateReportViews(db)
 }
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"gorm.io/gorm"
+	"os"
+	"time"
+)
+
+const (
+	ProductSummaryView  = "product_report_summary_mv"
+	ProductCategoryView = "product_report_category_mv"
+	ProductPeriodView   = "product_report_period_mv"
+)
+
+var reportViews = []string{
+	`CREATE MATERIALIZED VIEW IF NOT EXISTS ` + ProductSummaryView + ` AS
+		SELECT tenant_id,
+			COUNT(*) AS count,
+			MIN(price) AS min_price,
+			MAX(price) AS max_price,
+			AVG(price) AS avg_price,
+			percentile_cont(0.5) WITHIN GROUP (ORDER BY price) AS median_price
+		FROM products
+		WHERE delete_at IS NULL
+		GROUP BY tenant_id`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS ` + ProductSummaryView + `_key ON ` + ProductSummaryView + ` (tenant_id)`,
+	`CREATE MATERIALIZED VIEW IF NOT EXISTS ` + ProductCategoryView + ` AS
+		SELECT tenant_id, category, COUNT(*) AS count
+		FROM products
+		WHERE delete_at IS NULL
+		GROUP BY tenant_id, category`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS ` + ProductCategoryView + `_key ON ` + ProductCategoryView + ` (tenant_id, category)`,
+	`CREATE MATERIALIZED VIEW IF NOT EXISTS ` + ProductPeriodView + ` AS
+		SELECT tenant_id, 'week' AS interval, date_trunc('week', create_at) AS period, COUNT(*) AS count
+		FROM products
+		WHERE delete_at IS NULL
+		GROUP BY tenant_id, date_trunc('week', create_at)
+		UNION ALL
+		SELECT tenant_id, 'month' AS interval, date_trunc('month', create_at) AS period, COUNT(*) AS count
+		FROM products
+		WHERE delete_at IS NULL
+		GROUP BY tenant_id, date_trunc('month', create_at)`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS ` + ProductPeriodView + `_key ON ` + ProductPeriodView + ` (tenant_id, interval, period)`,
+}
+
+func CreateReportViews(db *gorm.DB) error {
+	for _, statement := range reportViews {
+		if err := db.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReportRefreshInterval이 0이면 머티리얼라이즈드 뷰를 쓰지 않고 항상 실시간으로 집계합니다.
+func ReportRefreshInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("REPORT_REFRESH_INTERVAL"))
+	if err != nil || interval < 0 {
+		return 0
+	}
+	return interval
+}
@@ -0,0 +1,98 @@
+package domainErrors
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Kind는 오류의 종류입니다. HTTP 상태 코드와 problem 응답의 title이 Kind로 정해집니다.
+type Kind string
+
+const (
+	KindNotFound           Kind = "not_found"
+	KindConflict           Kind = "conflict"
+	KindValidation         Kind = "validation"
+	KindPreconditionFailed Kind = "precondition_failed"
+	KindUnauthorized       Kind = "unauthorized"
+)
+
+var statusCodes = map[Kind]int{
+	KindNotFound:           http.StatusNotFound,
+	KindConflict:           http.StatusConflict,
+	KindValidation:         http.StatusUnprocessableEntity,
+	KindPreconditionFailed: http.StatusPreconditionFailed,
+	KindUnauthorized:       http.StatusUnauthorized,
+}
+
+// Error는 repository와 controller가 돌려주는 도메인 오류입니다.
+// Code는 클라이언트가 분기에 쓰는 안정적인 값이고(예: "product.not_found"), Detail은 사람이 읽는 설명입니다.
+type Error struct {
+	Kind   Kind
+	Code   string
+	Detail string
+	// Err는 원인 오류입니다. 응답에는 나가지 않고 로그와 errors.Is에만 씁니다.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Code + ": " + e.Detail + ": " + e.Err.Error()
+	}
+	return e.Code + ": " + e.Detail
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Wrap은 원인 오류를 붙인 복사본입니다.
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+func (e *Error) StatusCode() int {
+	return statusCodes[e.Kind]
+}
+
+func NotFound(code string, detail string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Detail: detail}
+}
+
+func Conflict(code string, detail string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Detail: detail}
+}
+
+func Validation(code string, detail string) *Error {
+	return &Error{Kind: KindValidation, Code: code, Detail: detail}
+}
+
+func PreconditionFailed(code string, detail string) *Error {
+	return &Error{Kind: KindPreconditionFailed, Code: code, Detail: detail}
+}
+
+func Unauthorized(code string, detail string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Detail: detail}
+}
+
+// As는 err 체인에서 도메인 오류를 찾습니다.
+func As(err error) (*Error, bool) {
+	var domainError *Error
+	ok := errors.As(err, &domainError)
+	return domainError, ok
+}
+
+// Is는 err 체인에 kind 종류의 도메인 오류가 있는지 확인합니다.
+func Is(err error, kind Kind) bool {
+	domainError, ok := As(err)
+	return ok && domainError.Kind == kind
+}
+
+// StatusCode는 도메인 오류면 그 상태 코드를, 아니면 fallback을 돌려줍니다.
+func StatusCode(err error, fallback int) int {
+	if domainError, ok := As(err); ok {
+		return domainError.StatusCode()
+	}
+	return fallback
+}
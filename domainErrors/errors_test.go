@@ -0,0 +1,44 @@
+package domainErrors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_WrapKeepsCause(t *testing.T) {
+	// 테스트 설정
+	cause := errors.New("record not found")
+	notFound := NotFound("product.not_found", "상품을 찾을 수 없습니다")
+
+	// 테스트 실행
+	err := fmt.Errorf("조회: %w", notFound.Wrap(cause))
+
+	// 검증 - Wrap은 원래 값을 바꾸지 않습니다.
+	assert.ErrorIs(t, err, cause)
+	assert.Nil(t, notFound.Err)
+	assert.True(t, Is(err, KindNotFound))
+	assert.False(t, Is(err, KindConflict))
+	assert.Equal(t, http.StatusNotFound, StatusCode(err, http.StatusInternalServerError))
+}
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{Conflict("c", ""), http.StatusConflict},
+		{Validation("v", ""), http.StatusUnprocessableEntity},
+		{PreconditionFailed("p", ""), http.StatusPreconditionFailed},
+		{Unauthorized("u", ""), http.StatusUnauthorized},
+		{errors.New("다른 오류"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행 및 검증
+		assert.Equal(t, tt.expected, StatusCode(tt.err, http.StatusInternalServerError), tt.err.Error())
+	}
+}
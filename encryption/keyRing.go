@@ -0,0 +1,112 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var (
+	ErrKeyRingNotConfigured = errors.New("encryption key ring is not configured")
+	ErrUnknownKey           = errors.New("ciphertext was encrypted with an unknown key")
+	ErrMalformedCiphertext  = errors.New("malformed ciphertext")
+	ErrMissingPrimaryKey    = errors.New("encrypted field needs a primary key before it is saved")
+)
+
+// KeyRing은 키 ID별 AES-GCM 키를 들고 있고, 새로 암호화할 때는 활성 키만 사용합니다.
+// 암호문은 "<키 ID>:<base64(nonce|ciphertext)>" 형식이라 키를 교체해도 예전 암호문을 복호화할 수 있습니다.
+type KeyRing struct {
+	activeKeyID string
+	keys        map[string]cipher.AEAD
+}
+
+func NewKeyRing(activeKeyID string, keys map[string][]byte) (*KeyRing, error) {
+	ring := &KeyRing{activeKeyID: activeKeyID, keys: make(map[string]cipher.AEAD, len(keys))}
+	for keyID, key := range keys {
+		if keyID == "" || strings.Contains(keyID, ":") {
+			return nil, fmt.Errorf("invalid encryption key id %q", keyID)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %q: %w", keyID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		ring.keys[keyID] = aead
+	}
+	if _, ok := ring.keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active encryption key %q is not in the key ring", activeKeyID)
+	}
+
+	return ring, nil
+}
+
+// LoadKeyRing은 ENCRYPTION_KEYS("kid1:base64key,kid2:base64key")와 ENCRYPTION_ACTIVE_KEY를 읽습니다.
+// ENCRYPTION_KEYS가 비어 있으면 nil을 돌려줍니다.
+func LoadKeyRing() (*KeyRing, error) {
+	raw := strings.TrimSpace(os.Getenv("ENCRYPTION_KEYS"))
+	if raw == "" {
+		return nil, nil
+	}
+
+	keys := map[string][]byte{}
+	for _, entry := range strings.Split(raw, ",") {
+		keyID, encoded, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, fmt.Errorf("ENCRYPTION_KEYS entry %q must be <kid>:<base64 key>", entry)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("ENCRYPTION_KEYS entry %q is not base64: %w", keyID, err)
+		}
+		keys[keyID] = key
+	}
+
+	return NewKeyRing(os.Getenv("ENCRYPTION_ACTIVE_KEY"), keys)
+}
+
+func (k *KeyRing) ActiveKeyID() string {
+	return k.activeKeyID
+}
+
+func (k *KeyRing) Encrypt(plaintext []byte, additionalData []byte) (string, error) {
+	aead := k.keys[k.activeKeyID]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := aead.Seal(nonce, nonce, plaintext, additionalData)
+	return k.activeKeyID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (k *KeyRing) Decrypt(ciphertext string, additionalData []byte) ([]byte, error) {
+	keyID, encoded, ok := strings.Cut(ciphertext, ":")
+	if !ok {
+		return nil, ErrMalformedCiphertext
+	}
+	aead, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, keyID)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformedCiphertext
+	}
+
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, additionalData)
+}
+
+func KeyID(ciphertext string) string {
+	keyID, _, _ := strings.Cut(ciphertext, ":")
+	return keyID
+}
@@ -0,0 +1,102 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func TestKeyRing_EncryptDecrypt(t *testing.T) {
+	// 테스트 설정
+	ring, err := NewKeyRing("k1", map[string][]byte{"k1": testKey(1)})
+	require.NoError(t, err)
+
+	// 테스트 실행
+	ciphertext, err := ring.Encrypt([]byte("원가 1200"), []byte("products.internal_notes"))
+	require.NoError(t, err)
+	plaintext, err := ring.Decrypt(ciphertext, []byte("products.internal_notes"))
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, "원가 1200", string(plaintext))
+	assert.True(t, strings.HasPrefix(ciphertext, "k1:"))
+	assert.Equal(t, "k1", KeyID(ciphertext))
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	// 테스트 설정 - k1으로 암호화한 뒤 활성 키를 k2로 교체합니다.
+	oldRing, err := NewKeyRing("k1", map[string][]byte{"k1": testKey(1)})
+	require.NoError(t, err)
+	ciphertext, err := oldRing.Encrypt([]byte("비밀"), nil)
+	require.NoError(t, err)
+
+	newRing, err := NewKeyRing("k2", map[string][]byte{"k1": testKey(1), "k2": testKey(2)})
+	require.NoError(t, err)
+
+	// 테스트 실행
+	plaintext, err := newRing.Decrypt(ciphertext, nil)
+	reencrypted, encryptErr := newRing.Encrypt(plaintext, nil)
+
+	// 검증
+	assert.NoError(t, err)
+	assert.NoError(t, encryptErr)
+	assert.Equal(t, "비밀", string(plaintext))
+	assert.Equal(t, "k2", KeyID(reencrypted))
+}
+
+func TestKeyRing_DecryptFailures(t *testing.T) {
+	// 테스트 설정
+	ring, err := NewKeyRing("k1", map[string][]byte{"k1": testKey(1)})
+	require.NoError(t, err)
+	ciphertext, err := ring.Encrypt([]byte("비밀"), []byte("products.supplier_cost"))
+	require.NoError(t, err)
+
+	// 검증 - 다른 컬럼 이름, 모르는 키, 잘못된 형식은 모두 실패해야 합니다.
+	_, err = ring.Decrypt(ciphertext, []byte("products.internal_notes"))
+	assert.Error(t, err)
+	_, err = ring.Decrypt("k9:"+strings.TrimPrefix(ciphertext, "k1:"), []byte("products.supplier_cost"))
+	assert.ErrorIs(t, err, ErrUnknownKey)
+	_, err = ring.Decrypt("no-separator", nil)
+	assert.ErrorIs(t, err, ErrMalformedCiphertext)
+}
+
+func TestNewKeyRing_InvalidConfig(t *testing.T) {
+	// 검증
+	_, err := NewKeyRing("k2", map[string][]byte{"k1": testKey(1)})
+	assert.Error(t, err)
+	_, err = NewKeyRing("k1", map[string][]byte{"k1": []byte("short")})
+	assert.Error(t, err)
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	// 테스트 설정
+	t.Setenv("ENCRYPTION_KEYS", "k1:"+base64.StdEncoding.EncodeToString(testKey(1))+", k2:"+base64.StdEncoding.EncodeToString(testKey(2)))
+	t.Setenv("ENCRYPTION_ACTIVE_KEY", "k2")
+
+	// 테스트 실행
+	ring, err := LoadKeyRing()
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, "k2", ring.ActiveKeyID())
+}
+
+func TestLoadKeyRing_Empty(t *testing.T) {
+	// 테스트 설정
+	t.Setenv("ENCRYPTION_KEYS", "")
+
+	// 테스트 실행
+	ring, err := LoadKeyRing()
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Nil(t, ring)
+}
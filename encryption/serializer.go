@@ -0,0 +1,109 @@
+package encryption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"gorm.io/gorm/schema"
+)
+
+const SerializerName = "encrypted"
+
+var keyRing atomic.Pointer[KeyRing]
+
+func init() {
+	schema.RegisterSerializer(SerializerName, Serializer{})
+}
+
+// SetKeyRing은 `gorm:"serializer:encrypted"` 필드가 사용할 키 링을 지정합니다.
+func SetKeyRing(ring *KeyRing) {
+	keyRing.Store(ring)
+}
+
+func CurrentKeyRing() *KeyRing {
+	return keyRing.Load()
+}
+
+// Serializer는 필드 값을 JSON으로 만든 뒤 AES-GCM으로 암호화해서 저장합니다.
+// 복호화는 DB에서 값을 읽어 올 때(Scan)만 일어나고, 제로 값은 NULL로 저장합니다.
+type Serializer struct{}
+
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue interface{}) error {
+	fieldValue := reflect.New(field.FieldType)
+	if dbValue != nil {
+		var ciphertext string
+		switch value := dbValue.(type) {
+		case string:
+			ciphertext = value
+		case []byte:
+			ciphertext = string(value)
+		default:
+			return fmt.Errorf("failed to decrypt %s: unsupported value %#v", field.Name, dbValue)
+		}
+
+		if ciphertext != "" {
+			ring := CurrentKeyRing()
+			if ring == nil {
+				return ErrKeyRingNotConfigured
+			}
+			plaintext, err := ring.Decrypt(ciphertext, rowAdditionalData(ctx, field, dst))
+			if err != nil {
+				// 행을 묶기 전에 저장한 암호문입니다. cmd/reencrypt -all로 다시 암호화하면 더는 쓰지 않습니다.
+				plaintext, err = ring.Decrypt(ciphertext, []byte(field.Schema.Table+"."+field.DBName))
+			}
+			if err != nil {
+				return fmt.Errorf("failed to decrypt %s: %w", field.Name, err)
+			}
+			if err = json.Unmarshal(plaintext, fieldValue.Interface()); err != nil {
+				return err
+			}
+		}
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue.Elem())
+	return nil
+}
+
+func (Serializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue interface{}) (interface{}, error) {
+	if fieldValue == nil || reflect.ValueOf(fieldValue).IsZero() {
+		return nil, nil
+	}
+
+	ring := CurrentKeyRing()
+	if ring == nil {
+		return nil, ErrKeyRingNotConfigured
+	}
+	additionalData := rowAdditionalData(ctx, field, dst)
+	if additionalData == nil {
+		return nil, fmt.Errorf("failed to encrypt %s: %w", field.Name, ErrMissingPrimaryKey)
+	}
+	plaintext, err := json.Marshal(fieldValue)
+	if err != nil {
+		return nil, err
+	}
+
+	return ring.Encrypt(plaintext, additionalData)
+}
+
+// AdditionalData는 암호문을 다른 컬럼이나 다른 행(다른 테넌트의 행 포함)으로 옮겨 붙여도 복호화되지 않도록
+// 테이블, 컬럼 이름과 행의 기본 키를 묶습니다.
+func AdditionalData(table string, column string, primaryKey interface{}) []byte {
+	return []byte(fmt.Sprintf("%s.%s:%v", table, column, primaryKey))
+}
+
+// rowAdditionalData는 dst 행의 기본 키로 AdditionalData를 만듭니다. 기본 키가 아직 비어 있으면 nil입니다.
+// 복호화할 때도 기본 키가 필요하므로 암호화 필드는 기본 키 컬럼과 함께 조회해야 합니다.
+func rowAdditionalData(ctx context.Context, field *schema.Field, dst reflect.Value) []byte {
+	primaryField := field.Schema.PrioritizedPrimaryField
+	if primaryField == nil {
+		return nil
+	}
+	primaryKey, zero := primaryField.ValueOf(ctx, dst)
+	if zero {
+		return nil
+	}
+	return AdditionalData(field.Schema.Table, field.DBName, primaryKey)
+}
@@ -0,0 +1,177 @@
+package encryption
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type secretRecord struct {
+	ID    int
+	Cost  *float64 `gorm:"serializer:encrypted"`
+	Notes string   `gorm:"serializer:encrypted"`
+}
+
+// ciphertextArg는 INSERT 인자로 넘어간 암호문을 잡아둡니다.
+type ciphertextArg struct {
+	value *string
+}
+
+func (a ciphertextArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	*a.value = s
+	return ok && strings.HasPrefix(s, "k1:")
+}
+
+func setupSerializerDB(t *testing.T) (sqlmock.Sqlmock, *gorm.DB) {
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	// GORM 설정
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		Conn:                 mockDB,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	ring, err := NewKeyRing("k1", map[string][]byte{"k1": testKey(1)})
+	require.NoError(t, err)
+	SetKeyRing(ring)
+	t.Cleanup(func() { SetKeyRing(nil) })
+
+	return mock, db
+}
+
+func TestSerializer_RoundTrip(t *testing.T) {
+	// 테스트 설정
+	mock, db := setupSerializerDB(t)
+	cost := 1200.5
+	var stored string
+
+	// SQL 쿼리 모의 설정 - 평문이 아니라 암호문이 저장되어야 합니다.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "secret_records" ("cost","notes","id") VALUES ($1,$2,$3) RETURNING "id"`)).
+		WithArgs(ciphertextArg{value: new(string)}, ciphertextArg{value: &stored}, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	err := db.Create(&secretRecord{ID: 1, Cost: &cost, Notes: "거래처 메모"}).Error
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "secret_records"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "cost", "notes"}).AddRow(1, nil, stored))
+	var record secretRecord
+	err = db.First(&record).Error
+
+	// 검증
+	assert.NoError(t, err)
+	assert.NotContains(t, stored, "거래처")
+	assert.Nil(t, record.Cost)
+	assert.Equal(t, "거래처 메모", record.Notes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSerializer_ZeroValueIsNull(t *testing.T) {
+	// 테스트 설정
+	mock, db := setupSerializerDB(t)
+	SetKeyRing(nil)
+
+	// SQL 쿼리 모의 설정 - 키 링이 없어도 빈 값은 NULL로 저장됩니다.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "secret_records" ("cost","notes") VALUES ($1,$2) RETURNING "id"`)).
+		WithArgs(nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	err := db.Create(&secretRecord{}).Error
+
+	// 검증
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSerializer_RequiresKeyRing(t *testing.T) {
+	// 테스트 설정
+	mock, db := setupSerializerDB(t)
+	SetKeyRing(nil)
+
+	// SQL 쿼리 모의 설정 - 암호화할 수 없으면 쿼리를 보내지 않고 롤백합니다.
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	// 테스트 실행
+	err := db.Create(&secretRecord{ID: 1, Notes: "메모"}).Error
+
+	// 검증
+	assert.ErrorIs(t, err, ErrKeyRingNotConfigured)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSerializer_RequiresPrimaryKey(t *testing.T) {
+	// 테스트 설정
+	mock, db := setupSerializerDB(t)
+
+	// SQL 쿼리 모의 설정 - 기본 키 없이 암호화하면 나중에 복호화할 수 없으므로 저장하지 않습니다.
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	// 테스트 실행
+	err := db.Create(&secretRecord{Notes: "메모"}).Error
+
+	// 검증
+	assert.ErrorIs(t, err, ErrMissingPrimaryKey)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSerializer_BoundToRow(t *testing.T) {
+	// 테스트 설정 - 1번 행의 암호문을 2번 행에 옮겨 붙입니다.
+	mock, db := setupSerializerDB(t)
+	stored, err := CurrentKeyRing().Encrypt([]byte(`"1번 행 메모"`), AdditionalData("secret_records", "notes", 1))
+	require.NoError(t, err)
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "secret_records"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "cost", "notes"}).AddRow(2, nil, stored))
+
+	// 테스트 실행
+	var record secretRecord
+	err = db.First(&record).Error
+
+	// 검증
+	assert.Error(t, err)
+	assert.NotEqual(t, "1번 행 메모", record.Notes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSerializer_ReadsLegacyCiphertext(t *testing.T) {
+	// 테스트 설정 - 기본 키를 묶기 전에는 테이블과 컬럼 이름만 묶었습니다.
+	mock, db := setupSerializerDB(t)
+	stored, err := CurrentKeyRing().Encrypt([]byte(`"예전 메모"`), []byte("secret_records.notes"))
+	require.NoError(t, err)
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "secret_records"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "cost", "notes"}).AddRow(1, nil, stored))
+
+	// 테스트 실행
+	var record secretRecord
+	err = db.First(&record).Error
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, "예전 메모", record.Notes)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,209 @@
+package events
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	ProductCreated = "product.created"
+	ProductUpdated = "product.updated"
+	ProductDeleted = "product.deleted"
+
+	// StreamReset은 Last-Event-ID가 재전송 버퍼보다 오래되어 놓친 이벤트가 있을 수 있다는 뜻입니다. 클라이언트는 목록을 다시 조회해야 합니다.
+	StreamReset = "stream.reset"
+)
+
+// ProductEventTypes는 구독에서 고를 수 있는 이벤트 종류입니다.
+var ProductEventTypes = []string{ProductCreated, ProductUpdated, ProductDeleted}
+
+const (
+	defaultBufferSize     = 1000
+	subscriberChannelSize = 64
+)
+
+type Event struct {
+	ID        uint64      `json:"id"`
+	Type      string      `json:"type"`
+	TenantID  string      `json:"-"`
+	ProductID string      `json:"productId"`
+	Category  string      `json:"category"`
+	Data      interface{} `json:"data,omitempty"`
+	CreateAt  time.Time   `json:"createdAt"`
+}
+
+// Filter가 비어 있으면 테넌트의 모든 이벤트를 받습니다. 값이 있으면 목록 중 하나와 일치하는 이벤트만 받습니다.
+type Filter struct {
+	ProductIDs []string
+	Categories []string
+}
+
+func (f Filter) Match(event *Event) bool {
+	if event.Type == StreamReset {
+		return true
+	}
+	if len(f.ProductIDs) > 0 && !contains(f.ProductIDs, event.ProductID) {
+		return false
+	}
+	if len(f.Categories) > 0 && !contains(f.Categories, event.Category) {
+		return false
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// buffer는 테넌트별 최근 이벤트와, 넘쳐서 버린 이벤트 중 가장 마지막 번호를 기억합니다.
+type buffer struct {
+	events  []Event
+	dropped uint64
+}
+
+type subscriber struct {
+	tenantID string
+	filter   Filter
+	ch       chan Event
+}
+
+// Broker는 프로세스 메모리 안에서 이벤트를 구독자에게 전달하고, 테넌트마다 최근 이벤트를 bufferSize 개까지 보관합니다.
+type Broker struct {
+	mu          sync.Mutex
+	lastID      uint64
+	bufferSize  int
+	buffers     map[string]*buffer
+	subscribers map[*subscriber]struct{}
+	hooks       []func(Event)
+}
+
+func NewBroker(bufferSize int) *Broker {
+	if bufferSize <= 0 {
+		bufferSize = defaultBufferSize
+	}
+	return &Broker{
+		bufferSize:  bufferSize,
+		buffers:     make(map[string]*buffer),
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+func BufferSize() int {
+	if size, err := strconv.Atoi(os.Getenv("EVENT_BUFFER_SIZE")); err == nil && size > 0 {
+		return size
+	}
+	return defaultBufferSize
+}
+
+// OnPublish는 모든 테넌트의 이벤트를 받는 함수를 등록합니다. 구독자와 달리 끊기지 않으므로 웹훅처럼 이벤트를 놓치면 안 되는 곳에서 씁니다.
+// 함수는 Publish를 호출한 고루틴에서 실행되므로 오래 걸리는 작업은 따로 넘겨야 합니다.
+func (b *Broker) OnPublish(hook func(Event)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.hooks = append(b.hooks, hook)
+}
+
+// Publish는 이벤트에 번호를 붙여 버퍼에 저장하고 구독자에게 보냅니다. 채널이 가득 찬 느린 구독자는 끊어서 Last-Event-ID로 다시 연결하게 합니다.
+func (b *Broker) Publish(event Event) {
+	if b == nil {
+		return
+	}
+
+	hooks := b.publish(&event)
+	for _, hook := range hooks {
+		hook(event)
+	}
+}
+
+func (b *Broker) publish(event *Event) []func(Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event.ID = b.lastID
+	if event.CreateAt.IsZero() {
+		event.CreateAt = time.Now()
+	}
+
+	buf, ok := b.buffers[event.TenantID]
+	if !ok {
+		buf = &buffer{}
+		b.buffers[event.TenantID] = buf
+	}
+	buf.events = append(buf.events, *event)
+	if over := len(buf.events) - b.bufferSize; over > 0 {
+		buf.dropped = buf.events[over-1].ID
+		buf.events = append(buf.events[:0:0], buf.events[over:]...)
+	}
+
+	for s := range b.subscribers {
+		if s.tenantID != event.TenantID || !s.filter.Match(event) {
+			continue
+		}
+		select {
+		case s.ch <- *event:
+		default:
+			b.remove(s)
+		}
+	}
+
+	return b.hooks
+}
+
+// Subscribe는 lastEventID 이후에 버퍼에 남아 있는 이벤트와 새 이벤트를 받을 채널을 돌려줍니다.
+// lastEventID가 0이면 재전송하지 않습니다. 채널이 닫히면 구독이 끊긴 것이고, cancel은 여러 번 호출해도 됩니다.
+func (b *Broker) Subscribe(tenantID string, lastEventID uint64, filter Filter) (replay []Event, ch <-chan Event, cancel func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if lastEventID > 0 {
+		replay = b.replay(tenantID, lastEventID, filter)
+	}
+
+	s := &subscriber{tenantID: tenantID, filter: filter, ch: make(chan Event, subscriberChannelSize)}
+	b.subscribers[s] = struct{}{}
+
+	return replay, s.ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(s)
+	}
+}
+
+func (b *Broker) replay(tenantID string, lastEventID uint64, filter Filter) []Event {
+	// 서버가 재시작되어 번호가 처음부터 다시 시작된 경우에도 놓친 이벤트를 알 수 없습니다.
+	if lastEventID > b.lastID {
+		return []Event{{ID: b.lastID, Type: StreamReset, TenantID: tenantID, CreateAt: time.Now()}}
+	}
+
+	buf, ok := b.buffers[tenantID]
+	if !ok {
+		return nil
+	}
+
+	var events []Event
+	if lastEventID < buf.dropped {
+		events = append(events, Event{ID: buf.dropped, Type: StreamReset, TenantID: tenantID, CreateAt: time.Now()})
+	}
+	for _, event := range buf.events {
+		if event.ID > lastEventID && filter.Match(&event) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func (b *Broker) remove(s *subscriber) {
+	if _, ok := b.subscribers[s]; !ok {
+		return
+	}
+	delete(b.subscribers, s)
+	close(s.ch)
+}
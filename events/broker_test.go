@@ -0,0 +1,103 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func receive(t *testing.T, ch <-chan Event) Event {
+	t.Helper()
+	select {
+	case event := <-ch:
+		return event
+	default:
+		t.Fatal("expected an event")
+		return Event{}
+	}
+}
+
+func TestBroker_PublishFiltersByTenantAndFilter(t *testing.T) {
+	// 테스트 설정
+	broker := NewBroker(10)
+	_, all, cancelAll := broker.Subscribe("tenant-a", 0, Filter{})
+	defer cancelAll()
+	_, food, cancelFood := broker.Subscribe("tenant-a", 0, Filter{Categories: []string{"식품"}})
+	defer cancelFood()
+	_, other, cancelOther := broker.Subscribe("tenant-b", 0, Filter{})
+	defer cancelOther()
+
+	// 테스트 실행
+	broker.Publish(Event{Type: ProductCreated, TenantID: "tenant-a", ProductID: "p1", Category: "생활"})
+	broker.Publish(Event{Type: ProductUpdated, TenantID: "tenant-a", ProductID: "p2", Category: "식품"})
+
+	// 검증
+	assert.Equal(t, "p1", receive(t, all).ProductID)
+	assert.Equal(t, "p2", receive(t, all).ProductID)
+	assert.Equal(t, "p2", receive(t, food).ProductID)
+	assert.Empty(t, food)
+	assert.Empty(t, other)
+}
+
+func TestBroker_SubscribeReplaysAfterLastEventID(t *testing.T) {
+	// 테스트 설정
+	broker := NewBroker(10)
+	broker.Publish(Event{Type: ProductCreated, TenantID: "tenant-a", ProductID: "p1"})
+	broker.Publish(Event{Type: ProductCreated, TenantID: "tenant-b", ProductID: "p2"})
+	broker.Publish(Event{Type: ProductUpdated, TenantID: "tenant-a", ProductID: "p1"})
+	broker.Publish(Event{Type: ProductCreated, TenantID: "tenant-a", ProductID: "p3"})
+
+	// 테스트 실행
+	replay, _, cancel := broker.Subscribe("tenant-a", 1, Filter{ProductIDs: []string{"p1"}})
+	defer cancel()
+
+	// 검증
+	if assert.Len(t, replay, 1) {
+		assert.Equal(t, uint64(3), replay[0].ID)
+		assert.Equal(t, ProductUpdated, replay[0].Type)
+	}
+}
+
+func TestBroker_SubscribeSignalsResetWhenBufferOverflowed(t *testing.T) {
+	// 테스트 설정 - 버퍼 크기 2에 이벤트 4개를 보내서 1, 2번을 버립니다.
+	broker := NewBroker(2)
+	for i := 0; i < 4; i++ {
+		broker.Publish(Event{Type: ProductCreated, TenantID: "tenant-a"})
+	}
+
+	// 테스트 실행
+	replay, _, cancel := broker.Subscribe("tenant-a", 1, Filter{})
+	defer cancel()
+	restarted, _, cancelRestarted := broker.Subscribe("tenant-a", 100, Filter{})
+	defer cancelRestarted()
+
+	// 검증
+	if assert.Len(t, replay, 3) {
+		assert.Equal(t, StreamReset, replay[0].Type)
+		assert.Equal(t, uint64(2), replay[0].ID)
+		assert.Equal(t, uint64(3), replay[1].ID)
+		assert.Equal(t, uint64(4), replay[2].ID)
+	}
+	if assert.Len(t, restarted, 1) {
+		assert.Equal(t, StreamReset, restarted[0].Type)
+	}
+}
+
+func TestBroker_DropsSlowSubscriber(t *testing.T) {
+	// 테스트 설정
+	broker := NewBroker(10)
+	_, ch, cancel := broker.Subscribe("tenant-a", 0, Filter{})
+
+	// 테스트 실행 - 채널 용량보다 많이 보내면 구독이 끊깁니다.
+	for i := 0; i < subscriberChannelSize+1; i++ {
+		broker.Publish(Event{Type: ProductCreated, TenantID: "tenant-a"})
+	}
+	received := 0
+	for range ch {
+		received++
+	}
+	cancel()
+
+	// 검증
+	assert.Equal(t, subscriberChannelSize, received)
+}
@@ -0,0 +1,165 @@
+package grpcHandler
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/logging"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// AuthorizationMetadata는 REST의 Authorization 헤더와 같은 형식("Bearer <토큰>" 또는 "ApiKey <키>")입니다.
+	AuthorizationMetadata = "authorization"
+	// TenantMetadata는 REST의 X-Tenant-ID 헤더에 해당합니다. 테넌트는 인증한 주체의 것만 쓰고, 보내면 같은 값이어야 합니다.
+	TenantMetadata = "x-tenant-id"
+
+	schemeBearer = "Bearer"
+	schemeAPIKey = "ApiKey"
+)
+
+// Authenticator는 REST의 OIDC, Authenticate, Tenant, Authorizer 미들웨어와 같은 규칙으로 호출을 확인합니다.
+// 주체와 테넌트를 컨텍스트에 넣은 뒤 Permissions에서 메서드의 권한을 찾아 정책으로 확인합니다.
+type Authenticator struct {
+	AuthController     *controller.AuthController
+	APIKeyController   *controller.APIKeyController
+	OIDCVerifier       *auth.OIDCVerifier
+	Policies           *auth.PolicyStore
+	TenantRepository   *repository.TenantRepository
+	AuditLogRepository *repository.AuditLogRepository
+	// Permissions는 메서드("/product.v1.ProductService/GetProduct")마다 필요한 권한입니다. 없는 메서드는 거절합니다.
+	Permissions map[string]string
+}
+
+func (a *Authenticator) Unary(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+func (a *Authenticator) Stream(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authorize(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(server, &serverStream{ServerStream: stream, ctx: ctx})
+}
+
+// serverStream은 인증한 컨텍스트를 핸들러에 넘깁니다.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *Authenticator) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	principal, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx = auth.WithPrincipal(ctx, principal)
+
+	ctx, err = a.tenantContext(ctx, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	permission, ok := a.Permissions[fullMethod]
+	if !ok || !a.Policies.Allows(principal, permission) {
+		a.recordDenial(ctx, principal, fullMethod, permission)
+		return nil, status.Errorf(codes.PermissionDenied, "missing permission %s", permission)
+	}
+	return ctx, nil
+}
+
+// authenticate는 iss가 OIDCVerifier.Issuer인 Bearer 토큰만 외부 IdP로 확인하고, 나머지는 직접 발급한 토큰이나 API 키로 확인합니다.
+func (a *Authenticator) authenticate(ctx context.Context) (*auth.Principal, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(AuthorizationMetadata)
+	if len(values) == 0 {
+		return nil, status.Errorf(codes.Unauthenticated, "%s metadata is required", AuthorizationMetadata)
+	}
+	scheme, credentials, _ := strings.Cut(values[0], " ")
+	credentials = strings.TrimSpace(credentials)
+
+	var (
+		statusCode int
+		principal  *auth.Principal
+		err        error
+	)
+	switch {
+	case credentials == "":
+		return nil, status.Errorf(codes.Unauthenticated, "missing credentials")
+	case strings.EqualFold(scheme, schemeBearer) && a.OIDCVerifier != nil && auth.TokenIssuer(credentials) == a.OIDCVerifier.Issuer:
+		statusCode = http.StatusUnauthorized
+		principal, err = a.OIDCVerifier.Verify(ctx, credentials, time.Now())
+	case strings.EqualFold(scheme, schemeBearer):
+		statusCode, principal, err = a.AuthController.Authenticate(ctx, credentials)
+	case strings.EqualFold(scheme, schemeAPIKey):
+		statusCode, principal, err = a.APIKeyController.Authenticate(ctx, credentials)
+	default:
+		return nil, status.Errorf(codes.Unauthenticated, "unsupported authorization scheme %q", scheme)
+	}
+	if err != nil {
+		return nil, FromHTTPStatus(statusCode, err)
+	}
+	return principal, nil
+}
+
+// tenantContext는 middleware.Tenant와 같이 주체의 테넌트만 씁니다. 테넌트가 없는 토큰은 거절합니다.
+func (a *Authenticator) tenantContext(ctx context.Context, principal *auth.Principal) (context.Context, error) {
+	if principal.TenantID == "" {
+		return nil, status.Errorf(codes.PermissionDenied, "token has no tenant claim")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(TenantMetadata); len(values) > 0 && values[0] != principal.TenantID {
+		return nil, status.Errorf(codes.PermissionDenied, "%s metadata does not match token claim", TenantMetadata)
+	}
+
+	exists, err := a.TenantRepository.Exists(ctx, principal.TenantID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "unknown tenant %s", principal.TenantID)
+	}
+
+	return tenancy.WithTenant(ctx, principal.TenantID), nil
+}
+
+// recordDenial은 middleware.Authorizer와 같이 거절한 호출을 감사 기록에 남깁니다. 실패해도 응답은 그대로 PermissionDenied입니다.
+func (a *Authenticator) recordDenial(ctx context.Context, principal *auth.Principal, fullMethod string, permission string) {
+	if a.AuditLogRepository == nil {
+		return
+	}
+	entry := &types.AuditLog{
+		TenantID:   principal.TenantID,
+		Action:     types.AuditActionAccessDenied,
+		Permission: permission,
+		Method:     http.MethodPost,
+		Path:       fullMethod,
+		ActorType:  types.AuditActorUser,
+		ActorID:    principal.UserID,
+	}
+	if principal.APIKeyID != "" {
+		entry.ActorType, entry.ActorID = types.AuditActorAPIKey, principal.APIKeyID
+	}
+
+	if err := a.AuditLogRepository.Insert(context.WithoutCancel(ctx), entry); err != nil {
+		logging.FromContext(ctx).Error("failed to write audit log", "method", entry.Method, "route", entry.Path, "error", err)
+	}
+}
@@ -0,0 +1,83 @@
+package grpcHandler
+
+import (
+	productv1 "Go-Gin-Basic-Template/proto/product/v1"
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthenticator_RequiresCredentials(t *testing.T) {
+	// 테스트 설정
+	client, _, mock := setupGRPCServer(t, nil)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{"메타데이터 없음", context.Background()},
+		{"테넌트만 보냄", metadata.AppendToOutgoingContext(context.Background(), TenantMetadata, "tenant-a")},
+		{"알 수 없는 방식", metadata.AppendToOutgoingContext(context.Background(), AuthorizationMetadata, "Basic dXNlcjpwYXNz")},
+		{"잘못된 토큰", metadata.AppendToOutgoingContext(context.Background(), AuthorizationMetadata, "Bearer nope")},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행
+		_, err := client.GetProduct(tt.ctx, &productv1.GetProductRequest{Id: uuid.NewString()})
+
+		// 검증 - DB를 조회하지 않습니다.
+		assert.Equal(t, codes.Unauthenticated, status.Code(err), tt.name)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthenticator_TenantFromToken(t *testing.T) {
+	// 테스트 설정
+	client, keys, mock := setupGRPCServer(t, nil)
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(revokedTokenQuery)).
+		WithArgs("token-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(revokedTokenQuery)).
+		WithArgs("token-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// 테스트 실행 - 다른 테넌트를 메타데이터로 고를 수 없고, 테넌트가 없는 토큰은 거절합니다.
+	mismatched := metadata.AppendToOutgoingContext(withToken(context.Background(), signToken(t, keys, "tenant-a", "admin")), TenantMetadata, "tenant-b")
+	_, mismatchErr := client.GetProduct(mismatched, &productv1.GetProductRequest{Id: uuid.NewString()})
+	_, missingErr := client.GetProduct(withToken(context.Background(), signToken(t, keys, "", "admin")), &productv1.GetProductRequest{Id: uuid.NewString()})
+
+	// 검증
+	assert.Equal(t, codes.PermissionDenied, status.Code(mismatchErr))
+	assert.Equal(t, codes.PermissionDenied, status.Code(missingErr))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthenticator_PermissionDenied(t *testing.T) {
+	// 테스트 설정 - viewer는 product:read만 가집니다.
+	client, keys, mock := setupGRPCServer(t, nil)
+	ctx := withToken(context.Background(), signToken(t, keys, "tenant-a", "viewer"))
+
+	// SQL 쿼리 모의 설정
+	expectAuthenticated(mock)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "audit_logs" ("id","tenant_id","actor_type","actor_id","action","permission","method","path","request_id","create_at")`)).
+		WithArgs(sqlmock.AnyArg(), "tenant-a", "user", "user-1", "access.denied", "product:write", "POST", productv1.ProductService_CreateProduct_FullMethodName, "", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	_, err := client.CreateProduct(ctx, &productv1.CreateProductRequest{Product: &productv1.ProductInput{Name: "사과", Price: 1000}})
+
+	// 검증 - 상품은 만들지 않고 거절을 감사 기록에 남깁니다.
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,212 @@
+package grpcHandler
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/events"
+	productv1 "Go-Gin-Basic-Template/proto/product/v1"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/types/responseTypes"
+	"context"
+	"encoding/base64"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+	pageTokenPrefix = "offset:"
+)
+
+// ProductPermissions는 ProductService의 메서드마다 필요한 권한입니다. REST의 같은 동작과 맞춥니다.
+var ProductPermissions = map[string]string{
+	productv1.ProductService_CreateProduct_FullMethodName: auth.PermissionProductWrite,
+	productv1.ProductService_UpdateProduct_FullMethodName: auth.PermissionProductWrite,
+	productv1.ProductService_DeleteProduct_FullMethodName: auth.PermissionProductDelete,
+	productv1.ProductService_GetProduct_FullMethodName:    auth.PermissionProductRead,
+	productv1.ProductService_ListProducts_FullMethodName:  auth.PermissionProductRead,
+	productv1.ProductService_WatchProducts_FullMethodName: auth.PermissionProductRead,
+}
+
+// ProductService는 Authenticator가 주체와 테넌트를 컨텍스트에 넣은 호출만 받습니다.
+type ProductService struct {
+	productv1.UnimplementedProductServiceServer
+	ProductController *controller.ProductController
+}
+
+func (s *ProductService) CreateProduct(ctx context.Context, request *productv1.CreateProductRequest) (*productv1.Product, error) {
+	if request.Product == nil {
+		return nil, status.Error(codes.InvalidArgument, "product is required")
+	}
+
+	statusCode, product, err := s.ProductController.Insert(ctx, productRequestFromProto(request.Product))
+	if err != nil {
+		return nil, FromHTTPStatus(statusCode, err)
+	}
+	return productToProto(product), nil
+}
+
+func (s *ProductService) UpdateProduct(ctx context.Context, request *productv1.UpdateProductRequest) (*productv1.Product, error) {
+	if request.Product == nil {
+		return nil, status.Error(codes.InvalidArgument, "product is required")
+	}
+
+	statusCode, product, err := s.ProductController.Update(ctx, request.Id, productRequestFromProto(request.Product))
+	if err != nil {
+		return nil, FromHTTPStatus(statusCode, err)
+	}
+	return productToProto(product), nil
+}
+
+func (s *ProductService) DeleteProduct(ctx context.Context, request *productv1.DeleteProductRequest) (*productv1.DeleteProductResponse, error) {
+	statusCode, id, err := s.ProductController.Delete(ctx, request.Id)
+	if err != nil {
+		return nil, FromHTTPStatus(statusCode, err)
+	}
+	return &productv1.DeleteProductResponse{Id: id}, nil
+}
+
+func (s *ProductService) GetProduct(ctx context.Context, request *productv1.GetProductRequest) (*productv1.Product, error) {
+	statusCode, products, err := s.ProductController.GetByIDs(ctx, []string{request.Id})
+	if err != nil {
+		return nil, FromHTTPStatus(statusCode, err)
+	}
+	if len(products) == 0 {
+		return nil, status.Errorf(codes.NotFound, "product %s not found", request.Id)
+	}
+	return productToProto(&products[0]), nil
+}
+
+func (s *ProductService) ListProducts(ctx context.Context, request *productv1.ListProductsRequest) (*productv1.ListProductsResponse, error) {
+	pageSize := int(request.PageSize)
+	switch {
+	case pageSize < 0:
+		return nil, status.Error(codes.InvalidArgument, "page_size must not be negative")
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+	offset, err := decodePageToken(request.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &requestTypes.ProductFilter{
+		Name:     request.Name,
+		MinPrice: request.MinPrice,
+		MaxPrice: request.MaxPrice,
+	}
+	statusCode, products, total, err := s.ProductController.GetPage(ctx, filter, pageSize, offset)
+	if err != nil {
+		return nil, FromHTTPStatus(statusCode, err)
+	}
+
+	response := &productv1.ListProductsResponse{TotalSize: total}
+	for i := range products {
+		response.Products = append(response.Products, productToProto(&products[i]))
+	}
+	if next := offset + len(products); int64(next) < total {
+		response.NextPageToken = encodePageToken(next)
+	}
+	return response, nil
+}
+
+// WatchProducts는 클라이언트가 끊거나 구독이 밀려서 끊길 때까지 이벤트를 보냅니다.
+// 밀려서 끊긴 경우 Unavailable을 보내므로, 클라이언트는 마지막 id로 다시 호출하면 됩니다.
+func (s *ProductService) WatchProducts(request *productv1.WatchProductsRequest, stream productv1.ProductService_WatchProductsServer) error {
+	ctx := stream.Context()
+	statusCode, replay, ch, cancel, err := s.ProductController.Subscribe(ctx, request.LastEventId, events.Filter{
+		ProductIDs: request.ProductIds,
+		Categories: request.Categories,
+	})
+	if err != nil {
+		return FromHTTPStatus(statusCode, err)
+	}
+	defer cancel()
+
+	for i := range replay {
+		if err = stream.Send(eventToProto(&replay[i])); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case event, ok := <-ch:
+			if !ok {
+				return status.Error(codes.Unavailable, "subscription dropped because the client fell behind")
+			}
+			if err = stream.Send(eventToProto(&event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func productRequestFromProto(input *productv1.ProductInput) *requestTypes.ProductRequest {
+	return &requestTypes.ProductRequest{
+		Name:          input.Name,
+		Price:         input.Price,
+		Category:      input.Category,
+		SupplierCost:  input.SupplierCost,
+		InternalNotes: input.InternalNotes,
+	}
+}
+
+func productToProto(product *types.Product) *productv1.Product {
+	return productResponseToProto(responseTypes.NewProduct(product))
+}
+
+// productResponseToProto는 REST 응답과 이벤트가 쓰는 responseTypes.Product를 메시지로 옮깁니다.
+func productResponseToProto(product responseTypes.Product) *productv1.Product {
+	message := &productv1.Product{
+		Id:         product.ID,
+		Name:       product.Name,
+		Price:      product.Price,
+		Category:   product.Category,
+		CreateTime: timestamppb.New(product.CreatedAt),
+	}
+	if !product.UpdatedAt.IsZero() {
+		message.UpdateTime = timestamppb.New(product.UpdatedAt)
+	}
+	return message
+}
+
+func eventToProto(event *events.Event) *productv1.ProductEvent {
+	message := &productv1.ProductEvent{
+		Id:        event.ID,
+		Type:      event.Type,
+		ProductId: event.ProductID,
+		Category:  event.Category,
+	}
+	if product, ok := event.Data.(responseTypes.Product); ok {
+		message.Product = productResponseToProto(product)
+	}
+	return message
+}
+
+func encodePageToken(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(pageTokenPrefix + strconv.Itoa(offset)))
+}
+
+func decodePageToken(token string) (int, error) {
+	if token == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || !strings.HasPrefix(string(raw), pageTokenPrefix) {
+		return 0, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(raw), pageTokenPrefix))
+	if err != nil || offset < 0 {
+		return 0, status.Error(codes.InvalidArgument, "invalid page_token")
+	}
+	return offset, nil
+}
@@ -0,0 +1,209 @@
+package grpcHandler
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/events"
+	productv1 "Go-Gin-Basic-Template/proto/product/v1"
+	"Go-Gin-Basic-Template/repository"
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	tenantQuery       = `SELECT "id" FROM "tenants" WHERE id = $1`
+	revokedTokenQuery = `SELECT "id" FROM "revoked_tokens" WHERE id = $1`
+)
+
+// 테스트 설정 함수
+func setupGRPCServer(t *testing.T, broker *events.Broker) (productv1.ProductServiceClient, *auth.KeySet, sqlmock.Sqlmock) {
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, PreferSimpleProtocol: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	keys, err := auth.NewKeySet("k1", map[string][]byte{"k1": bytes.Repeat([]byte("k"), 32)})
+	require.NoError(t, err)
+	policies, err := auth.NewPolicyStore("")
+	require.NoError(t, err)
+
+	server := NewServer(&Authenticator{
+		AuthController:     controller.NewAuthController(&repository.UserRepository{DB: db}, &repository.RevokedTokenRepository{DB: db}, keys),
+		APIKeyController:   &controller.APIKeyController{APIKeyRepository: &repository.APIKeyRepository{DB: db}},
+		Policies:           policies,
+		TenantRepository:   &repository.TenantRepository{DB: db},
+		AuditLogRepository: &repository.AuditLogRepository{DB: db},
+		Permissions:        ProductPermissions,
+	})
+	productv1.RegisterProductServiceServer(server, &ProductService{
+		ProductController: &controller.ProductController{ProductRepository: &repository.ProductRepository{DB: db}, Events: broker},
+	})
+
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return productv1.NewProductServiceClient(conn), keys, mock
+}
+
+// signToken은 tenantID 테넌트의 roles 역할을 가진 액세스 토큰을 만듭니다.
+func signToken(t *testing.T, keys *auth.KeySet, tenantID string, roles ...string) string {
+	now := time.Now()
+	token, err := keys.Sign(&auth.Claims{
+		Subject:   "user-1",
+		ID:        "token-1",
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Minute).Unix(),
+		TokenType: auth.TokenTypeAccess,
+		TenantID:  tenantID,
+		Roles:     roles,
+	})
+	require.NoError(t, err)
+	return token
+}
+
+// withToken은 호출 메타데이터에 Bearer 토큰을 넣습니다.
+func withToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, AuthorizationMetadata, "Bearer "+token)
+}
+
+// expectAuthenticated는 토큰 폐기 여부와 테넌트 조회를 모의 설정합니다.
+func expectAuthenticated(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(regexp.QuoteMeta(revokedTokenQuery)).
+		WithArgs("token-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(tenantQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("tenant-a"))
+}
+
+func TestProductService_GetProduct(t *testing.T) {
+	// 테스트 설정
+	client, keys, mock := setupGRPCServer(t, nil)
+	id := uuid.New()
+	now := time.Now()
+	ctx := withToken(context.Background(), signToken(t, keys, "tenant-a", "viewer"))
+
+	// SQL 쿼리 모의 설정
+	expectAuthenticated(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id IN ($1) AND "products"."delete_at" IS NULL`)).
+		WithArgs(id.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "create_at", "name", "price", "category"}).
+			AddRow(id, now, "사과", 1000.0, "식품"))
+
+	// 테스트 실행
+	product, err := client.GetProduct(ctx, &productv1.GetProductRequest{Id: id.String()})
+
+	// 검증
+	require.NoError(t, err)
+	assert.Equal(t, id.String(), product.Id)
+	assert.Equal(t, "사과", product.Name)
+	assert.Equal(t, 1000.0, product.Price)
+	assert.Equal(t, now.Unix(), product.CreateTime.AsTime().Unix())
+	assert.Nil(t, product.UpdateTime)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductService_GetProduct_NotFound(t *testing.T) {
+	// 테스트 설정
+	client, keys, mock := setupGRPCServer(t, nil)
+	ctx := withToken(context.Background(), signToken(t, keys, "tenant-a", "viewer"))
+
+	// SQL 쿼리 모의 설정
+	expectAuthenticated(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id IN ($1)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// 테스트 실행
+	_, err := client.GetProduct(ctx, &productv1.GetProductRequest{Id: uuid.NewString()})
+
+	// 검증
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductService_ListProducts_InvalidPageSize(t *testing.T) {
+	// 테스트 설정
+	client, keys, mock := setupGRPCServer(t, nil)
+	ctx := withToken(context.Background(), signToken(t, keys, "tenant-a", "viewer"))
+
+	// SQL 쿼리 모의 설정
+	expectAuthenticated(mock)
+
+	// 테스트 실행
+	_, err := client.ListProducts(ctx, &productv1.ListProductsRequest{PageSize: -1})
+
+	// 검증
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductService_WatchProducts(t *testing.T) {
+	// 테스트 설정
+	broker := events.NewBroker(10)
+	broker.Publish(events.Event{Type: events.ProductCreated, TenantID: "tenant-a", ProductID: "p1", Category: "식품"})
+	broker.Publish(events.Event{Type: events.ProductCreated, TenantID: "tenant-a", ProductID: "p2", Category: "생활"})
+	broker.Publish(events.Event{Type: events.ProductUpdated, TenantID: "tenant-a", ProductID: "p1", Category: "식품"})
+	client, keys, mock := setupGRPCServer(t, broker)
+
+	// SQL 쿼리 모의 설정
+	expectAuthenticated(mock)
+
+	ctx, cancel := context.WithCancel(withToken(context.Background(), signToken(t, keys, "tenant-a", "viewer")))
+	defer cancel()
+
+	// 테스트 실행
+	stream, err := client.WatchProducts(ctx, &productv1.WatchProductsRequest{
+		Categories:  []string{"식품"},
+		LastEventId: 1,
+	})
+	require.NoError(t, err)
+
+	// 검증 - 1번 이후의 식품 이벤트가 재전송되고, 이후 이벤트도 같은 필터로 전달됩니다.
+	replayed, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), replayed.Id)
+	assert.Equal(t, events.ProductUpdated, replayed.Type)
+
+	broker.Publish(events.Event{Type: events.ProductDeleted, TenantID: "tenant-a", ProductID: "p2", Category: "생활"})
+	broker.Publish(events.Event{Type: events.ProductDeleted, TenantID: "tenant-a", ProductID: "p1", Category: "식품"})
+	live, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), live.Id)
+	assert.Equal(t, "p1", live.ProductId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFromHTTPStatus(t *testing.T) {
+	// 검증
+	assert.Equal(t, codes.NotFound, status.Code(FromHTTPStatus(http.StatusNotFound, io.EOF)))
+	assert.Equal(t, codes.InvalidArgument, status.Code(FromHTTPStatus(http.StatusBadRequest, io.EOF)))
+	assert.Equal(t, codes.Unavailable, status.Code(FromHTTPStatus(http.StatusServiceUnavailable, io.EOF)))
+	assert.Equal(t, codes.Internal, status.Code(FromHTTPStatus(http.StatusInternalServerError, io.EOF)))
+}
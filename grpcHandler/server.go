@@ -0,0 +1,47 @@
+package grpcHandler
+
+import (
+	"Go-Gin-Basic-Template/logging"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"runtime/debug"
+)
+
+const defaultAddr = ":9090"
+
+// Addr는 gRPC 서버 주소입니다. PORT와 같은 형식(":9090")으로 GRPC_PORT에 지정합니다.
+func Addr() string {
+	if addr := os.Getenv("GRPC_PORT"); addr != "" {
+		return addr
+	}
+	return defaultAddr
+}
+
+// NewServer는 모든 호출을 authenticator로 확인하는 grpc.Server를 만듭니다. 서비스는 돌려받은 서버에 등록합니다.
+func NewServer(authenticator *Authenticator) *grpc.Server {
+	return grpc.NewServer(
+		grpc.ChainUnaryInterceptor(recoverUnary, authenticator.Unary),
+		grpc.ChainStreamInterceptor(recoverStream, authenticator.Stream),
+	)
+}
+
+// recoverUnary와 recoverStream은 핸들러의 panic을 Internal로 바꿉니다. 서버 프로세스가 죽지 않게 합니다.
+func recoverUnary(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (response interface{}, err error) {
+	defer recoverPanic(ctx, info.FullMethod, &err)
+	return handler(ctx, request)
+}
+
+func recoverStream(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverPanic(stream.Context(), info.FullMethod, &err)
+	return handler(server, stream)
+}
+
+func recoverPanic(ctx context.Context, fullMethod string, err *error) {
+	if p := recover(); p != nil {
+		logging.FromContext(ctx).Error("grpc panic recovered", "method", fullMethod, "panic", p, "stack", string(debug.Stack()))
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
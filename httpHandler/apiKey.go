@@ -0,0 +1,70 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/types/responseTypes"
+	"Go-Gin-Basic-Template/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type APIKeyHandler struct {
+	APIKeyController *controller.APIKeyController
+}
+
+func (h *APIKeyHandler) Insert(c *gin.Context) {
+	var apiKey requestTypes.APIKeyRequest
+	if statusCode, err := utils.Bind(c, &apiKey); err != nil {
+		utils.RespondWithError(c, statusCode, i18n.InvalidRequestPayload, err)
+		return
+	}
+
+	statusCode, created, key, err := h.APIKeyController.Insert(c.Request.Context(), &apiKey)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SaveFailed, err)
+		return
+	}
+
+	// 원문 키는 이 응답에서 한 번만 보여주므로 멱등성 기록이나 캐시에 남기지 않습니다.
+	utils.NoStore(c)
+	utils.RespondData(c, statusCode, responseTypes.APIKeyCreated{APIKey: *created, Key: key})
+}
+
+func (h *APIKeyHandler) GetAll(c *gin.Context) {
+	var filter requestTypes.APIKeyFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidQueryParameter, err)
+		return
+	}
+
+	statusCode, apiKeys, err := h.APIKeyController.GetAll(c.Request.Context(), &filter)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
+		return
+	}
+
+	utils.RespondList(c, statusCode, *apiKeys, nil)
+}
+
+func (h *APIKeyHandler) Rotate(c *gin.Context) {
+	statusCode, rotated, key, err := h.APIKeyController.Rotate(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SaveFailed, err)
+		return
+	}
+
+	utils.NoStore(c)
+	utils.RespondData(c, statusCode, responseTypes.APIKeyCreated{APIKey: *rotated, Key: key})
+}
+
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	statusCode, err := h.APIKeyController.Revoke(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SaveFailed, err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, statusCode, i18n.Success)
+}
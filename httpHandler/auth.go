@@ -0,0 +1,61 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	AuthController *controller.AuthController
+}
+
+func (h *AuthHandler) Login(c *gin.Context) {
+	var login requestTypes.LoginRequest
+	if statusCode, err := utils.Bind(c, &login); err != nil {
+		utils.RespondWithError(c, statusCode, i18n.InvalidRequestPayload, err)
+		return
+	}
+
+	statusCode, token, err := h.AuthController.Login(c.Request.Context(), &login)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.AuthenticationFailed, err)
+		return
+	}
+
+	utils.RespondData(c, statusCode, *token)
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var refresh requestTypes.RefreshRequest
+	if statusCode, err := utils.Bind(c, &refresh); err != nil {
+		utils.RespondWithError(c, statusCode, i18n.InvalidRequestPayload, err)
+		return
+	}
+
+	statusCode, token, err := h.AuthController.Refresh(c.Request.Context(), &refresh)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.AuthenticationFailed, err)
+		return
+	}
+
+	utils.RespondData(c, statusCode, *token)
+}
+
+func (h *AuthHandler) Revoke(c *gin.Context) {
+	var revoke requestTypes.RevokeRequest
+	if statusCode, err := utils.Bind(c, &revoke); err != nil {
+		utils.RespondWithError(c, statusCode, i18n.InvalidRequestPayload, err)
+		return
+	}
+
+	statusCode, err := h.AuthController.Revoke(c.Request.Context(), &revoke)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.AuthenticationFailed, err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, statusCode, i18n.Success)
+}
@@ -0,0 +1,55 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/openapi"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+	"net/http"
+)
+
+// DocsHandler의 Document는 라우트를 모두 등록한 뒤에 채웁니다. (Router.SetupRoutes)
+type DocsHandler struct {
+	Document *openapi.Document
+}
+
+func (h *DocsHandler) OpenAPI(c *gin.Context) {
+	c.JSON(http.StatusOK, h.Document)
+}
+
+// SwaggerUI는 바이너리에 포함된 swagger-ui 파일로 /openapi.json을 보여줍니다. 외부 CDN에 접속하지 않습니다.
+func (h *DocsHandler) SwaggerUI(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUIPage))
+}
+
+// SwaggerUIAssets는 /docs/ 자체는 SwaggerUI 페이지로 보냅니다. 배포판의 index.html은 예제 문서를 열기 때문입니다.
+func (h *DocsHandler) SwaggerUIAssets(c *gin.Context) {
+	filepath := c.Param("filepath")
+	if filepath == "/" || filepath == "/index.html" {
+		h.SwaggerUI(c)
+		return
+	}
+	c.FileFromFS(filepath, http.FS(swaggerFiles.FS))
+}
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="ko">
+<head>
+  <meta charset="UTF-8">
+  <title>Go-Gin-Basic-Template API</title>
+  <link rel="stylesheet" href="/docs/swagger-ui.css">
+  <link rel="icon" type="image/png" href="/docs/favicon-32x32.png" sizes="32x32">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="/docs/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({
+      url: '/openapi.json',
+      dom_id: '#swagger-ui',
+      presets: [SwaggerUIBundle.presets.apis],
+      layout: 'BaseLayout',
+    });
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,224 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/controller"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+	"io"
+	"mime"
+	"net/http"
+)
+
+const (
+	// graphqlMaxBodySize보다 큰 요청 본문은 읽지 않습니다.
+	graphqlMaxBodySize = 1 << 20
+	// graphqlMaxComplexity는 한 요청이 읽을 수 있는 필드 수의 상한입니다. products 아래의 필드는 first만큼 곱해서 셉니다.
+	graphqlMaxComplexity = 5000
+)
+
+// GraphQLRequest는 POST 본문(application/json)과 GET 쿼리 문자열의 요청입니다.
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type GraphQLHandler struct {
+	ProductController *controller.ProductController
+	Schema            *graphql.Schema
+	// Authorize는 operation마다 필요한 권한을 확인합니다. 거절하면 응답을 쓰고 false를 돌려줍니다. nil이면 확인하지 않습니다.
+	Authorize func(c *gin.Context, permission string) bool
+}
+
+// NewGraphQLHandler는 스키마 정의가 잘못되었으면 panic합니다. 시작할 때 바로 드러나야 하는 프로그래밍 오류입니다.
+func NewGraphQLHandler(productController *controller.ProductController) *GraphQLHandler {
+	schema, err := newProductSchema(productController)
+	if err != nil {
+		panic(err)
+	}
+
+	return &GraphQLHandler{ProductController: productController, Schema: schema}
+}
+
+// Query는 GET과 POST를 모두 받습니다. GET은 query operation만 실행합니다.
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var request GraphQLRequest
+	var err error
+	queryOnly := c.Request.Method == http.MethodGet
+	if queryOnly {
+		request, err = graphqlRequestFromQuery(c)
+	} else {
+		request, err = graphqlRequestFromBody(c)
+	}
+	if err != nil {
+		respondWithGraphQLError(c, err)
+		return
+	}
+
+	// 권한과 비용은 실행 전에 정해야 하므로 operation을 먼저 읽습니다. 문법 검증과 실행은 스키마가 합니다.
+	doc, op, err := selectOperation(request)
+	if err != nil {
+		respondWithGraphQLError(c, err)
+		return
+	}
+	if queryOnly && op.Operation != ast.Query {
+		respondWithGraphQLError(c, fmt.Errorf("Can only perform a %s operation from a POST request.", op.Operation))
+		return
+	}
+
+	// query는 product:read, mutation은 product:write가 필요합니다.
+	if h.Authorize != nil {
+		permission := auth.PermissionProductRead
+		if op.Operation == ast.Mutation {
+			permission = auth.PermissionProductWrite
+		}
+		if !h.Authorize(c, permission) {
+			return
+		}
+	}
+
+	if complexity := selectionComplexity(op.SelectionSet, doc.Fragments, request.Variables, map[string]bool{}); complexity > graphqlMaxComplexity {
+		respondWithGraphQLError(c, fmt.Errorf("query complexity %d exceeds the limit of %d", complexity, graphqlMaxComplexity))
+		return
+	}
+
+	ctx := withProductLoaders(c.Request.Context(), h.ProductController)
+	response := h.Schema.Exec(ctx, request.Query, request.OperationName, request.Variables)
+
+	// 실행 전에 실패한 요청(문법, 검증, 변수 오류)은 data가 없고 400으로 응답합니다.
+	statusCode := http.StatusOK
+	if response.Data == nil {
+		statusCode = http.StatusBadRequest
+	}
+	c.JSON(statusCode, response)
+}
+
+// GraphiQL은 테넌트 헤더 없이 열 수 있어야 하므로 /graphql 그룹 밖에 등록합니다. 헤더는 GraphiQL의 Headers 탭에서 넣습니다.
+func (h *GraphQLHandler) GraphiQL(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(graphiqlPage))
+}
+
+func graphqlRequestFromQuery(c *gin.Context) (request GraphQLRequest, err error) {
+	request.Query = c.Query("query")
+	request.OperationName = c.Query("operationName")
+	if variables := c.Query("variables"); variables != "" {
+		if err = json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+			return request, errors.New("variables는 JSON 객체여야 합니다")
+		}
+	}
+	return request, nil
+}
+
+// graphqlRequestFromBody는 application/json과 application/graphql(본문 전체가 쿼리)을 받습니다.
+func graphqlRequestFromBody(c *gin.Context) (request GraphQLRequest, err error) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, graphqlMaxBodySize))
+	if err != nil {
+		return request, err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if mediaType == "application/graphql" {
+		request.Query = string(body)
+		return request, nil
+	}
+
+	if err = json.Unmarshal(body, &request); err != nil {
+		return request, errors.New("요청 본문은 {\"query\": ...} 형식의 JSON이어야 합니다")
+	}
+	return request, nil
+}
+
+// selectOperation은 스키마가 실행할 것과 같은 operation을 고릅니다. 이름이 없으면 operation이 하나뿐이어야 합니다.
+func selectOperation(request GraphQLRequest) (*ast.QueryDocument, *ast.OperationDefinition, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: request.Query})
+	if err != nil {
+		return nil, nil, err
+	}
+	if request.OperationName == "" {
+		if len(doc.Operations) != 1 {
+			return nil, nil, errors.New("operationName is required when the document has more than one operation")
+		}
+		return doc, doc.Operations[0], nil
+	}
+	if op := doc.Operations.ForName(request.OperationName); op != nil {
+		return doc, op, nil
+	}
+	return nil, nil, fmt.Errorf("no operation with name %q", request.OperationName)
+}
+
+// selectionComplexity는 필드마다 1을 더하고, 목록을 돌려주는 products 아래는 first만큼 곱합니다.
+// 별칭이나 fragment를 반복해도 그만큼 다시 셉니다. 순환하는 fragment는 스키마 검증에서 거절하므로 한 번만 셉니다.
+func selectionComplexity(selections ast.SelectionSet, fragments ast.FragmentDefinitionList, variables map[string]interface{}, visiting map[string]bool) int {
+	total := 0
+	for _, selection := range selections {
+		switch selection := selection.(type) {
+		case *ast.Field:
+			total += 1 + fieldMultiplier(selection, variables)*selectionComplexity(selection.SelectionSet, fragments, variables, visiting)
+		case *ast.InlineFragment:
+			total += selectionComplexity(selection.SelectionSet, fragments, variables, visiting)
+		case *ast.FragmentSpread:
+			fragment := fragments.ForName(selection.Name)
+			if fragment == nil || visiting[selection.Name] {
+				continue
+			}
+			visiting[selection.Name] = true
+			total += selectionComplexity(fragment.SelectionSet, fragments, variables, visiting)
+			delete(visiting, selection.Name)
+		}
+	}
+	return total
+}
+
+func fieldMultiplier(field *ast.Field, variables map[string]interface{}) int {
+	if field.Name != "products" {
+		return 1
+	}
+	first := graphqlDefaultPageSize
+	if argument := field.Arguments.ForName("first"); argument != nil {
+		value, _ := argument.Value.Value(variables)
+		switch value := value.(type) {
+		case int64:
+			first = int(value)
+		case float64:
+			first = int(value)
+		}
+	}
+	// 범위를 벗어난 first는 resolver가 거절합니다.
+	return min(max(first, 1), graphqlMaxPageSize)
+}
+
+func respondWithGraphQLError(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, &graphql.Response{Errors: []*gqlerrors.QueryError{{Message: err.Error()}}})
+}
+
+const graphiqlPage = `<!DOCTYPE html>
+<html lang="ko">
+<head>
+  <meta charset="utf-8">
+  <title>GraphiQL</title>
+  <style>body { margin: 0; height: 100vh; } #graphiql { height: 100vh; }</style>
+  <link rel="stylesheet" href="https://unpkg.com/graphiql@3/graphiql.min.css">
+</head>
+<body>
+  <div id="graphiql">Loading...</div>
+  <script crossorigin src="https://unpkg.com/react@18/umd/react.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/react-dom@18/umd/react-dom.production.min.js"></script>
+  <script crossorigin src="https://unpkg.com/graphiql@3/graphiql.min.js"></script>
+  <script>
+    const fetcher = GraphiQL.createFetcher({ url: '/graphql' });
+    ReactDOM.createRoot(document.getElementById('graphiql')).render(React.createElement(GraphiQL, {
+      fetcher,
+      defaultHeaders: JSON.stringify({ 'X-Tenant-ID': '' }, null, 2),
+      shouldPersistHeaders: true,
+    }));
+  </script>
+</body>
+</html>
+`
@@ -0,0 +1,455 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/graph-gophers/dataloader"
+	"github.com/graph-gophers/graphql-go"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	graphqlDefaultPageSize = 20
+	graphqlMaxPageSize     = 100
+	graphqlCursorPrefix    = "offset:"
+	// graphqlMaxDepth는 중첩할 수 있는 필드 깊이입니다. 상품 쿼리는 5(products > edges > node > revisions > price)를 넘지 않지만,
+	// GraphiQL의 스키마 조회(introspection)가 ofType을 깊게 중첩하므로 여유를 둡니다.
+	graphqlMaxDepth = 15
+)
+
+//go:embed product.graphql
+var productSchema string
+
+// newProductSchema는 스키마 정의와 resolver가 맞지 않으면 오류를 돌려줍니다.
+func newProductSchema(productController *controller.ProductController) (*graphql.Schema, error) {
+	return graphql.ParseSchema(productSchema, &rootResolver{productController: productController},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(graphqlMaxDepth),
+	)
+}
+
+// graphqlError는 컨트롤러의 상태 코드를 extensions.code로 옮깁니다.
+type graphqlError struct {
+	err    error
+	status int
+}
+
+func (e *graphqlError) Error() string {
+	return e.err.Error()
+}
+
+func (e *graphqlError) Unwrap() error {
+	return e.err
+}
+
+func (e *graphqlError) Extensions() map[string]interface{} {
+	code := "INTERNAL_SERVER_ERROR"
+	switch e.status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		code = "BAD_USER_INPUT"
+	case http.StatusNotFound:
+		code = "NOT_FOUND"
+	case http.StatusServiceUnavailable:
+		code = "SERVICE_UNAVAILABLE"
+	}
+	return map[string]interface{}{"code": code}
+}
+
+func newGraphQLError(status int, err error) error {
+	return &graphqlError{err: err, status: status}
+}
+
+// productLoaders는 요청마다 새로 만들어 컨텍스트에 넣습니다. 캐시가 요청 사이에 공유되지 않습니다.
+// resolver가 필드마다 동시에 실행되므로 잠깐 기다리는 동안 모인 키를 한 번에 읽습니다.
+type productLoaders struct {
+	products  *dataloader.Loader
+	revisions *dataloader.Loader
+}
+
+type productLoadersKey struct{}
+
+func withProductLoaders(ctx context.Context, productController *controller.ProductController) context.Context {
+	loaders := &productLoaders{
+		products: dataloader.NewBatchedLoader(func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+			// 같은 요청이면 같은 쿼리가 나가도록 ID를 정렬합니다.
+			ids := keys.Keys()
+			sort.Strings(ids)
+			statusCode, products, err := productController.GetByIDs(ctx, ids)
+			if err != nil {
+				return failedResults(len(keys), newGraphQLError(statusCode, err))
+			}
+			byID := make(map[string]*types.Product, len(products))
+			for i := range products {
+				byID[products[i].ID.String()] = &products[i]
+			}
+			results := make([]*dataloader.Result, len(keys))
+			for i, key := range keys {
+				// 없는 상품은 nil이고 product(id:)는 null이 됩니다.
+				results[i] = &dataloader.Result{Data: byID[key.String()]}
+			}
+			return results
+		}),
+		revisions: dataloader.NewBatchedLoader(func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+			ids := make([]uuid.UUID, len(keys))
+			for i, key := range keys {
+				ids[i] = key.Raw().(uuid.UUID)
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+			statusCode, revisions, err := productController.GetRevisionsByProductIDs(ctx, ids)
+			if err != nil {
+				return failedResults(len(keys), newGraphQLError(statusCode, err))
+			}
+			byProduct := make(map[uuid.UUID][]types.ProductRevision, len(keys))
+			for _, revision := range revisions {
+				byProduct[revision.ProductID] = append(byProduct[revision.ProductID], revision)
+			}
+			results := make([]*dataloader.Result, len(keys))
+			for i, key := range keys {
+				results[i] = &dataloader.Result{Data: byProduct[key.Raw().(uuid.UUID)]}
+			}
+			return results
+		}),
+	}
+	return context.WithValue(ctx, productLoadersKey{}, loaders)
+}
+
+func loadersFrom(ctx context.Context) *productLoaders {
+	return ctx.Value(productLoadersKey{}).(*productLoaders)
+}
+
+func failedResults(n int, err error) []*dataloader.Result {
+	results := make([]*dataloader.Result, n)
+	for i := range results {
+		results[i] = &dataloader.Result{Error: err}
+	}
+	return results
+}
+
+// uuidKey는 리비전 로더의 키입니다. Raw로 uuid.UUID를 그대로 돌려줍니다.
+type uuidKey uuid.UUID
+
+func (k uuidKey) String() string   { return uuid.UUID(k).String() }
+func (k uuidKey) Raw() interface{} { return uuid.UUID(k) }
+
+// dateTime은 DateTime 스칼라입니다. RFC 3339 문자열로 주고받습니다.
+type dateTime struct {
+	time.Time
+}
+
+func (dateTime) ImplementsGraphQLType(name string) bool {
+	return name == "DateTime"
+}
+
+func (t *dateTime) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("DateTime cannot represent value: %v", input)
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+func (t dateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Format(time.RFC3339Nano))
+}
+
+// optionalTime은 값이 없는 시각(0001-01-01)을 null로 보냅니다.
+func optionalTime(t time.Time) *dateTime {
+	if t.IsZero() {
+		return nil
+	}
+	return &dateTime{t}
+}
+
+func encodeCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(graphqlCursorPrefix + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), graphqlCursorPrefix) {
+		return 0, errors.New("잘못된 커서")
+	}
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(raw), graphqlCursorPrefix))
+	if err != nil || offset < 0 {
+		return 0, errors.New("잘못된 커서")
+	}
+	return offset, nil
+}
+
+type productInput struct {
+	Name          string
+	Price         float64
+	Category      string
+	Sku           *string
+	SupplierCost  *float64
+	InternalNotes *string
+}
+
+func (input *productInput) request() *requestTypes.ProductRequest {
+	request := &requestTypes.ProductRequest{
+		Name:         input.Name,
+		Price:        input.Price,
+		Category:     input.Category,
+		SupplierCost: input.SupplierCost,
+	}
+	if input.Sku != nil {
+		request.SKU = *input.Sku
+	}
+	if input.InternalNotes != nil {
+		request.InternalNotes = *input.InternalNotes
+	}
+	return request
+}
+
+type productFilterInput struct {
+	Name     *string
+	MinPrice *float64
+	MaxPrice *float64
+}
+
+func (input *productFilterInput) filter() *requestTypes.ProductFilter {
+	if input == nil {
+		return nil
+	}
+	filter := &requestTypes.ProductFilter{MinPrice: input.MinPrice, MaxPrice: input.MaxPrice}
+	if input.Name != nil {
+		filter.Name = *input.Name
+	}
+	return filter
+}
+
+// rootResolver는 Query와 Mutation 필드를 컨트롤러로 넘깁니다.
+type rootResolver struct {
+	productController *controller.ProductController
+}
+
+func (r *rootResolver) Product(ctx context.Context, args struct{ ID graphql.ID }) (*productResolver, error) {
+	value, err := loadersFrom(ctx).products.Load(ctx, dataloader.StringKey(args.ID))()
+	if err != nil {
+		return nil, err
+	}
+	product, _ := value.(*types.Product)
+	if product == nil {
+		return nil, nil
+	}
+	return &productResolver{product: product}, nil
+}
+
+func (r *rootResolver) Products(ctx context.Context, args struct {
+	Filter *productFilterInput
+	First  int32
+	After  *string
+}) (*productConnectionResolver, error) {
+	if args.First < 0 || args.First > graphqlMaxPageSize {
+		return nil, newGraphQLError(http.StatusBadRequest, fmt.Errorf("first는 0 이상 %d 이하여야 합니다", graphqlMaxPageSize))
+	}
+	offset := 0
+	if args.After != nil {
+		var err error
+		if offset, err = decodeCursor(*args.After); err != nil {
+			return nil, newGraphQLError(http.StatusBadRequest, err)
+		}
+	}
+
+	statusCode, products, total, err := r.productController.GetPage(ctx, args.Filter.filter(), int(args.First), offset)
+	if err != nil {
+		return nil, newGraphQLError(statusCode, err)
+	}
+	// 목록에서 읽은 상품은 같은 요청의 product(id:) 조회에서 다시 읽지 않습니다.
+	loaders := loadersFrom(ctx)
+	for i := range products {
+		loaders.products.Prime(ctx, dataloader.StringKey(products[i].ID.String()), &products[i])
+	}
+	return &productConnectionResolver{products: products, offset: offset, total: total}, nil
+}
+
+func (r *rootResolver) CreateProduct(ctx context.Context, args struct{ Input productInput }) (*productResolver, error) {
+	statusCode, product, err := r.productController.Insert(ctx, args.Input.request())
+	if err != nil {
+		return nil, newGraphQLError(statusCode, err)
+	}
+	return &productResolver{product: product}, nil
+}
+
+func (r *rootResolver) UpdateProduct(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input productInput
+}) (*productResolver, error) {
+	statusCode, product, err := r.productController.Update(ctx, string(args.ID), args.Input.request())
+	if err != nil {
+		return nil, newGraphQLError(statusCode, err)
+	}
+	return &productResolver{product: product}, nil
+}
+
+func (r *rootResolver) DeleteProduct(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	statusCode, id, err := r.productController.Delete(ctx, string(args.ID))
+	if err != nil {
+		return "", newGraphQLError(statusCode, err)
+	}
+	return graphql.ID(id), nil
+}
+
+type productResolver struct {
+	product *types.Product
+}
+
+func (r *productResolver) ID() graphql.ID {
+	return graphql.ID(r.product.ID.String())
+}
+
+func (r *productResolver) Name() string {
+	return r.product.Name
+}
+
+func (r *productResolver) Price() float64 {
+	return r.product.Price
+}
+
+func (r *productResolver) Category() string {
+	return r.product.Category
+}
+
+func (r *productResolver) Sku() *string {
+	if r.product.SKU == "" {
+		return nil
+	}
+	return &r.product.SKU
+}
+
+func (r *productResolver) CreatedAt() dateTime {
+	return dateTime{r.product.CreateAt}
+}
+
+func (r *productResolver) UpdatedAt() *dateTime {
+	return optionalTime(r.product.UpdateAt)
+}
+
+func (r *productResolver) Revisions(ctx context.Context) ([]*revisionResolver, error) {
+	value, err := loadersFrom(ctx).revisions.Load(ctx, uuidKey(r.product.ID))()
+	if err != nil {
+		return nil, err
+	}
+	revisions, _ := value.([]types.ProductRevision)
+	result := make([]*revisionResolver, len(revisions))
+	for i := range revisions {
+		result[i] = &revisionResolver{revision: &revisions[i]}
+	}
+	return result, nil
+}
+
+type revisionResolver struct {
+	revision *types.ProductRevision
+}
+
+func (r *revisionResolver) Revision() int32 {
+	return int32(r.revision.Revision)
+}
+
+func (r *revisionResolver) Name() string {
+	return r.revision.Snapshot.Name
+}
+
+func (r *revisionResolver) Price() float64 {
+	return r.revision.Snapshot.Price
+}
+
+func (r *revisionResolver) Category() string {
+	return r.revision.Snapshot.Category
+}
+
+func (r *revisionResolver) CreatedAt() dateTime {
+	return dateTime{r.revision.CreateAt}
+}
+
+type productConnectionResolver struct {
+	products []types.Product
+	offset   int
+	total    int64
+}
+
+func (r *productConnectionResolver) Edges() []*productEdgeResolver {
+	edges := make([]*productEdgeResolver, len(r.products))
+	for i := range r.products {
+		edges[i] = &productEdgeResolver{cursor: encodeCursor(r.offset + i + 1), node: &productResolver{product: &r.products[i]}}
+	}
+	return edges
+}
+
+func (r *productConnectionResolver) Nodes() []*productResolver {
+	nodes := make([]*productResolver, len(r.products))
+	for i := range r.products {
+		nodes[i] = &productResolver{product: &r.products[i]}
+	}
+	return nodes
+}
+
+func (r *productConnectionResolver) PageInfo() *pageInfoResolver {
+	end := r.offset + len(r.products)
+	pageInfo := &pageInfoResolver{
+		hasNextPage:     int64(end) < r.total,
+		hasPreviousPage: r.offset > 0,
+	}
+	if len(r.products) > 0 {
+		startCursor, endCursor := encodeCursor(r.offset+1), encodeCursor(end)
+		pageInfo.startCursor, pageInfo.endCursor = &startCursor, &endCursor
+	}
+	return pageInfo
+}
+
+func (r *productConnectionResolver) TotalCount() int32 {
+	return int32(r.total)
+}
+
+type productEdgeResolver struct {
+	cursor string
+	node   *productResolver
+}
+
+func (r *productEdgeResolver) Cursor() string {
+	return r.cursor
+}
+
+func (r *productEdgeResolver) Node() *productResolver {
+	return r.node
+}
+
+type pageInfoResolver struct {
+	hasNextPage     bool
+	hasPreviousPage bool
+	startCursor     *string
+	endCursor       *string
+}
+
+func (r *pageInfoResolver) HasNextPage() bool {
+	return r.hasNextPage
+}
+
+func (r *pageInfoResolver) HasPreviousPage() bool {
+	return r.hasPreviousPage
+}
+
+func (r *pageInfoResolver) StartCursor() *string {
+	return r.startCursor
+}
+
+func (r *pageInfoResolver) EndCursor() *string {
+	return r.endCursor
+}
@@ -0,0 +1,206 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/repository"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupGraphQLRouter(t *testing.T) (*gin.Engine, sqlmock.Sqlmock) {
+	gin.SetMode(gin.TestMode)
+
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, PreferSimpleProtocol: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	handler := NewGraphQLHandler(&controller.ProductController{ProductRepository: &repository.ProductRepository{DB: db}})
+	router := gin.New()
+	router.GET("/graphql", handler.Query)
+	router.POST("/graphql", handler.Query)
+	return router, mock
+}
+
+func postGraphQL(router *gin.Engine, body string) (*httptest.ResponseRecorder, map[string]interface{}) {
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var out map[string]interface{}
+	_ = json.Unmarshal(w.Body.Bytes(), &out)
+	return w, out
+}
+
+func TestGraphQLHandler_BatchesProductsAndRevisions(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupGraphQLRouter(t)
+	first, second := uuid.New(), uuid.New()
+	// 로더는 ID를 정렬해서 조회합니다.
+	if second.String() < first.String() {
+		first, second = second, first
+	}
+	now := time.Now()
+
+	// SQL 쿼리 모의 설정 - 별칭 두 개의 상품과 리비전을 각각 한 번의 쿼리로 읽습니다.
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id IN ($1,$2) AND "products"."delete_at" IS NULL`)).
+		WithArgs(first.String(), second.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "create_at", "name", "price", "category"}).
+			AddRow(first, now, "사과", 1000.0, "식품").
+			AddRow(second, now, "칫솔", 2000.0, "생활"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_revisions" WHERE product_id IN ($1,$2) ORDER BY product_id, revision`)).
+		WithArgs(first, second).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "product_id", "revision", "snapshot", "create_at"}).
+			AddRow(uuid.New(), first, 1, `{"name":"사과","price":900,"category":"식품"}`, now).
+			AddRow(uuid.New(), first, 2, `{"name":"사과","price":1000,"category":"식품"}`, now))
+
+	query := `{"query": "query ($a: ID!, $b: ID!) { a: product(id: $a) { name revisions { revision price } } b: product(id: $b) { name revisions { revision } } missing: product(id: \"not-a-uuid\") { name } }",
+		"variables": {"a": "` + first.String() + `", "b": "` + second.String() + `"}}`
+
+	// 테스트 실행
+	w, out := postGraphQL(router, query)
+
+	// 검증
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, out["errors"])
+	data := out["data"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"name": "사과",
+		"revisions": []interface{}{
+			map[string]interface{}{"revision": 1.0, "price": 900.0},
+			map[string]interface{}{"revision": 2.0, "price": 1000.0},
+		},
+	}, data["a"])
+	assert.Equal(t, map[string]interface{}{"name": "칫솔", "revisions": []interface{}{}}, data["b"])
+	assert.Nil(t, data["missing"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGraphQLHandler_ProductsPagination(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupGraphQLRouter(t)
+	id := uuid.New()
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products" WHERE price >= $1 AND "products"."delete_at" IS NULL`)).
+		WithArgs(500.0).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE price >= $1 AND "products"."delete_at" IS NULL ORDER BY create_at, id LIMIT $2 OFFSET $3`)).
+		WithArgs(500.0, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(id, "사과"))
+
+	body := `{"query": "{ products(first: 1, after: \"` + encodeCursor(1) + `\", filter: {minPrice: 500}) { totalCount nodes { id name } pageInfo { hasNextPage hasPreviousPage endCursor } } }"}`
+
+	// 테스트 실행
+	w, out := postGraphQL(router, body)
+
+	// 검증
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, out["errors"])
+	products := out["data"].(map[string]interface{})["products"].(map[string]interface{})
+	assert.Equal(t, 3.0, products["totalCount"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": id.String(), "name": "사과"}}, products["nodes"])
+	assert.Equal(t, map[string]interface{}{
+		"hasNextPage":     true,
+		"hasPreviousPage": true,
+		"endCursor":       encodeCursor(2),
+	}, products["pageInfo"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGraphQLHandler_InvalidCursor(t *testing.T) {
+	// 테스트 설정
+	router, _ := setupGraphQLRouter(t)
+
+	// 테스트 실행
+	w, out := postGraphQL(router, `{"query": "{ products(after: \"bad\") { totalCount } }"}`)
+
+	// 검증 - resolver 오류는 200과 함께 extensions.code로 알려줍니다.
+	assert.Equal(t, http.StatusOK, w.Code)
+	errs := out["errors"].([]interface{})
+	require.Len(t, errs, 1)
+	assert.Equal(t, map[string]interface{}{"code": "BAD_USER_INPUT"}, errs[0].(map[string]interface{})["extensions"])
+}
+
+func TestGraphQLHandler_GetRejectsMutation(t *testing.T) {
+	// 테스트 설정
+	router, _ := setupGraphQLRouter(t)
+	query := url.Values{"query": {`mutation { deleteProduct(id: "1") }`}}
+
+	// 테스트 실행
+	req := httptest.NewRequest(http.MethodGet, "/graphql?"+query.Encode(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// 검증
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Can only perform a mutation operation from a POST request.")
+	assert.NotContains(t, w.Body.String(), `"data"`)
+}
+
+func TestGraphQLHandler_AuthorizesPerOperation(t *testing.T) {
+	// 테스트 설정 - product:write가 없는 읽기 전용 클라이언트입니다.
+	gin.SetMode(gin.TestMode)
+	var checked []string
+	handler := NewGraphQLHandler(&controller.ProductController{})
+	handler.Authorize = func(c *gin.Context, permission string) bool {
+		checked = append(checked, permission)
+		if permission != auth.PermissionProductRead {
+			c.AbortWithStatus(http.StatusForbidden)
+			return false
+		}
+		return true
+	}
+	router := gin.New()
+	router.POST("/graphql", handler.Query)
+
+	// 테스트 실행
+	denied, _ := postGraphQL(router, `{"query": "mutation { deleteProduct(id: \"not-a-uuid\") }"}`)
+	allowed, _ := postGraphQL(router, `{"query": "{ product(id: \"not-a-uuid\") { name } }"}`)
+
+	// 검증 - mutation은 product:write, query는 product:read로 확인합니다.
+	assert.Equal(t, http.StatusForbidden, denied.Code)
+	assert.Equal(t, http.StatusOK, allowed.Code)
+	assert.Equal(t, []string{auth.PermissionProductWrite, auth.PermissionProductRead}, checked)
+}
+
+func TestGraphQLHandler_RejectsExpensiveQueries(t *testing.T) {
+	// 테스트 설정 - DB 쿼리를 설정하지 않았으므로 실행까지 가면 실패합니다.
+	router, mock := setupGraphQLRouter(t)
+	page := `products(first: 100) { nodes { id name price category sku createdAt updatedAt } }`
+	aliases := make([]string, 10)
+	for i := range aliases {
+		aliases[i] = fmt.Sprintf("p%d: %s", i, page)
+	}
+	deep := "{ __schema { types { fields { type " + strings.Repeat("{ ofType ", 14) + "{ name }" + strings.Repeat(" }", 14) + " } } } }"
+
+	// 테스트 실행
+	complex, complexOut := postGraphQL(router, fmt.Sprintf(`{"query": %q}`, "{ "+strings.Join(aliases, " ")+" }"))
+	nested, nestedOut := postGraphQL(router, fmt.Sprintf(`{"query": %q}`, deep))
+
+	// 검증 - 별칭으로 목록을 여러 번 읽거나 너무 깊은 쿼리는 실행하지 않고 400입니다.
+	assert.Equal(t, http.StatusBadRequest, complex.Code)
+	assert.Contains(t, complexOut["errors"].([]interface{})[0].(map[string]interface{})["message"], "query complexity 8010 exceeds the limit")
+	assert.Equal(t, http.StatusBadRequest, nested.Code)
+	assert.Contains(t, nestedOut["errors"].([]interface{})[0].(map[string]interface{})["message"], "exceeds max depth 15")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
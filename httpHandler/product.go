@@ -2,54 +2,116 @@ package httpHandler
 
 import (
 	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/events"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/patch"
 	"Go-Gin-Basic-Template/types"
 	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/types/responseTypes"
 	"Go-Gin-Basic-Template/utils"
+	"errors"
+	"fmt"
+	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// eventHeartbeatInterval마다 주석 줄을 보내서 프록시가 유휴 연결을 끊지 않게 합니다.
+const eventHeartbeatInterval = 15 * time.Second
+
 type ProductHandler struct {
 	ProductController *controller.ProductController
+	// LegacyResponse면 GET /product, GET /product/:id가 예전 모양(모델 그대로, 단건도 배열)으로 응답합니다.
+	// 클라이언트가 옮겨갈 수 있도록 한 릴리스 동안만 남겨둡니다.
+	LegacyResponse bool
+}
+
+// LegacyProductResponse는 LEGACY_PRODUCT_RESPONSE 환경 변수입니다.
+func LegacyProductResponse() bool {
+	legacy, _ := strconv.ParseBool(os.Getenv("LEGACY_PRODUCT_RESPONSE"))
+	return legacy
 }
 
 func (h *ProductHandler) Insert(c *gin.Context) {
 	var product requestTypes.ProductRequest
-	if err := c.ShouldBindJSON(&product); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload", err)
+	if statusCode, err := utils.Bind(c, &product); err != nil {
+		utils.RespondWithError(c, statusCode, i18n.InvalidRequestPayload, err)
 		return
 	}
 
-	statusCode, message, err := h.ProductController.Insert(&product)
+	statusCode, _, err := h.ProductController.Insert(c.Request.Context(), &product)
 	if err != nil {
-		utils.RespondWithError(c, statusCode, message, err)
+		utils.RespondWithError(c, statusCode, i18n.SaveFailed, err)
 		return
 	}
 
-	utils.RespondWithSuccess(c, statusCode, message)
+	utils.RespondWithSuccess(c, statusCode, i18n.Success)
 }
 
 func (h *ProductHandler) Update(c *gin.Context) {
 	id := c.Param("id")
 	var product requestTypes.ProductRequest
-	if err := c.ShouldBindJSON(&product); err != nil {
-		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request payload", err)
+	if statusCode, err := utils.Bind(c, &product); err != nil {
+		utils.RespondWithError(c, statusCode, i18n.InvalidRequestPayload, err)
+		return
+	}
+
+	statusCode, _, err := h.ProductController.Update(c.Request.Context(), id, &product)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SaveFailed, err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, statusCode, i18n.Success)
+}
+
+// Patch는 Content-Type으로 patch 형식을 고릅니다. application/merge-patch+json(과 application/json)은 RFC 7396,
+// application/json-patch+json은 RFC 6902입니다. 보내지 않은 필드는 그대로 둡니다.
+func (h *ProductHandler) Patch(c *gin.Context) {
+	id := c.Param("id")
+	var apply func(document []byte, patch []byte) ([]byte, error)
+	switch contentType := c.ContentType(); contentType {
+	case "", patch.MIMEMergePatch, binding.MIMEJSON:
+		apply = patch.Merge
+	case patch.MIMEJSONPatch:
+		apply = patch.Apply
+	default:
+		utils.RespondWithError(c, http.StatusUnsupportedMediaType, i18n.UnsupportedFormat, fmt.Errorf("supported types: %s, %s", patch.MIMEMergePatch, patch.MIMEJSONPatch))
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidPatch, err)
 		return
 	}
 
-	statusCode, message, err := h.ProductController.Update(id, &product)
+	statusCode, _, err := h.ProductController.Patch(c.Request.Context(), id, func(document []byte) ([]byte, error) {
+		return apply(document, body)
+	})
 	if err != nil {
+		message := i18n.SaveFailed
+		if statusCode == http.StatusBadRequest {
+			message = i18n.InvalidPatch
+		}
 		utils.RespondWithError(c, statusCode, message, err)
 		return
 	}
 
-	utils.RespondWithSuccess(c, statusCode, message)
+	utils.RespondWithSuccess(c, statusCode, i18n.Success)
 }
 
 func (h *ProductHandler) Delete(c *gin.Context) {
 	id := c.Param("id")
 
-	statusCode, message, err := h.ProductController.Delete(id)
+	statusCode, message, err := h.ProductController.Delete(c.Request.Context(), id)
 	if err != nil {
 		utils.RespondWithError(c, statusCode, message, err)
 		return
@@ -59,25 +121,296 @@ func (h *ProductHandler) Delete(c *gin.Context) {
 }
 
 func (h *ProductHandler) GetAll(c *gin.Context) {
-	statusCode, product, err := h.ProductController.GetAll()
+	var filter requestTypes.ProductFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidQueryParameter, err)
+		return
+	}
+	var page requestTypes.Page
+	if err := c.ShouldBindQuery(&page); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidQueryParameter, err)
+		return
+	}
+
+	var (
+		statusCode int
+		products   []types.Product
+		pagination *utils.Pagination
+		err        error
+	)
+	// limit이 없으면 예전처럼 전체 목록을 돌려줍니다.
+	if page.Limit != nil {
+		var total int64
+		statusCode, products, total, err = h.ProductController.GetPage(c.Request.Context(), &filter, *page.Limit, page.Offset)
+		pagination = &utils.Pagination{Offset: page.Offset, Limit: *page.Limit, Total: total}
+	} else {
+		var all *[]types.Product
+		if statusCode, all, err = h.ProductController.GetAll(c.Request.Context(), &filter); all != nil {
+			products = *all
+		}
+	}
 	if err != nil {
-		utils.RespondWithError(c, statusCode, "SELECT 오류", err)
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
 		return
 	}
 
-	utils.RespondWithGet(c, statusCode, *product)
+	if h.LegacyResponse {
+		deprecateLegacyResponse(c)
+		utils.RespondList(c, statusCode, products, pagination)
+		return
+	}
+	utils.RespondList(c, statusCode, responseTypes.NewProducts(products), pagination)
 }
 
 func (h *ProductHandler) GetByID(c *gin.Context) {
 	id := c.Param("id")
 
-	statusCode, product, err := h.ProductController.Get(id)
+	statusCode, product, err := h.ProductController.Get(c.Request.Context(), id)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
+		return
+	}
+	if h.LegacyResponse {
+		deprecateLegacyResponse(c)
+		utils.RespondList(c, statusCode, []types.Product{*product}, nil)
+		return
+	}
+	utils.RespondData(c, statusCode, responseTypes.NewProduct(product))
+}
+
+func deprecateLegacyResponse(c *gin.Context) {
+	utils.Deprecate(c, utils.Deprecation{
+		Message: utils.T(c, i18n.LegacyProductResponse),
+	})
+}
+
+func (h *ProductHandler) GetRevisions(c *gin.Context) {
+	id := c.Param("id")
+
+	statusCode, revisions, err := h.ProductController.GetRevisions(c.Request.Context(), id)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
+		return
+	}
+
+	utils.RespondList(c, statusCode, responseTypes.NewProductRevisions(*revisions), nil)
+}
+
+func (h *ProductHandler) GetRevision(c *gin.Context) {
+	id := c.Param("id")
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidRevision, err)
+		return
+	}
+
+	statusCode, result, err := h.ProductController.GetRevision(c.Request.Context(), id, revision)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
+		return
+	}
+
+	utils.RespondData(c, statusCode, responseTypes.NewProductRevision(result))
+}
+
+func (h *ProductHandler) DiffRevisions(c *gin.Context) {
+	id := c.Param("id")
+	from, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidRevision, err)
+		return
+	}
+	to, err := strconv.Atoi(c.Query("to"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidRevision, err)
+		return
+	}
+
+	statusCode, changes, err := h.ProductController.DiffRevisions(c.Request.Context(), id, from, to)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
+		return
+	}
+
+	utils.RespondList(c, statusCode, changes, nil)
+}
+
+func (h *ProductHandler) Revert(c *gin.Context) {
+	id := c.Param("id")
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidRevision, err)
+		return
+	}
+
+	statusCode, message, err := h.ProductController.Revert(c.Request.Context(), id, revision)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, message, err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, statusCode, message)
+}
+
+func (h *ProductHandler) Import(c *gin.Context) {
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+
+	body, filename, err := importBody(c)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidRequestPayload, err)
+		return
+	}
+	defer body.Close()
+
+	format := importFormat(c.Query("format"), filename, c.ContentType())
+	statusCode, report, err := h.ProductController.Import(c.Request.Context(), body, format, dryRun)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.ImportFailed, err)
+		return
+	}
+
+	utils.RespondData(c, statusCode, *report)
+}
+
+// importBody는 multipart 업로드라면 "file" 파트를, 아니면 요청 본문을 메모리에 올리지 않고 그대로 돌려줍니다.
+func importBody(c *gin.Context) (io.ReadCloser, string, error) {
+	if c.ContentType() != "multipart/form-data" {
+		return c.Request.Body, "", nil
+	}
+
+	reader, err := c.Request.MultipartReader()
 	if err != nil {
-		utils.RespondWithError(c, statusCode, "SELECT 오류", err)
+		return nil, "", err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, "", errors.New("multipart body has no \"file\" part")
+		}
+		if part.FormName() == "file" {
+			return part, part.FileName(), nil
+		}
+		part.Close()
+	}
+}
+
+func importFormat(query string, filename string, contentType string) string {
+	if query != "" {
+		return strings.ToLower(query)
+	}
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return controller.ImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return controller.ImportFormatNDJSON
+	}
+	switch contentType {
+	case "text/csv":
+		return controller.ImportFormatCSV
+	case "application/x-ndjson", "application/jsonl":
+		return controller.ImportFormatNDJSON
+	}
+	return ""
+}
+
+func (h *ProductHandler) Export(c *gin.Context) {
+	var filter requestTypes.ProductFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidQueryParameter, err)
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", controller.ExportFormatCSV))
+	contentType, ok := controller.ExportContentType(format)
+	if !ok {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.UnsupportedFormat, errors.New("format must be one of csv, ndjson, xlsx"))
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", `attachment; filename="products.`+format+`"`)
+	statusCode, err := h.ProductController.Export(c.Request.Context(), &filter, format, c.Writer)
+	if err != nil {
+		// 이미 본문을 쓰기 시작했다면 상태 코드를 바꿀 수 없으므로 연결만 끊습니다.
+		if c.Writer.Written() {
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
+	}
+}
+
+func (h *ProductHandler) Events(c *gin.Context) {
+	var filter requestTypes.ProductEventFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidQueryParameter, err)
+		return
+	}
+
+	// 브라우저 EventSource는 재연결할 때 Last-Event-ID 헤더를 보내고, 처음 연결할 때는 쿼리로만 넘길 수 있습니다.
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var since uint64
+	if lastEventID != "" {
+		var err error
+		if since, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidLastEventID, err)
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+	statusCode, replay, ch, cancel, err := h.ProductController.Subscribe(ctx, since, events.Filter{
+		ProductIDs: filter.ProductIDs,
+		Categories: filter.Categories,
+	})
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SubscribeFailed, err)
 		return
 	}
-	var response []types.Product
-	response = append(response, *product)
+	defer cancel()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	for _, event := range replay {
+		renderEvent(c, event)
+	}
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-ch:
+			// 채널이 닫혔다면 너무 느려서 끊긴 것입니다. 클라이언트가 Last-Event-ID로 다시 연결합니다.
+			if !ok {
+				return
+			}
+			renderEvent(c, event)
+		case <-heartbeat.C:
+			if _, err := io.WriteString(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+	}
+}
 
-	utils.RespondWithGet(c, statusCode, response)
+func renderEvent(c *gin.Context, event events.Event) {
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatUint(event.ID, 10),
+		Event: event.Type,
+		Data:  event,
+	})
 }
@@ -0,0 +1,99 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/events"
+	"Go-Gin-Basic-Template/tenancy"
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupEventServer(t *testing.T, broker *events.Broker) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	handler := &ProductHandler{ProductController: &controller.ProductController{Events: broker}}
+
+	router := gin.New()
+	router.GET("/product/events", func(c *gin.Context) {
+		c.Request = c.Request.WithContext(tenancy.WithTenant(c.Request.Context(), "tenant-a"))
+	}, handler.Events)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// readEvent는 빈 줄이 나올 때까지 읽어서 SSE 이벤트 하나를 필드 이름별로 돌려줍니다.
+func readEvent(t *testing.T, reader *bufio.Reader) map[string]string {
+	t.Helper()
+	fields := map[string]string{}
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			return fields
+		}
+		name, value, _ := strings.Cut(line, ":")
+		fields[name] = value
+	}
+}
+
+func TestProductHandler_Events_ReplayAndLive(t *testing.T) {
+	// 테스트 설정
+	broker := events.NewBroker(10)
+	broker.Publish(events.Event{Type: events.ProductCreated, TenantID: "tenant-a", ProductID: "p1", Category: "식품"})
+	broker.Publish(events.Event{Type: events.ProductCreated, TenantID: "tenant-a", ProductID: "p2", Category: "생활"})
+	broker.Publish(events.Event{Type: events.ProductUpdated, TenantID: "tenant-a", ProductID: "p1", Category: "식품"})
+	server := setupEventServer(t, broker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/product/events?category=식품", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "1")
+
+	// 테스트 실행
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+
+	// 검증 - 1번 이후의 식품 이벤트만 재전송됩니다.
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	replayed := readEvent(t, reader)
+	assert.Equal(t, "3", replayed["id"])
+	assert.Equal(t, events.ProductUpdated, replayed["event"])
+
+	// 연결 이후에 발생한 이벤트도 같은 필터로 전달됩니다.
+	broker.Publish(events.Event{Type: events.ProductDeleted, TenantID: "tenant-a", ProductID: "p2", Category: "생활"})
+	broker.Publish(events.Event{Type: events.ProductDeleted, TenantID: "tenant-a", ProductID: "p1", Category: "식품"})
+	live := readEvent(t, reader)
+	assert.Equal(t, "5", live["id"])
+	assert.Equal(t, events.ProductDeleted, live["event"])
+	assert.Contains(t, live["data"], `"productId":"p1"`)
+}
+
+func TestProductHandler_Events_InvalidLastEventID(t *testing.T) {
+	// 테스트 설정
+	server := setupEventServer(t, events.NewBroker(10))
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/product/events", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "abc")
+
+	// 테스트 실행
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	// 검증
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,169 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/utils"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const productForUpdateQuery = `SELECT * FROM "products" WHERE id = $1 AND "products"."delete_at" IS NULL ORDER BY "products"."id" LIMIT $2 FOR UPDATE`
+
+// expectProductForUpdate는 수정할 상품을 잠그고 읽는 쿼리를 설정합니다.
+func expectProductForUpdate(mock sqlmock.Sqlmock, id uuid.UUID) {
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(productForUpdateQuery)).
+		WithArgs(id.String(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "create_at", "name", "price", "category", "sku"}).
+			AddRow(id, "tenant-a", time.Now(), "사과", 1000.0, "식품", "FOOD-APPLE-001"))
+}
+
+func expectProductSaved(mock sqlmock.Sqlmock, name string, price float64, category string, sku string) {
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET`)).
+		WithArgs(
+			sqlmock.AnyArg(), // TenantID
+			sqlmock.AnyArg(), // CreateAt
+			sqlmock.AnyArg(), // UpdateAt
+			sqlmock.AnyArg(), // DeleteAt
+			name,
+			price,
+			category,
+			sku,
+			nil,              // SupplierCost
+			nil,              // InternalNotes
+			sqlmock.AnyArg(), // WHERE 조건의 ID
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(revision), 0) FROM "product_revisions" WHERE product_id = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_revisions"`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+}
+
+func sendPatch(t *testing.T, router http.Handler, method string, id uuid.UUID, contentType string, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/product/"+id.String(), strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestProductHandler_Patch_MergePatch(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupProductRouter(t, false)
+	id := uuid.New()
+
+	// SQL 쿼리 모의 설정 - 보내지 않은 필드는 현재 값 그대로 저장합니다.
+	expectProductForUpdate(mock, id)
+	expectProductSaved(mock, "사과", 2000.0, "", "FOOD-APPLE-001")
+
+	// 테스트 실행
+	w := sendPatch(t, router, http.MethodPatch, id, "application/merge-patch+json", `{"price": 2000, "category": null}`)
+
+	// 검증
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductHandler_Patch_JSONPatch(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupProductRouter(t, false)
+	id := uuid.New()
+
+	// SQL 쿼리 모의 설정
+	expectProductForUpdate(mock, id)
+	expectProductSaved(mock, "청사과", 1000.0, "식품", "FOOD-APPLE-002")
+
+	// 테스트 실행
+	w := sendPatch(t, router, http.MethodPatch, id, "application/json-patch+json", `[
+		{"op": "test", "path": "/name", "value": "사과"},
+		{"op": "replace", "path": "/name", "value": "청사과"},
+		{"op": "replace", "path": "/sku", "value": "FOOD-APPLE-002"}
+	]`)
+
+	// 검증
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductHandler_Patch_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		code        string
+		queried     bool
+	}{
+		{"test failed", "application/json-patch+json", `[{"op": "test", "path": "/price", "value": 500}]`, http.StatusConflict, "product.patch_test_failed", true},
+		{"missing path", "application/json-patch+json", `[{"op": "remove", "path": "/color"}]`, http.StatusUnprocessableEntity, "product.patch_path_not_found", true},
+		{"wrong type", "application/json-patch+json", `[{"op": "replace", "path": "/price", "value": "비쌈"}]`, http.StatusUnprocessableEntity, "product.patch_invalid_value", true},
+		{"validation failed", "application/merge-patch+json", `{"name": null}`, http.StatusUnprocessableEntity, "request.validation_failed", true},
+		{"malformed patch", "application/json-patch+json", `{"op": "add"}`, http.StatusBadRequest, "bad_request", true},
+		{"unsupported type", "text/plain", `price=1`, http.StatusUnsupportedMediaType, "unsupported_media_type", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 테스트 설정
+			router, mock := setupProductRouter(t, false)
+			id := uuid.New()
+
+			// SQL 쿼리 모의 설정 - 실패하면 저장하지 않고 롤백합니다.
+			if tt.queried {
+				expectProductForUpdate(mock, id)
+				mock.ExpectRollback()
+			}
+
+			// 테스트 실행
+			w := sendPatch(t, router, http.MethodPatch, id, tt.contentType, tt.body)
+
+			// 검증
+			assert.Equal(t, tt.status, w.Code)
+			var problem utils.Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, tt.code, problem.Code)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestProductHandler_Put_ReplacesAllFields(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupProductRouter(t, false)
+	id := uuid.New()
+
+	// SQL 쿼리 모의 설정 - 보내지 않은 sku와 category는 비워집니다.
+	expectProductForUpdate(mock, id)
+	expectProductSaved(mock, "배", 3000.0, "", "")
+
+	// 테스트 실행
+	w := sendPatch(t, router, http.MethodPut, id, "application/json", `{"name": "배", "price": 3000}`)
+
+	// 검증
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductHandler_Put_ValidationFailed(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupProductRouter(t, false)
+
+	// 테스트 실행
+	w := sendPatch(t, router, http.MethodPut, uuid.New(), "application/json", `{"price": 3000}`)
+
+	// 검증 - 상품을 읽기 전에 거절합니다.
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,213 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/utils"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const productByIDQuery = `SELECT * FROM "products" WHERE id IN ($1) AND "products"."delete_at" IS NULL`
+
+func setupProductRouter(t *testing.T, legacy bool) (*gin.Engine, sqlmock.Sqlmock) {
+	gin.SetMode(gin.TestMode)
+
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, PreferSimpleProtocol: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	handler := &ProductHandler{
+		ProductController: &controller.ProductController{ProductRepository: &repository.ProductRepository{DB: db}},
+		LegacyResponse:    legacy,
+	}
+	router := gin.New()
+	withTenant := func(c *gin.Context) {
+		c.Request = c.Request.WithContext(tenancy.WithTenant(c.Request.Context(), "tenant-a"))
+	}
+	router.GET("/product", withTenant, handler.GetAll)
+	router.GET("/product/:id", withTenant, handler.GetByID)
+	router.POST("/product", withTenant, handler.Insert)
+	router.PUT("/product/:id", withTenant, handler.Update)
+	router.PATCH("/product/:id", withTenant, handler.Patch)
+	router.GET("/product/:id/revisions/:rev", withTenant, handler.GetRevision)
+	return router, mock
+}
+
+func TestProductHandler_GetByID_SingleObject(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupProductRouter(t, false)
+	id := uuid.New()
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(productByIDQuery)).
+		WithArgs(id.String()).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "create_at", "update_at", "name", "price", "category"}).
+			AddRow(id, createdAt, createdAt, "사과", 1000.0, "식품"))
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/product/"+id.String(), nil))
+
+	// 검증 - data는 배열이 아닌 camelCase 객체입니다.
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Deprecation"))
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, map[string]interface{}{
+		"id":        id.String(),
+		"name":      "사과",
+		"price":     1000.0,
+		"category":  "식품",
+		"createdAt": "2024-01-02T03:04:05Z",
+		"updatedAt": "2024-01-02T03:04:05Z",
+	}, body.Data)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductHandler_GetByID_NotFound(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupProductRouter(t, false)
+	id := uuid.New()
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(productByIDQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/product/"+id.String(), nil))
+
+	// 검증
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var problem utils.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "product.not_found", problem.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductHandler_GetByID_LegacyResponse(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupProductRouter(t, true)
+	id := uuid.New()
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(productByIDQuery)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "price"}).AddRow(id, "사과", 1000.0))
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/product/"+id.String(), nil))
+
+	// 검증 - 예전처럼 모델 그대로 배열에 담기고 Deprecation 헤더가 붙습니다.
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	var body struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Data, 1)
+	assert.Equal(t, "사과", body.Data[0]["Name"])
+	assert.Equal(t, id.String(), body.Data[0]["ID"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductHandler_GetAll_Page(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupProductRouter(t, false)
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "products"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(uuid.New(), "배"))
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/product?limit=1&offset=1", nil))
+
+	// 검증
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Data []map[string]interface{} `json:"data"`
+		Meta struct {
+			Pagination map[string]interface{} `json:"pagination"`
+		} `json:"meta"`
+		Links map[string]string `json:"links"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Data, 1)
+	assert.Equal(t, "배", body.Data[0]["name"])
+	assert.Equal(t, map[string]interface{}{"offset": 1.0, "limit": 1.0, "total": 3.0}, body.Meta.Pagination)
+	assert.Equal(t, "/product?limit=1&offset=2", body.Links["next"])
+	assert.Equal(t, "/product?limit=1&offset=0", body.Links["prev"])
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductHandler_GetAll_InvalidLimit(t *testing.T) {
+	// 테스트 설정
+	router, _ := setupProductRouter(t, false)
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/product?limit=0", nil))
+
+	// 검증 - 검증 오류는 필드별 오류와 함께 422입니다.
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	var problem utils.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "limit", problem.Errors[0].Field)
+	assert.Equal(t, "min", problem.Errors[0].Rule)
+}
+
+func TestProductHandler_GetRevision_SingleObject(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupProductRouter(t, false)
+	id, productID := uuid.New(), uuid.New()
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_revisions" WHERE product_id = $1 AND revision = $2`)).
+		WithArgs(productID.String(), 2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "product_id", "revision", "snapshot", "create_at"}).
+			AddRow(id, "tenant-a", productID, 2, `{"name":"사과","price":1000,"category":"식품"}`, createdAt))
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/product/"+productID.String()+"/revisions/2", nil))
+
+	// 검증 - data는 배열이 아닌 camelCase 객체이고 테넌트는 나가지 않습니다.
+	assert.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, map[string]interface{}{
+		"id":        id.String(),
+		"productId": productID.String(),
+		"revision":  2.0,
+		"snapshot":  map[string]interface{}{"name": "사과", "price": 1000.0, "category": "식품"},
+		"createdAt": "2024-01-02T03:04:05Z",
+	}, body.Data)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
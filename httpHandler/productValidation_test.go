@@ -0,0 +1,57 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/utils"
+	"Go-Gin-Basic-Template/validation"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductHandler_Insert_ValidationFailed(t *testing.T) {
+	// 테스트 설정
+	router, mock := setupProductRouter(t, false)
+	body := `{"name": "   ", "price": -1, "sku": "food_apple"}`
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/product", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept-Language", "en")
+	router.ServeHTTP(w, req)
+
+	// 검증 - DB에 쓰기 전에 필드마다 오류를 돌려줍니다.
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, utils.MIMEProblemJSON, w.Header().Get("Content-Type"))
+	var problem utils.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "request.validation_failed", problem.Code)
+	assert.Equal(t, []validation.FieldError{
+		{Field: "name", Rule: "notblank", Message: "name must not be blank"},
+		{Field: "price", Rule: "gt", Param: "0", Message: "price must be greater than 0"},
+		{Field: "sku", Rule: "sku", Message: "sku must be a SKU of uppercase letters and digits (e.g. FOOD-APPLE-001)"},
+	}, problem.Errors)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProductHandler_Insert_MalformedBody(t *testing.T) {
+	// 테스트 설정
+	router, _ := setupProductRouter(t, false)
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/product", strings.NewReader(`{"name": `))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// 검증 - 검증 오류가 아닌 파싱 오류는 그대로 400입니다.
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var problem utils.Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Empty(t, problem.Errors)
+}
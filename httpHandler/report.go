@@ -0,0 +1,27 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/utils"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+type ReportHandler struct {
+	ReportController *controller.ReportController
+}
+
+func (h *ReportHandler) Products(c *gin.Context) {
+	interval := c.DefaultQuery("interval", types.ReportIntervalMonth)
+	fresh, _ := strconv.ParseBool(c.Query("fresh"))
+
+	statusCode, report, err := h.ReportController.Products(c.Request.Context(), interval, fresh)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
+		return
+	}
+
+	utils.RespondData(c, statusCode, *report)
+}
@@ -0,0 +1,39 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type TenantHandler struct {
+	TenantController *controller.TenantController
+}
+
+func (h *TenantHandler) Insert(c *gin.Context) {
+	var tenant requestTypes.TenantRequest
+	if statusCode, err := utils.Bind(c, &tenant); err != nil {
+		utils.RespondWithError(c, statusCode, i18n.InvalidRequestPayload, err)
+		return
+	}
+
+	statusCode, message, err := h.TenantController.Insert(c.Request.Context(), &tenant)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, message, err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, statusCode, message)
+}
+
+func (h *TenantHandler) GetAll(c *gin.Context) {
+	statusCode, tenants, err := h.TenantController.GetAll(c.Request.Context())
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
+		return
+	}
+
+	utils.RespondList(c, statusCode, *tenants, nil)
+}
@@ -0,0 +1,30 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/types/responseTypes"
+	"Go-Gin-Basic-Template/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	UserController *controller.UserController
+}
+
+func (h *UserHandler) Insert(c *gin.Context) {
+	var user requestTypes.UserRequest
+	if statusCode, err := utils.Bind(c, &user); err != nil {
+		utils.RespondWithError(c, statusCode, i18n.InvalidRequestPayload, err)
+		return
+	}
+
+	statusCode, created, err := h.UserController.Insert(c.Request.Context(), &user)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SaveFailed, err)
+		return
+	}
+
+	utils.RespondData(c, statusCode, responseTypes.NewUser(created))
+}
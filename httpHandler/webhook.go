@@ -0,0 +1,104 @@
+package httpHandler
+
+import (
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/types/responseTypes"
+	"Go-Gin-Basic-Template/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type WebhookHandler struct {
+	WebhookController *controller.WebhookController
+}
+
+func (h *WebhookHandler) Insert(c *gin.Context) {
+	var webhook requestTypes.WebhookRequest
+	if statusCode, err := utils.Bind(c, &webhook); err != nil {
+		utils.RespondWithError(c, statusCode, i18n.InvalidRequestPayload, err)
+		return
+	}
+
+	statusCode, subscription, secret, err := h.WebhookController.Insert(c.Request.Context(), &webhook)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.WebhookCreateFailed, err)
+		return
+	}
+
+	// 서명 비밀은 구독 행에만 암호화해서 남기고, 멱등성 기록이나 캐시에는 남기지 않습니다.
+	utils.NoStore(c)
+	utils.RespondData(c, statusCode, responseTypes.WebhookSubscriptionCreated{
+		WebhookSubscription: *subscription,
+		Secret:              secret,
+	})
+}
+
+func (h *WebhookHandler) Update(c *gin.Context) {
+	id := c.Param("id")
+	var webhook requestTypes.WebhookPatchRequest
+	if statusCode, err := utils.Bind(c, &webhook); err != nil {
+		utils.RespondWithError(c, statusCode, i18n.InvalidRequestPayload, err)
+		return
+	}
+
+	statusCode, subscription, err := h.WebhookController.Update(c.Request.Context(), id, &webhook)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.WebhookUpdateFailed, err)
+		return
+	}
+
+	utils.RespondData(c, statusCode, subscription)
+}
+
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	id := c.Param("id")
+
+	statusCode, message, err := h.WebhookController.Delete(c.Request.Context(), id)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, message, err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, statusCode, message)
+}
+
+func (h *WebhookHandler) GetAll(c *gin.Context) {
+	statusCode, subscriptions, err := h.WebhookController.GetAll(c.Request.Context())
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
+		return
+	}
+
+	utils.RespondList(c, statusCode, *subscriptions, nil)
+}
+
+func (h *WebhookHandler) GetByID(c *gin.Context) {
+	id := c.Param("id")
+
+	statusCode, subscription, err := h.WebhookController.Get(c.Request.Context(), id)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
+		return
+	}
+
+	utils.RespondData(c, statusCode, subscription)
+}
+
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	id := c.Param("id")
+	var filter requestTypes.WebhookDeliveryFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidQueryParameter, err)
+		return
+	}
+
+	statusCode, deliveries, err := h.WebhookController.GetDeliveries(c.Request.Context(), id, &filter)
+	if err != nil {
+		utils.RespondWithError(c, statusCode, i18n.SelectFailed, err)
+		return
+	}
+
+	utils.RespondList(c, statusCode, *deliveries, nil)
+}
@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+)
+
+const (
+	Korean   = "ko"
+	English  = "en"
+	Japanese = "ja"
+)
+
+// Supported는 카탈로그가 있는 언어입니다. locales/<언어>.json이 하나씩 있어야 합니다.
+var Supported = []string{Korean, English, Japanese}
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogs는 언어별로 메시지 코드 → 메시지입니다. 메시지의 %s, %d는 T의 args로 채웁니다.
+var catalogs = loadCatalogs()
+
+func loadCatalogs() map[string]map[string]string {
+	catalogs := make(map[string]map[string]string, len(Supported))
+	for _, locale := range Supported {
+		body, err := localeFiles.ReadFile(path.Join("locales", locale+".json"))
+		if err != nil {
+			panic(err)
+		}
+		catalog := make(map[string]string)
+		if err = json.Unmarshal(body, &catalog); err != nil {
+			panic(fmt.Sprintf("i18n: locales/%s.json: %v", locale, err))
+		}
+		catalogs[locale] = catalog
+	}
+	return catalogs
+}
+
+// Lookup은 locale 카탈로그에서만 찾습니다.
+func Lookup(locale string, key string) (string, bool) {
+	message, ok := catalogs[locale][key]
+	return message, ok
+}
+
+// T는 locale의 메시지입니다. locale에 없으면 기본 언어에서 찾고, 그래도 없으면 key를 그대로 돌려줍니다.
+// 상품 ID처럼 번역할 수 없는 값을 메시지 자리에 넘겨도 그대로 나갑니다.
+func T(locale string, key string, args ...interface{}) string {
+	message, ok := Lookup(locale, key)
+	if !ok {
+		if message, ok = Lookup(DefaultLocale(), key); !ok {
+			return key
+		}
+	}
+	if len(args) > 0 && strings.Contains(message, "%") {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
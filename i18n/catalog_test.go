@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogs_HaveSameKeys(t *testing.T) {
+	// 테스트 설정 - keys.go의 상수는 모든 카탈로그에 있어야 합니다.
+	file, err := parser.ParseFile(token.NewFileSet(), "keys.go", nil, 0)
+	require.NoError(t, err)
+	var keys []string
+	ast.Inspect(file, func(node ast.Node) bool {
+		if literal, ok := node.(*ast.BasicLit); ok && literal.Kind == token.STRING {
+			key, _ := strconv.Unquote(literal.Value)
+			keys = append(keys, key)
+		}
+		return true
+	})
+	require.NotEmpty(t, keys)
+
+	for _, locale := range Supported {
+		// 검증
+		for _, key := range keys {
+			_, ok := Lookup(locale, key)
+			assert.True(t, ok, "%s: %s", locale, key)
+		}
+		assert.Len(t, catalogs[locale], len(catalogs[Korean]), locale)
+		for key := range catalogs[Korean] {
+			assert.Contains(t, catalogs[locale], key, locale)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	// 테스트 실행 및 검증
+	assert.Equal(t, "Success", T(English, Success))
+	assert.Equal(t, "Failed to encode response as text/csv", T(English, EncodeFailed, "text/csv"))
+	// 카탈로그에 없는 값은 그대로 나갑니다.
+	assert.Equal(t, "0b6f…", T(Japanese, "0b6f…"))
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		expected       string
+	}{
+		{"", Korean},
+		{"en-US,en;q=0.9", English},
+		{"ja-JP", Japanese},
+		{"fr-FR, ja;q=0.5, en;q=0.3", Japanese},
+		{"fr-FR", Korean},
+		{"잘못된 값", Korean},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행 및 검증
+		assert.Equal(t, tt.expected, Negotiate(tt.acceptLanguage), tt.acceptLanguage)
+	}
+}
+
+func TestNegotiate_DefaultLocale(t *testing.T) {
+	// 테스트 설정
+	t.Setenv("DEFAULT_LOCALE", English)
+
+	// 테스트 실행 및 검증
+	assert.Equal(t, English, Negotiate("fr-FR"))
+	assert.Equal(t, "Success", T("fr", Success))
+}
+
+func TestTranslate(t *testing.T) {
+	// 테스트 설정
+	v := validator.New()
+	require.NoError(t, RegisterValidator(v))
+	err := v.Struct(struct {
+		Name string `validate:"required"`
+		Code string `validate:"min=3"`
+	}{Code: "a"})
+	var validationErrors validator.ValidationErrors
+	require.ErrorAs(t, err, &validationErrors)
+
+	tests := map[string][]string{
+		Korean:   {"Name은(는) 필수 항목입니다", "Code은(는) 3자 이상이어야 합니다"},
+		English:  {"Name is a required field", "Code must be at least 3 characters in length"},
+		Japanese: {"Nameは必須フィールドです", "Codeの長さは少なくとも3文字はなければなりません"},
+	}
+	for locale, expected := range tests {
+		// 테스트 실행
+		messages := []string{Translate(locale, validationErrors[0]), Translate(locale, validationErrors[1])}
+
+		// 검증
+		assert.Equal(t, expected, messages, locale)
+	}
+}
@@ -0,0 +1,18 @@
+package i18n
+
+import "context"
+
+type localeKey struct{}
+
+// WithLocale은 요청 컨텍스트에 언어를 넣습니다. controller처럼 gin.Context가 없는 곳에서 FromContext로 꺼내 씁니다.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext는 WithLocale로 넣은 언어입니다. 없으면 DefaultLocale과 false를 돌려줍니다.
+func FromContext(ctx context.Context) (string, bool) {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok {
+		return locale, true
+	}
+	return DefaultLocale(), false
+}
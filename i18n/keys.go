@@ -0,0 +1,41 @@
+package i18n
+
+// 메시지 코드입니다. 추가하면 locales/의 모든 카탈로그에도 추가해야 합니다. (go test ./i18n가 확인합니다)
+// 도메인 오류의 Code(예: "product.not_found")도 같은 카탈로그에서 찾습니다.
+const (
+	Success       = "common.success"
+	SelectFailed  = "common.select_failed"
+	SaveFailed    = "common.save_failed"
+	DeleteFailed  = "common.delete_failed"
+	EncodeFailed  = "common.encode_failed"
+	NotAcceptable = "common.not_acceptable"
+
+	InvalidRequestPayload = "request.invalid_payload"
+	InvalidQueryParameter = "request.invalid_query"
+	RequestTooLarge       = "request.too_large"
+	UnsupportedFormat     = "request.unsupported_format"
+	ValidationFailed      = "request.validation_failed"
+	InvalidPatch          = "request.invalid_patch"
+
+	InvalidRevision       = "product.invalid_revision"
+	InvalidLastEventID    = "product.invalid_last_event_id"
+	ImportFailed          = "product.import_failed"
+	SubscribeFailed       = "product.subscribe_failed"
+	LegacyProductResponse = "product.legacy_response"
+	WebhookCreateFailed   = "webhook.create_failed"
+	WebhookUpdateFailed   = "webhook.update_failed"
+	InvalidTenantID       = "tenant.invalid_id"
+	TenantRequired        = "tenant.required"
+	TenantMismatch        = "tenant.mismatch"
+	TenantNotFound        = "tenant.not_found"
+	AdminRequired         = "admin.required"
+	InvalidIdempotencyKey = "idempotency.invalid_key"
+	IdempotencyKeyReused  = "idempotency.key_reused"
+	IdempotencyInProgress = "idempotency.in_progress"
+
+	AuthenticationRequired = "auth.required"
+	AuthenticationFailed   = "auth.failed"
+	PermissionDenied       = "auth.permission_denied"
+
+	RateLimitExceeded = "request.rate_limited"
+)
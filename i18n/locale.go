@@ -0,0 +1,37 @@
+package i18n
+
+import (
+	"golang.org/x/text/language"
+	"os"
+)
+
+var matcher = newMatcher()
+
+func newMatcher() language.Matcher {
+	tags := make([]language.Tag, 0, len(Supported))
+	for _, locale := range Supported {
+		tags = append(tags, language.MustParse(locale))
+	}
+	return language.NewMatcher(tags)
+}
+
+// DefaultLocale은 Accept-Language가 없거나 지원하지 않는 언어일 때 쓰는 DEFAULT_LOCALE입니다. 기본값은 ko입니다.
+func DefaultLocale() string {
+	if locale := os.Getenv("DEFAULT_LOCALE"); catalogs[locale] != nil {
+		return locale
+	}
+	return Korean
+}
+
+// Negotiate는 Accept-Language(q 값 포함)에서 지원하는 언어를 고릅니다. ja-JP처럼 지역이 붙어 있어도 ja로 맞춥니다.
+func Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale()
+	}
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale()
+	}
+	return Supported[index]
+}
@@ -0,0 +1,127 @@
+package i18n
+
+import (
+	"fmt"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/ja"
+	"github.com/go-playground/locales/ko"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	jaTranslations "github.com/go-playground/validator/v10/translations/ja"
+	"reflect"
+	"strings"
+)
+
+var translators = ut.New(en.New(), en.New(), ja.New(), ko.New())
+
+// RegisterValidator는 v의 검증 오류 메시지를 지원하는 언어로 등록합니다.
+// 번역은 Validate 인스턴스마다 따로 등록되므로 gin의 binding.Validator 엔진에 등록해야 합니다.
+func RegisterValidator(v *validator.Validate) error {
+	englishTranslator, _ := translators.GetTranslator(English)
+	if err := enTranslations.RegisterDefaultTranslations(v, englishTranslator); err != nil {
+		return err
+	}
+	japaneseTranslator, _ := translators.GetTranslator(Japanese)
+	if err := jaTranslations.RegisterDefaultTranslations(v, japaneseTranslator); err != nil {
+		return err
+	}
+	koreanTranslator, _ := translators.GetTranslator(Korean)
+	return registerKoreanTranslations(v, koreanTranslator)
+}
+
+// RegisterValidation은 커스텀 검증 태그의 메시지를 언어별로 등록합니다. messages는 언어 → "{0}은(는) ..." 형식의 메시지입니다.
+func RegisterValidation(v *validator.Validate, tag string, messages map[string]string) error {
+	for locale, message := range messages {
+		translator, found := translators.GetTranslator(locale)
+		if !found {
+			return fmt.Errorf("i18n: unsupported locale %q", locale)
+		}
+		if err := v.RegisterTranslation(tag, translator, registerMessage(tag, message), translateField); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Translate는 검증 오류 하나를 locale로 번역합니다.
+func Translate(locale string, fieldError validator.FieldError) string {
+	translator, _ := translators.GetTranslator(locale)
+	return fieldError.Translate(translator)
+}
+
+func registerMessage(tag string, message string) validator.RegisterTranslationsFunc {
+	return func(translator ut.Translator) error {
+		return translator.Add(tag, message, true)
+	}
+}
+
+func translateField(translator ut.Translator, fieldError validator.FieldError) string {
+	message, err := translator.T(fieldError.Tag(), fieldError.Field(), fieldError.Param())
+	if err != nil {
+		return fieldError.Error()
+	}
+	return message
+}
+
+// koreanMessages는 validator에 한국어 번역이 없어서 자주 쓰는 태그만 직접 둡니다. 없는 태그는 validator의 기본 메시지가 나갑니다.
+// 문자열, 슬라이스, 숫자에 따라 단위가 달라지는 태그는 "-string", "-items"를 붙인 키를 씁니다.
+var koreanMessages = map[string]string{
+	"required":      "{0}은(는) 필수 항목입니다",
+	"min":           "{0}은(는) {1} 이상이어야 합니다",
+	"min-string":    "{0}은(는) {1}자 이상이어야 합니다",
+	"min-items":     "{0}은(는) {1}개 이상이어야 합니다",
+	"max":           "{0}은(는) {1} 이하여야 합니다",
+	"max-string":    "{0}은(는) {1}자 이하여야 합니다",
+	"max-items":     "{0}은(는) {1}개 이하여야 합니다",
+	"len":           "{0}은(는) {1}이어야 합니다",
+	"len-string":    "{0}은(는) {1}자여야 합니다",
+	"len-items":     "{0}은(는) {1}개여야 합니다",
+	"gt":            "{0}은(는) {1}보다 커야 합니다",
+	"gte":           "{0}은(는) {1} 이상이어야 합니다",
+	"lt":            "{0}은(는) {1}보다 작아야 합니다",
+	"lte":           "{0}은(는) {1} 이하여야 합니다",
+	"oneof":         "{0}은(는) [{1}] 중 하나여야 합니다",
+	"email":         "{0}은(는) 올바른 이메일 주소여야 합니다",
+	"url":           "{0}은(는) 올바른 URL이어야 합니다",
+	"uuid":          "{0}은(는) 올바른 UUID여야 합니다",
+	"alphanum":      "{0}은(는) 영문자와 숫자만 쓸 수 있습니다",
+	"required_if":   "{0}은(는) 필수 항목입니다",
+	"required_with": "{0}은(는) 필수 항목입니다",
+}
+
+func registerKoreanTranslations(v *validator.Validate, translator ut.Translator) error {
+	for key, message := range koreanMessages {
+		if err := translator.Add(key, message, true); err != nil {
+			return err
+		}
+	}
+	for key := range koreanMessages {
+		if strings.Contains(key, "-") {
+			continue
+		}
+		if err := v.RegisterTranslation(key, translator, func(ut.Translator) error { return nil }, translateKorean); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// translateKorean은 min, max, len처럼 필드 종류에 따라 단위가 다른 태그를 고릅니다.
+func translateKorean(translator ut.Translator, fieldError validator.FieldError) string {
+	key := fieldError.Tag()
+	switch key {
+	case "min", "max", "len":
+		switch fieldError.Kind() {
+		case reflect.String:
+			key += "-string"
+		case reflect.Slice, reflect.Map, reflect.Array:
+			key += "-items"
+		}
+	}
+	message, err := translator.T(key, fieldError.Field(), fieldError.Param())
+	if err != nil {
+		return fieldError.Error()
+	}
+	return message
+}
@@ -0,0 +1,78 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"log/slog"
+	"time"
+)
+
+const defaultSlowThreshold = 200 * time.Millisecond
+
+// GormLogger는 GORM의 SQL 로그를 요청 컨텍스트의 slog 로거로 보냅니다. 오류는 error, SlowThreshold보다 느린 쿼리는 warn,
+// 나머지는 debug로 남기므로 LOG_LEVEL=debug일 때만 모든 SQL이 보입니다. 찾는 행이 없는 것은 오류로 보지 않습니다.
+type GormLogger struct {
+	SlowThreshold time.Duration
+	level         gormLogger.LogLevel
+}
+
+func NewGormLogger() *GormLogger {
+	return &GormLogger{SlowThreshold: defaultSlowThreshold, level: gormLogger.Info}
+}
+
+func (l *GormLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	l.log(ctx, gormLogger.Info, slog.LevelInfo, fmt.Sprintf(msg, args...))
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	l.log(ctx, gormLogger.Warn, slog.LevelWarn, fmt.Sprintf(msg, args...))
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	l.log(ctx, gormLogger.Error, slog.LevelError, fmt.Sprintf(msg, args...))
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormLogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	level, message := slog.LevelDebug, "sql"
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormLogger.Error:
+		level, message = slog.LevelError, "sql failed"
+	case l.SlowThreshold > 0 && elapsed > l.SlowThreshold && l.level >= gormLogger.Warn:
+		level, message = slog.LevelWarn, "slow sql"
+	}
+
+	logger := FromContext(ctx)
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+	sql, rows := fc()
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int64("rows", rows),
+		slog.Float64("duration_ms", float64(elapsed.Microseconds())/1000),
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	logger.LogAttrs(ctx, level, message, attrs...)
+}
+
+func (l *GormLogger) log(ctx context.Context, minimum gormLogger.LogLevel, level slog.Level, message string) {
+	if l.level >= minimum {
+		FromContext(ctx).Log(ctx, level, message)
+	}
+}
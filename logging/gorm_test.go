@@ -0,0 +1,81 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+func decodeLines(t *testing.T, buffer *bytes.Buffer) []map[string]interface{} {
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		entry := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestLevel(t *testing.T) {
+	tests := map[string]slog.Level{"": slog.LevelInfo, "debug": slog.LevelDebug, "WARN": slog.LevelWarn, "error": slog.LevelError, "loud": slog.LevelInfo}
+
+	for value, expected := range tests {
+		// 테스트 설정
+		t.Setenv("LOG_LEVEL", value)
+
+		// 테스트 실행 및 검증
+		assert.Equal(t, expected, Level(), value)
+	}
+}
+
+func TestGormLogger_Trace(t *testing.T) {
+	// 테스트 설정 - 요청 컨텍스트의 로거로 남기므로 요청 ID가 붙습니다.
+	var buffer bytes.Buffer
+	ctx := WithLogger(context.Background(), New(&buffer, slog.LevelInfo).With("request_id", "req-1"))
+	logger := NewGormLogger()
+	query := func() (string, int64) { return `SELECT * FROM "products"`, 2 }
+
+	// 테스트 실행
+	logger.Trace(ctx, time.Now(), query, nil)
+	logger.Trace(ctx, time.Now(), query, gorm.ErrRecordNotFound)
+	logger.Trace(ctx, time.Now().Add(-time.Second), query, nil)
+	logger.Trace(ctx, time.Now(), query, errors.New("connection reset"))
+
+	// 검증 - info 수준에서는 느린 쿼리와 실패한 쿼리만 남고, 찾는 행이 없는 것은 오류가 아닙니다.
+	lines := decodeLines(t, &buffer)
+	require.Len(t, lines, 2)
+	assert.Equal(t, "slow sql", lines[0]["msg"])
+	assert.Equal(t, "WARN", lines[0]["level"])
+	assert.Equal(t, "req-1", lines[0]["request_id"])
+	assert.Equal(t, `SELECT * FROM "products"`, lines[0]["sql"])
+	assert.Equal(t, "sql failed", lines[1]["msg"])
+	assert.Equal(t, "connection reset", lines[1]["error"])
+}
+
+func TestGormLogger_TraceDebug(t *testing.T) {
+	// 테스트 설정
+	var buffer bytes.Buffer
+	ctx := WithLogger(context.Background(), New(&buffer, slog.LevelDebug))
+
+	// 테스트 실행
+	NewGormLogger().Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 1", 1 }, nil)
+	NewGormLogger().LogMode(gormLogger.Silent).Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 2", 1 }, nil)
+
+	// 검증 - Silent로 바꾼 로거는 남기지 않습니다.
+	lines := decodeLines(t, &buffer)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "SELECT 1", lines[0]["sql"])
+}
@@ -0,0 +1,44 @@
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type loggerKey struct{}
+
+// Level은 LOG_LEVEL(debug, info, warn, error)이고 기본값은 info입니다. debug면 모든 SQL을 남깁니다.
+func Level() slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(os.Getenv("LOG_LEVEL")))); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+func New(w io.Writer, level slog.Leveler) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// Setup은 표준 출력에 JSON으로 쓰는 로거를 기본 로거로 정합니다. log 패키지로 쓰는 로그도 같은 형식으로 나갑니다.
+func Setup() *slog.Logger {
+	logger := New(os.Stdout, Level())
+	slog.SetDefault(logger)
+	return logger
+}
+
+// WithLogger는 요청 ID처럼 요청마다 붙일 속성을 가진 로거를 컨텍스트에 넣습니다.
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext는 컨텍스트의 로거이고, 없으면 기본 로거입니다. 컨트롤러와 저장소는 이 로거로 남겨야 요청 ID가 붙습니다.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/logging"
+	"Go-Gin-Basic-Template/tenancy"
+	"github.com/gin-gonic/gin"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+// AccessLog는 요청마다 한 줄의 JSON 로그를 남깁니다. 5xx는 error, 4xx는 warn입니다. RequestMeta 다음에 등록해야 요청 ID가 붙습니다.
+func AccessLog() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// 뒤의 미들웨어가 테넌트와 인증 주체를 넣은 컨텍스트로 바꿔두므로 끝난 뒤에 읽습니다.
+		ctx := c.Request.Context()
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Float64("duration_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if tenantID, ok := tenancy.FromContext(ctx); ok {
+			attrs = append(attrs, slog.String("tenant_id", tenantID))
+		}
+		if principal, ok := auth.FromContext(ctx); ok {
+			if principal.APIKeyID != "" {
+				attrs = append(attrs, slog.String("api_key_id", principal.APIKeyID))
+			} else {
+				attrs = append(attrs, slog.String("user_id", principal.UserID))
+			}
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		logging.FromContext(ctx).LogAttrs(ctx, level, "request", attrs...)
+	}
+}
+
+// Recovery는 패닉을 요청 ID가 붙은 로그로 남기고 500으로 응답합니다. gin.Recovery는 일반 텍스트로 쓰므로 대신 씁니다.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered interface{}) {
+		logging.FromContext(c.Request.Context()).Error("panic recovered",
+			slog.Any("panic", recovered),
+			slog.String("stack", string(debug.Stack())))
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
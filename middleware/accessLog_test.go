@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// 테스트 설정 함수
+func setupAccessLogTest() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestMeta(), AccessLog(), Recovery())
+	r.GET("/product/:id", func(c *gin.Context) { c.String(http.StatusNotFound, "missing") })
+	r.GET("/panic", func(c *gin.Context) { panic("boom") })
+	return r
+}
+
+func TestAccessLog(t *testing.T) {
+	// 테스트 설정
+	buffer := setupLogCapture(t)
+	r := setupAccessLogTest()
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/product/42", nil)
+	req.Header.Set(utils.RequestIDHeader, "req-1")
+	r.ServeHTTP(w, req)
+
+	// 검증
+	entry := lastLogLine(t, buffer)
+	assert.Equal(t, "request", entry["msg"])
+	assert.Equal(t, "WARN", entry["level"])
+	assert.Equal(t, "req-1", entry["request_id"])
+	assert.Equal(t, "/product/42", entry["path"])
+	assert.Equal(t, "/product/:id", entry["route"])
+	assert.Equal(t, float64(http.StatusNotFound), entry["status"])
+}
+
+func TestRecovery(t *testing.T) {
+	// 테스트 설정
+	buffer := setupLogCapture(t)
+	r := setupAccessLogTest()
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(utils.RequestIDHeader, "req-1")
+	r.ServeHTTP(w, req)
+
+	// 검증 - 패닉과 접근 로그 모두 요청 ID가 붙습니다.
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, buffer.String(), `"msg":"panic recovered","request_id":"req-1","panic":"boom"`)
+	entry := lastLogLine(t, buffer)
+	assert.Equal(t, "ERROR", entry["level"])
+	assert.Equal(t, "req-1", entry["request_id"])
+}
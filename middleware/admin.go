@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/utils"
+	"crypto/subtle"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"os"
+)
+
+const AdminTokenHeader = "X-Admin-Token"
+
+func AdminOnly() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		expected := os.Getenv("ADMIN_TOKEN")
+		given := c.GetHeader(AdminTokenHeader)
+		if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(given)) != 1 {
+			utils.RespondWithError(c, http.StatusForbidden, i18n.AdminRequired, errors.New("invalid admin token"))
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(tenancy.WithoutScope(c.Request.Context()))
+		c.Next()
+	}
+}
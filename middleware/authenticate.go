@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/utils"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+const (
+	schemeBearer = "Bearer"
+	schemeAPIKey = "ApiKey"
+)
+
+// Authenticate는 Authorization 헤더의 Bearer 토큰이나 ApiKey를 확인해서 요청 컨텍스트에 auth.Principal을 넣습니다.
+// 토큰의 테넌트 클레임은 TenantClaimKey로 넘기므로 Tenant보다 먼저 등록해야 합니다. OIDC가 이미 인증한 요청은 그대로 통과합니다.
+func Authenticate(authController *controller.AuthController, apiKeyController *controller.APIKeyController) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := auth.FromContext(c.Request.Context()); ok {
+			c.Next()
+			return
+		}
+
+		scheme, credentials, _ := strings.Cut(c.GetHeader("Authorization"), " ")
+		credentials = strings.TrimSpace(credentials)
+
+		var (
+			statusCode int
+			principal  *auth.Principal
+			err        error
+		)
+		switch {
+		case strings.EqualFold(scheme, schemeBearer) && credentials != "":
+			statusCode, principal, err = authController.Authenticate(c.Request.Context(), credentials)
+		case strings.EqualFold(scheme, schemeAPIKey) && credentials != "":
+			statusCode, principal, err = apiKeyController.Authenticate(c.Request.Context(), credentials)
+		default:
+			c.Header("WWW-Authenticate", schemeBearer+", "+schemeAPIKey)
+			utils.RespondWithError(c, http.StatusUnauthorized, i18n.AuthenticationRequired, errors.New("missing credentials"))
+			c.Abort()
+			return
+		}
+		if err != nil {
+			if statusCode == http.StatusUnauthorized {
+				c.Header("WWW-Authenticate", scheme+` error="invalid_token"`)
+			}
+			utils.RespondWithError(c, statusCode, i18n.AuthenticationFailed, err)
+			c.Abort()
+			return
+		}
+
+		c.Set(TenantClaimKey, principal.TenantID)
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
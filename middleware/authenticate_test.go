@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/repository"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// 테스트 설정 함수
+func setupAuthenticateTest(t *testing.T) (*gin.Engine, *auth.KeySet, sqlmock.Sqlmock) {
+	gin.SetMode(gin.TestMode)
+
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, PreferSimpleProtocol: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	keys, err := auth.NewKeySet("k1", map[string][]byte{"k1": bytes.Repeat([]byte("k"), 32)})
+	require.NoError(t, err)
+	authController := controller.NewAuthController(&repository.UserRepository{DB: db}, &repository.RevokedTokenRepository{DB: db}, keys)
+	apiKeyController := &controller.APIKeyController{APIKeyRepository: &repository.APIKeyRepository{DB: db}}
+
+	r := gin.New()
+	r.GET("/me", Authenticate(authController, apiKeyController), func(c *gin.Context) {
+		principal, ok := auth.FromContext(c.Request.Context())
+		if !ok {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"user": principal.UserID, "tenant": c.GetString(TenantClaimKey)})
+	})
+	return r, keys, mock
+}
+
+func signToken(t *testing.T, keys *auth.KeySet, tokenType string) string {
+	now := time.Now()
+	token, err := keys.Sign(&auth.Claims{
+		Subject:   "user-1",
+		ID:        "token-1",
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Minute).Unix(),
+		TokenType: tokenType,
+		TenantID:  "tenant-a",
+	})
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthenticate(t *testing.T) {
+	// 테스트 설정
+	r, keys, mock := setupAuthenticateTest(t)
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "revoked_tokens" WHERE id = $1`)).
+		WithArgs("token-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+signToken(t, keys, auth.TokenTypeAccess))
+	r.ServeHTTP(w, req)
+
+	// 검증
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"user":"user-1","tenant":"tenant-a"}`, w.Body.String())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthenticate_Rejected(t *testing.T) {
+	// 테스트 설정
+	r, keys, mock := setupAuthenticateTest(t)
+
+	tests := []struct {
+		name          string
+		authorization string
+		challenge     string
+	}{
+		{"missing", "", "Bearer, ApiKey"},
+		{"other scheme", "Basic dXNlcjpwYXNz", "Bearer, ApiKey"},
+		{"empty token", "Bearer ", "Bearer, ApiKey"},
+		{"malformed", "Bearer abc", `Bearer error="invalid_token"`},
+		{"malformed api key", "ApiKey abc", `ApiKey error="invalid_token"`},
+		{"refresh token", "Bearer " + signToken(t, keys, auth.TokenTypeRefresh), `Bearer error="invalid_token"`},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		if tt.authorization != "" {
+			req.Header.Set("Authorization", tt.authorization)
+		}
+		r.ServeHTTP(w, req)
+
+		// 검증 - DB를 조회하지 않습니다.
+		assert.Equal(t, http.StatusUnauthorized, w.Code, tt.name)
+		assert.Equal(t, tt.challenge, w.Header().Get("WWW-Authenticate"), tt.name)
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
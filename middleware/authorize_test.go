@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/repository"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// 테스트 설정 함수
+func setupAuthorizeTest(t *testing.T, principal *auth.Principal) (*gin.Engine, sqlmock.Sqlmock) {
+	gin.SetMode(gin.TestMode)
+
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, PreferSimpleProtocol: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	policies, err := auth.NewPolicyStore("")
+	require.NoError(t, err)
+	authorizer := &Authorizer{Policies: policies, AuditLogRepository: &repository.AuditLogRepository{DB: db}}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if principal != nil {
+			c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		}
+	})
+	r.DELETE("/product/:id", authorizer.Require(auth.PermissionProductDelete), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r, mock
+}
+
+func TestAuthorizer_Allowed(t *testing.T) {
+	// 테스트 설정
+	r, mock := setupAuthorizeTest(t, &auth.Principal{UserID: "user-1", Roles: []string{"manager"}})
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/product/1", nil))
+
+	// 검증 - 감사 기록을 남기지 않습니다.
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthorizer_DeniedIsAudited(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		actorType string
+		actorID   string
+	}{
+		{"user", &auth.Principal{UserID: "user-1", TenantID: "tenant-a", Roles: []string{"editor"}}, "user", "user-1"},
+		{"api key", &auth.Principal{APIKeyID: "key-1", TenantID: "tenant-a", Scopes: []string{auth.PermissionProductWrite}}, "api_key", "key-1"},
+	}
+
+	for _, tt := range tests {
+		// 테스트 설정
+		r, mock := setupAuthorizeTest(t, tt.principal)
+
+		// SQL 쿼리 모의 설정
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "audit_logs" ("id","tenant_id","actor_type","actor_id","action","permission","method","path","request_id","create_at")`)).
+			WithArgs(sqlmock.AnyArg(), "tenant-a", tt.actorType, tt.actorID, "access.denied", "product:delete", http.MethodDelete, "/product/:id", "", sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		// 테스트 실행
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/product/1", nil))
+
+		// 검증
+		assert.Equal(t, http.StatusForbidden, w.Code, tt.name)
+		assert.NoError(t, mock.ExpectationsWereMet(), tt.name)
+	}
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/utils"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	defaultIdempotencyTTL     = 24 * time.Hour
+	defaultIdempotencyMaxBody = 10 << 20
+	maxIdempotencyKeyLength   = 255
+)
+
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, record *types.IdempotencyRecord) (*types.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *types.IdempotencyRecord) error
+	Release(ctx context.Context, key string) error
+}
+
+// Idempotency는 Idempotency-Key 헤더가 있는 POST 요청의 응답을 저장해두고, TTL 안에 같은 키로 재시도하면 저장된 응답을 돌려줍니다.
+// Cache-Control: no-store 응답은 저장하지 않습니다. streamingRoutes("/product/import"처럼 gin 라우트 경로)는 본문을 버퍼링하지 않도록
+// 키가 있어도 그대로 통과시킵니다.
+func Idempotency(store IdempotencyStore, streamingRoutes ...string) gin.HandlerFunc {
+	ttl := idempotencyTTL()
+	maxBody := idempotencyMaxBody()
+	streaming := make(map[string]bool, len(streamingRoutes))
+	for _, route := range streamingRoutes {
+		streaming[route] = true
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" || streaming[c.FullPath()] {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidIdempotencyKey, errors.New("idempotency key is too long"))
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBody+1))
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, i18n.InvalidRequestPayload, err)
+			c.Abort()
+			return
+		}
+		if int64(len(body)) > maxBody {
+			utils.RespondWithError(c, http.StatusRequestEntityTooLarge, i18n.RequestTooLarge, errors.New("request body exceeds idempotency limit"))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		now := time.Now()
+		record := &types.IdempotencyRecord{
+			Key:         key,
+			Fingerprint: fingerprint(c.Request, body),
+			CreateAt:    now,
+			ExpireAt:    now.Add(ttl),
+		}
+
+		existing, err := store.Reserve(ctx, record)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusInternalServerError, i18n.SaveFailed, err)
+			c.Abort()
+			return
+		}
+		if existing != nil {
+			replay(c, existing, record.Fingerprint)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		completed := false
+		defer func() {
+			// 5xx나 패닉으로 끝난 요청은 키를 풀어서 재시도할 수 있게 합니다.
+			if !completed {
+				_ = store.Release(context.WithoutCancel(ctx), key)
+			}
+		}()
+
+		c.Next()
+
+		// 비밀을 담은 응답(utils.NoStore)은 저장하지 않고 키를 풀어둡니다. 같은 키로 재시도하면 다시 실행합니다.
+		if recorder.Status() >= http.StatusInternalServerError || noStore(recorder.Header()) {
+			return
+		}
+		record.StatusCode = recorder.Status()
+		record.ContentType = recorder.Header().Get("Content-Type")
+		record.Body = recorder.body.Bytes()
+		if err = store.Complete(context.WithoutCancel(ctx), record); err == nil {
+			completed = true
+		}
+	}
+}
+
+func replay(c *gin.Context, existing *types.IdempotencyRecord, fingerprint string) {
+	if existing.Fingerprint != fingerprint {
+		utils.RespondWithError(c, http.StatusUnprocessableEntity, i18n.IdempotencyKeyReused, errors.New("idempotency key reused with a different request"))
+		c.Abort()
+		return
+	}
+	if !existing.Completed {
+		utils.RespondWithError(c, http.StatusConflict, i18n.IdempotencyInProgress, errors.New("request with this idempotency key is in progress"))
+		c.Abort()
+		return
+	}
+
+	c.Header(IdempotencyReplayedHeader, "true")
+	c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+	c.Abort()
+}
+
+func noStore(header http.Header) bool {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+			return true
+		}
+	}
+	return false
+}
+
+func fingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(r.URL.RequestURI()))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func idempotencyTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_TTL"))
+	if err != nil || ttl <= 0 {
+		return defaultIdempotencyTTL
+	}
+	return ttl
+}
+
+func idempotencyMaxBody() int64 {
+	size, err := strconv.ParseInt(os.Getenv("IDEMPOTENCY_MAX_BODY"), 10, 64)
+	if err != nil || size <= 0 {
+		return defaultIdempotencyMaxBody
+	}
+	return size
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
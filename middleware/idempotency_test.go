@@ -0,0 +1,235 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/httpHandler"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// memoryIdempotencyStore는 테스트용 IdempotencyStore 구현체입니다.
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*types.IdempotencyRecord
+	// written은 Reserve와 Complete로 저장소에 쓴 기록을 모두 남깁니다.
+	written []types.IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: map[string]*types.IdempotencyRecord{}}
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, record *types.IdempotencyRecord) (*types.IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.written = append(s.written, *record)
+	if existing, ok := s.records[record.Key]; ok {
+		copied := *existing
+		return &copied, nil
+	}
+	copied := *record
+	s.records[record.Key] = &copied
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, record *types.IdempotencyRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.written = append(s.written, *record)
+	copied := *record
+	copied.Completed = true
+	s.records[record.Key] = &copied
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// 테스트 설정 함수
+func setupIdempotencyTest(status int) (*gin.Engine, *memoryIdempotencyStore, *int) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	store := newMemoryIdempotencyStore()
+	calls := 0
+
+	r.POST("/product", Idempotency(store), func(c *gin.Context) {
+		calls++
+		c.JSON(status, gin.H{"call": calls})
+	})
+
+	return r, store, &calls
+}
+
+func postWithKey(r *gin.Engine, key string, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, "/product", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(IdempotencyKeyHeader, key)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_ReplaysStoredResponse(t *testing.T) {
+	// 테스트 설정
+	r, _, calls := setupIdempotencyTest(http.StatusCreated)
+
+	// 테스트 실행
+	first := postWithKey(r, "key-1", `{"name":"상품"}`)
+	second := postWithKey(r, "key-1", `{"name":"상품"}`)
+
+	// 검증
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, "true", second.Header().Get(IdempotencyReplayedHeader))
+	assert.Empty(t, first.Header().Get(IdempotencyReplayedHeader))
+}
+
+func TestIdempotency_DifferentBody(t *testing.T) {
+	// 테스트 설정
+	r, _, calls := setupIdempotencyTest(http.StatusCreated)
+
+	// 테스트 실행
+	postWithKey(r, "key-1", `{"name":"상품"}`)
+	w := postWithKey(r, "key-1", `{"name":"다른 상품"}`)
+
+	// 검증
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	// 테스트 설정
+	r, store, calls := setupIdempotencyTest(http.StatusCreated)
+	req, _ := http.NewRequest(http.MethodPost, "/product", strings.NewReader(`{}`))
+	store.records["key-1"] = &types.IdempotencyRecord{Key: "key-1", Fingerprint: fingerprint(req, []byte(`{}`))}
+
+	// 테스트 실행
+	w := postWithKey(r, "key-1", `{}`)
+
+	// 검증
+	assert.Equal(t, 0, *calls)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestIdempotency_ReleasesKeyOnServerError(t *testing.T) {
+	// 테스트 설정
+	r, store, calls := setupIdempotencyTest(http.StatusInternalServerError)
+
+	// 테스트 실행
+	postWithKey(r, "key-1", `{}`)
+	postWithKey(r, "key-1", `{}`)
+
+	// 검증
+	assert.Equal(t, 2, *calls)
+	assert.Empty(t, store.records)
+}
+
+func TestIdempotency_WithoutKey(t *testing.T) {
+	// 테스트 설정
+	r, store, calls := setupIdempotencyTest(http.StatusCreated)
+
+	// 테스트 실행
+	postWithKey(r, "", `{}`)
+	postWithKey(r, "", `{}`)
+
+	// 검증
+	assert.Equal(t, 2, *calls)
+	assert.Empty(t, store.records)
+}
+
+func TestIdempotency_SkipsStreamingRoutes(t *testing.T) {
+	// 테스트 설정 - 가져오기 본문은 IDEMPOTENCY_MAX_BODY보다 커도 됩니다.
+	t.Setenv("IDEMPOTENCY_MAX_BODY", "16")
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	store := newMemoryIdempotencyStore()
+	var received []int
+	r.POST("/product/import", Idempotency(store, "/product/import"), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		received = append(received, len(body))
+		c.Status(http.StatusOK)
+	})
+	body := strings.Repeat("name,price\n", 100)
+
+	// 테스트 실행
+	var responses []*httptest.ResponseRecorder
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodPost, "/product/import", strings.NewReader(body))
+		req.Header.Set(IdempotencyKeyHeader, "key-1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		responses = append(responses, w)
+	}
+
+	// 검증 - 413 없이 본문을 핸들러가 그대로 읽고, 키를 저장하지 않습니다.
+	assert.Equal(t, http.StatusOK, responses[0].Code)
+	assert.Equal(t, http.StatusOK, responses[1].Code)
+	assert.Equal(t, []int{len(body), len(body)}, received)
+	assert.Empty(t, store.written)
+}
+
+func TestIdempotency_DoesNotStoreSecrets(t *testing.T) {
+	// 테스트 설정 - 원문 API 키를 돌려주는 실제 핸들러에 Idempotency-Key를 붙여 두 번 보냅니다.
+	gin.SetMode(gin.TestMode)
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer mockDB.Close()
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, PreferSimpleProtocol: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	store := newMemoryIdempotencyStore()
+	handler := &httpHandler.APIKeyHandler{APIKeyController: &controller.APIKeyController{
+		APIKeyRepository: &repository.APIKeyRepository{DB: db},
+		TenantRepository: &repository.TenantRepository{DB: db},
+	}}
+	r := gin.New()
+	r.POST("/product", Idempotency(store), handler.Insert)
+
+	// SQL 쿼리 모의 설정
+	for i := 0; i < 2; i++ {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "tenants" WHERE id = $1`)).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("tenant-a"))
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "api_keys"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+
+	// 테스트 실행
+	body := `{"name":"batch","tenantId":"tenant-a","scopes":["product:read"]}`
+	first := postWithKey(r, "key-1", body)
+	second := postWithKey(r, "key-1", body)
+
+	// 검증 - 키는 응답으로만 나가고, 재시도는 저장된 응답을 돌려주지 않고 다시 실행합니다.
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Contains(t, first.Body.String(), `"key":"gk_`)
+	assert.Equal(t, "no-store", first.Header().Get("Cache-Control"))
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Empty(t, second.Header().Get(IdempotencyReplayedHeader))
+	assert.NotEmpty(t, store.written)
+	for _, record := range store.written {
+		assert.NotContains(t, string(record.Body), "gk_")
+	}
+	assert.Empty(t, store.records)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
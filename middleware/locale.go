@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// Locale은 Accept-Language로 응답 언어를 정해서 요청 컨텍스트에 넣고 Content-Language 헤더로 알려줍니다.
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		locale := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		c.Request = c.Request.WithContext(i18n.WithLocale(c.Request.Context(), locale))
+		c.Header("Content-Language", locale)
+		c.Next()
+	}
+}
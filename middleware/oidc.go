@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// OIDC는 외부 IdP가 발급한 Bearer 토큰을 verifier로 확인합니다. iss가 verifier.Issuer인 토큰만 맡고
+// 나머지는 Authenticate에 넘기므로 Authenticate보다 먼저 등록해야 합니다. verifier가 nil이면 아무것도 하지 않습니다.
+func OIDC(verifier *auth.OIDCVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scheme, token, _ := strings.Cut(c.GetHeader("Authorization"), " ")
+		token = strings.TrimSpace(token)
+		if verifier == nil || !strings.EqualFold(scheme, schemeBearer) || auth.TokenIssuer(token) != verifier.Issuer {
+			c.Next()
+			return
+		}
+
+		principal, err := verifier.Verify(c.Request.Context(), token, time.Now())
+		if err != nil {
+			c.Header("WWW-Authenticate", schemeBearer+` error="invalid_token"`)
+			utils.RespondWithError(c, http.StatusUnauthorized, i18n.AuthenticationFailed, err)
+			c.Abort()
+			return
+		}
+
+		c.Set(TenantClaimKey, principal.TenantID)
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
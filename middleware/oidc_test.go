@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOIDCIssuer = "https://idp.example.com"
+
+// 테스트 설정 함수
+func setupOIDCTest(t *testing.T) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	// 이 서버의 키가 아닌 RS256 키 하나만 있는 JWKS입니다.
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	jwks := `{"keys":[{"kty":"RSA","kid":"idp-1","use":"sig","alg":"RS256","n":"sXchDaQebHnPiGvyDOAT4saGEUetSyo9MKLOoWFsueri23bOdgWp4Dy1WlUzewbgBHod5pcM9H95GQRV3JDXboIRROSBigeC5yjU1hGzHHyXss8UDprecbAYxknTcQkhslANGRUZmdTOQ5qTRsLAt6BTYuyvVRdhS8exSZEy_c4gs_7svlJJQ4H9_NxsiIoLwAEk7-Q3UXERGYw_75IDrGA84-lA_-Ct4eTlXHBIY2EaV7t7LjJaynVJCpkv4LKjTTAumiGUIuQhrNhZLuF_RJLqHpM2kgWFLU7-VTdL1VbC2tejvcI2BlMkEpk1BzBZI0KQB0GaDWFLN-aEAw3vRw","e":"AQAB"}]}`
+	require.NoError(t, os.WriteFile(path, []byte(jwks), 0o600))
+	verifier := &auth.OIDCVerifier{Keys: auth.NewJWKS(path, time.Hour), Issuer: testOIDCIssuer, Audience: "products-api"}
+
+	r := gin.New()
+	r.GET("/me", OIDC(verifier), func(c *gin.Context) {
+		_, authenticated := auth.FromContext(c.Request.Context())
+		c.JSON(http.StatusOK, gin.H{"authenticated": authenticated})
+	})
+	return r
+}
+
+func signIssuerToken(t *testing.T, issuer string) string {
+	keys, err := auth.NewKeySet("k1", map[string][]byte{"k1": bytes.Repeat([]byte("k"), 32)})
+	require.NoError(t, err)
+	token, err := keys.Sign(&auth.Claims{Issuer: issuer, Subject: "user-1", ExpiresAt: time.Now().Add(time.Minute).Unix()})
+	require.NoError(t, err)
+	return token
+}
+
+func TestOIDC_PassesOtherTokens(t *testing.T) {
+	// 테스트 설정
+	r := setupOIDCTest(t)
+
+	for _, authorization := range []string{"", "ApiKey gk_abc", "Bearer " + signIssuerToken(t, "local")} {
+		// 테스트 실행
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", authorization)
+		r.ServeHTTP(w, req)
+
+		// 검증 - 다른 발급자의 토큰은 Authenticate에 맡깁니다.
+		assert.Equal(t, http.StatusOK, w.Code, authorization)
+		assert.JSONEq(t, `{"authenticated":false}`, w.Body.String())
+	}
+}
+
+func TestOIDC_RejectsUnverifiedToken(t *testing.T) {
+	// 테스트 설정
+	r := setupOIDCTest(t)
+
+	// 테스트 실행 - iss는 IdP이지만 JWKS에 없는 키로 서명했습니다.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+signIssuerToken(t, testOIDCIssuer))
+	r.ServeHTTP(w, req)
+
+	// 검증
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, `Bearer error="invalid_token"`, w.Header().Get("WWW-Authenticate"))
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/logging"
+	"Go-Gin-Basic-Template/rateLimit"
+	"Go-Gin-Basic-Template/utils"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit은 클라이언트와 라우트마다 토큰 버킷으로 요청 수를 제한합니다. 클라이언트는 API 키, 사용자, IP 순서로 구분하므로
+// 인증된 사용자를 구분하려면 Authenticate 다음에 등록해야 합니다. 저장소 오류는 로그만 남기고 요청을 통과시킵니다.
+func RateLimit(store rateLimit.Store, rules *rateLimit.Rules) gin.HandlerFunc {
+	return rateLimitBy(store, rules, rateLimitClient)
+}
+
+// RateLimitByIP는 주체와 상관없이 IP마다 제한합니다. 잘못된 자격 증명도 세려면 OIDC와 Authenticate보다 먼저 등록합니다.
+// 버킷은 RateLimit과 따로 셉니다.
+func RateLimitByIP(store rateLimit.Store, rules *rateLimit.Rules) gin.HandlerFunc {
+	return rateLimitBy(store, rules, func(c *gin.Context) string {
+		return "preauth-ip:" + c.ClientIP()
+	})
+}
+
+func rateLimitBy(store rateLimit.Store, rules *rateLimit.Rules, client func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+		limit, ok := rules.For(c.Request.Method, c.FullPath())
+		if !ok {
+			c.Next()
+			return
+		}
+
+		result, err := store.Take(c.Request.Context(), route+" "+client(c), limit, time.Now())
+		if err != nil {
+			logging.FromContext(c.Request.Context()).Error("rate limit store failed", "route", route, "error", err)
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", ceilSeconds(result.Reset))
+		c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%s", limit.Requests, ceilSeconds(limit.Period)))
+		if !result.Allowed {
+			c.Header("Retry-After", ceilSeconds(result.RetryAfter))
+			utils.RespondWithError(c, http.StatusTooManyRequests, i18n.RateLimitExceeded, errors.New("rate limit exceeded"))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func rateLimitClient(c *gin.Context) string {
+	if principal, ok := auth.FromContext(c.Request.Context()); ok {
+		if principal.APIKeyID != "" {
+			return "key:" + principal.APIKeyID
+		}
+		return "user:" + principal.UserID
+	}
+	return "ip:" + c.ClientIP()
+}
+
+func ceilSeconds(duration time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(duration.Seconds())))
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/rateLimit"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingRateLimitStore는 저장소 장애를 흉내 냅니다.
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(ctx context.Context, key string, limit rateLimit.Limit, now time.Time) (rateLimit.Result, error) {
+	return rateLimit.Result{}, errors.New("connection refused")
+}
+
+// 테스트 설정 함수
+func setupRateLimitTest(t *testing.T, store rateLimit.Store) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	rules, err := rateLimit.ParseRules("GET /product=2/1m,GET /health=off")
+	require.NoError(t, err)
+
+	r := gin.New()
+	withPrincipal := func(c *gin.Context) {
+		if userID := c.GetHeader("X-Test-User"); userID != "" {
+			c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), &auth.Principal{UserID: userID}))
+		}
+	}
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/product", withPrincipal, RateLimit(store, rules), ok)
+	r.GET("/health", RateLimit(store, rules), ok)
+	return r
+}
+
+func getAs(r *gin.Engine, path string, userID string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if userID != "" {
+		req.Header.Set("X-Test-User", userID)
+	}
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRateLimit(t *testing.T) {
+	// 테스트 설정
+	r := setupRateLimitTest(t, rateLimit.NewMemoryStore())
+
+	// 테스트 실행
+	first := getAs(r, "/product", "user-1")
+	getAs(r, "/product", "user-1")
+	limited := getAs(r, "/product", "user-1")
+	other := getAs(r, "/product", "user-2")
+	anonymous := getAs(r, "/product", "")
+
+	// 검증 - 사용자마다 따로 셉니다.
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", first.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=60", first.Header().Get("RateLimit-Policy"))
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "0", limited.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", limited.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, other.Code)
+	assert.Equal(t, http.StatusOK, anonymous.Code)
+}
+
+func TestRateLimit_Unlimited(t *testing.T) {
+	// 테스트 설정
+	r := setupRateLimitTest(t, rateLimit.NewMemoryStore())
+
+	for i := 0; i < 5; i++ {
+		// 테스트 실행
+		w := getAs(r, "/health", "")
+
+		// 검증
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestRateLimit_StoreFailureAllows(t *testing.T) {
+	// 테스트 설정
+	r := setupRateLimitTest(t, failingRateLimitStore{})
+
+	// 테스트 실행
+	w := getAs(r, "/product", "user-1")
+
+	// 검증 - 저장소 장애로 API 전체를 막지 않습니다.
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
+
+func TestRateLimitByIP_BeforeAuthentication(t *testing.T) {
+	// 테스트 설정 - 자격 증명이 틀리면 401로 끊는 인증 앞에 등록합니다.
+	gin.SetMode(gin.TestMode)
+	rules, err := rateLimit.ParseRules("*=2/1m")
+	require.NoError(t, err)
+	store := rateLimit.NewMemoryStore()
+	r := gin.New()
+	rejectAll := func(c *gin.Context) { c.AbortWithStatus(http.StatusUnauthorized) }
+	r.GET("/product", RateLimitByIP(store, rules), rejectAll, RateLimit(store, rules))
+
+	// 테스트 실행
+	first := getAs(r, "/product", "")
+	getAs(r, "/product", "")
+	limited := getAs(r, "/product", "")
+
+	// 검증 - 인증에 실패한 요청도 IP마다 셉니다.
+	assert.Equal(t, http.StatusUnauthorized, first.Code)
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "30", limited.Header().Get("Retry-After"))
+}
+
+func TestRateLimitByIP_IgnoresPrincipal(t *testing.T) {
+	// 테스트 설정
+	r := gin.New()
+	rules, err := rateLimit.ParseRules("*=1/1m")
+	require.NoError(t, err)
+	withPrincipal := func(c *gin.Context) {
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), &auth.Principal{UserID: c.GetHeader("X-Test-User")}))
+	}
+	r.GET("/product", withPrincipal, RateLimitByIP(rateLimit.NewMemoryStore(), rules), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// 테스트 실행
+	first := getAs(r, "/product", "user-1")
+	other := getAs(r, "/product", "user-2")
+
+	// 검증 - 같은 IP면 사용자가 달라도 같이 셉니다.
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, http.StatusTooManyRequests, other.Code)
+}
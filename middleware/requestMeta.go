@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/logging"
+	"Go-Gin-Basic-Template/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"time"
+)
+
+const maxRequestIDLength = 128
+
+// RequestMeta는 응답 Envelope의 meta에 쓰는 요청 시작 시각과 요청 ID를 기록합니다. 다른 미들웨어보다 먼저 등록해야 합니다.
+// 요청의 X-Request-ID를 그대로 쓰고, 없거나 쓸 수 없는 값이면 새로 만듭니다. 요청 ID를 붙인 로거를 요청 컨텍스트에 넣습니다.
+func RequestMeta() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(utils.RequestStartKey, time.Now())
+
+		id := c.GetHeader(utils.RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Set(utils.RequestIDKey, id)
+		c.Header(utils.RequestIDHeader, id)
+
+		ctx := c.Request.Context()
+		c.Request = c.Request.WithContext(logging.WithLogger(ctx, logging.FromContext(ctx).With("request_id", id)))
+		c.Next()
+	}
+}
+
+// validRequestID는 로그와 헤더에 그대로 옮겨도 되는 값인지 확인합니다. 공백과 제어 문자가 없는 ASCII만 받습니다.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/logging"
+	"Go-Gin-Basic-Template/utils"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 테스트 설정 함수 - 기본 로거를 버퍼로 바꾸고 테스트가 끝나면 되돌립니다.
+func setupLogCapture(t *testing.T) *bytes.Buffer {
+	var buffer bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logging.New(&buffer, slog.LevelDebug))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &buffer
+}
+
+func lastLogLine(t *testing.T, buffer *bytes.Buffer) map[string]interface{} {
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	entry := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &entry))
+	return entry
+}
+
+func TestRequestMeta(t *testing.T) {
+	// 테스트 설정
+	gin.SetMode(gin.TestMode)
+	buffer := setupLogCapture(t)
+	r := gin.New()
+	r.GET("/ping", RequestMeta(), func(c *gin.Context) {
+		logging.FromContext(c.Request.Context()).Info("handled")
+		c.String(http.StatusOK, utils.RequestID(c))
+	})
+
+	tests := []struct {
+		name     string
+		header   string
+		accepted bool
+	}{
+		{"given", "req-1", true},
+		{"missing", "", false},
+		{"control characters", "req\n1", false},
+		{"too long", strings.Repeat("a", maxRequestIDLength+1), false},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		if tt.header != "" {
+			req.Header.Set(utils.RequestIDHeader, tt.header)
+		}
+		r.ServeHTTP(w, req)
+
+		// 검증 - 응답 헤더, meta, 로그가 같은 ID입니다.
+		id := w.Header().Get(utils.RequestIDHeader)
+		if tt.accepted {
+			assert.Equal(t, tt.header, id, tt.name)
+		} else {
+			_, err := uuid.Parse(id)
+			assert.NoError(t, err, tt.name)
+		}
+		assert.Equal(t, id, w.Body.String(), tt.name)
+		assert.Equal(t, id, lastLogLine(t, buffer)["request_id"], tt.name)
+	}
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/utils"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+const (
+	TenantHeader = "X-Tenant-ID"
+	// TenantClaimKey는 인증 미들웨어가 토큰의 테넌트 클레임을 넣어두는 gin 컨텍스트 키입니다.
+	TenantClaimKey = "tenantClaim"
+)
+
+func Tenant(tenantRepository *repository.TenantRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader(TenantHeader)
+		claim := c.GetString(TenantClaimKey)
+
+		tenantID := header
+		// 인증된 요청은 토큰의 테넌트만 씁니다. 토큰에 테넌트가 없으면 헤더로 테넌트를 고를 수 없습니다.
+		if _, authenticated := auth.FromContext(c.Request.Context()); authenticated {
+			if claim == "" {
+				utils.RespondWithError(c, http.StatusForbidden, i18n.TenantRequired, errors.New("token has no tenant claim"))
+				c.Abort()
+				return
+			}
+			if header != "" && header != claim {
+				utils.RespondWithError(c, http.StatusForbidden, i18n.TenantMismatch, errors.New("tenant header does not match token claim"))
+				c.Abort()
+				return
+			}
+			tenantID = claim
+		}
+		if tenantID == "" {
+			utils.RespondWithError(c, http.StatusBadRequest, i18n.TenantRequired, tenancy.ErrMissingTenant)
+			c.Abort()
+			return
+		}
+
+		exists, err := tenantRepository.Exists(c.Request.Context(), tenantID)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusInternalServerError, i18n.SelectFailed, err)
+			c.Abort()
+			return
+		}
+		if !exists {
+			utils.RespondWithError(c, http.StatusNotFound, i18n.TenantNotFound, errors.New("unknown tenant "+tenantID))
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(tenancy.WithTenant(c.Request.Context(), tenantID))
+		c.Next()
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"Go-Gin-Basic-Template/auth"
+	"Go-Gin-Basic-Template/repository"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// 테스트 설정 함수 - principal이 nil이 아니면 인증 미들웨어처럼 요청 주체와 테넌트 클레임을 넣습니다.
+func setupTenantTest(t *testing.T, principal *auth.Principal) (*gin.Engine, sqlmock.Sqlmock) {
+	gin.SetMode(gin.TestMode)
+
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, PreferSimpleProtocol: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	r := gin.New()
+	authenticated := func(c *gin.Context) {
+		if principal != nil {
+			c.Set(TenantClaimKey, principal.TenantID)
+			c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		}
+	}
+	r.GET("/product", authenticated, Tenant(&repository.TenantRepository{DB: db}), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r, mock
+}
+
+func TestTenant_IgnoresHeaderForPrincipalWithoutTenant(t *testing.T) {
+	// 테스트 설정 - 테넌트 클레임이 없는 토큰으로 인증된 요청입니다.
+	r, mock := setupTenantTest(t, &auth.Principal{UserID: "user-1"})
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/product", nil)
+	req.Header.Set(TenantHeader, "tenant-b")
+	r.ServeHTTP(w, req)
+
+	// 검증 - 헤더로 테넌트를 고를 수 없고 DB를 조회하지 않습니다.
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenant_RejectsOtherTenantHeader(t *testing.T) {
+	// 테스트 설정
+	r, mock := setupTenantTest(t, &auth.Principal{UserID: "user-1", TenantID: "tenant-a"})
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/product", nil)
+	req.Header.Set(TenantHeader, "tenant-b")
+	r.ServeHTTP(w, req)
+
+	// 검증
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
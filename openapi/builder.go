@@ -0,0 +1,181 @@
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	Version         = "3.0.3"
+	jsonContentType = "application/json"
+)
+
+var ginParameter = regexp.MustCompile(`[:*]([^/]+)`)
+
+// Endpoint는 라우트 하나의 문서입니다. 본문과 응답은 Go 값으로 넘기면 스키마로 바뀝니다.
+type Endpoint struct {
+	Summary     string
+	Description string
+	Tags        []string
+	// Parameters에 없는 경로 파라미터는 문자열로 추가됩니다.
+	Parameters []Parameter
+	// Query는 form 태그가 있는 구조체입니다. (예: requestTypes.ProductFilter{})
+	Query     interface{}
+	Request   *Body
+	Responses map[int]Body
+	// Security는 AddSecurityScheme으로 등록한 이름입니다.
+	Security []string
+}
+
+// Body의 Content는 콘텐츠 타입별 예시 값입니다. 값 대신 *Schema를 넣으면 그대로 씁니다.
+type Body struct {
+	Description string
+	Content     map[string]interface{}
+	// Exact면 AlsoProduces, AlsoConsumes를 적용하지 않습니다. 항상 JSON으로만 응답하는 라우트에 씁니다.
+	Exact bool
+}
+
+// JSON은 application/json 본문 하나만 있는 Body입니다.
+func JSON(description string, value interface{}) Body {
+	return Body{Description: description, Content: map[string]interface{}{jsonContentType: value}}
+}
+
+// Builder는 라우트를 하나씩 받아 Document를 만듭니다.
+type Builder struct {
+	document        *Document
+	schemas         *schemaGenerator
+	defaultResponse *Body
+	produces        []string
+	consumes        []string
+}
+
+func NewBuilder(info Info) *Builder {
+	schemas := newSchemaGenerator()
+	return &Builder{
+		document: &Document{
+			OpenAPI:    Version,
+			Info:       info,
+			Paths:      make(map[string]PathItem),
+			Components: Components{Schemas: schemas.components},
+		},
+		schemas: schemas,
+	}
+}
+
+// SetDefaultResponse는 모든 operation의 "default" 응답입니다. 오류 응답 모양이 하나뿐일 때 씁니다.
+func (b *Builder) SetDefaultResponse(body Body) {
+	b.defaultResponse = &body
+}
+
+// AlsoProduces와 AlsoConsumes는 application/json 본문을 같은 스키마의 다른 콘텐츠 타입으로도 적습니다.
+// 서버가 Accept와 Content-Type에 따라 같은 값을 다른 형식으로 주고받을 때 씁니다.
+func (b *Builder) AlsoProduces(contentTypes ...string) {
+	b.produces = append(b.produces, contentTypes...)
+}
+
+func (b *Builder) AlsoConsumes(contentTypes ...string) {
+	b.consumes = append(b.consumes, contentTypes...)
+}
+
+func (b *Builder) AddSecurityScheme(name string, scheme SecurityScheme) {
+	if b.document.Components.SecuritySchemes == nil {
+		b.document.Components.SecuritySchemes = make(map[string]SecurityScheme)
+	}
+	b.document.Components.SecuritySchemes[name] = scheme
+}
+
+// Add는 gin 경로(/product/:id)를 OpenAPI 경로(/product/{id})로 바꿔서 등록합니다.
+func (b *Builder) Add(method string, ginPath string, endpoint Endpoint) {
+	operation := &Operation{
+		Summary:     endpoint.Summary,
+		Description: endpoint.Description,
+		Tags:        endpoint.Tags,
+		Parameters:  b.parameters(ginPath, endpoint),
+		Responses:   make(map[string]Response),
+	}
+	if endpoint.Request != nil {
+		operation.RequestBody = &RequestBody{
+			Description: endpoint.Request.Description,
+			Required:    true,
+			Content:     b.content(*endpoint.Request, b.consumes),
+		}
+	}
+	for statusCode, body := range endpoint.Responses {
+		operation.Responses[strconv.Itoa(statusCode)] = b.response(statusCode, body)
+	}
+	if b.defaultResponse != nil {
+		operation.Responses["default"] = b.response(0, *b.defaultResponse)
+	}
+	for _, name := range endpoint.Security {
+		operation.Security = append(operation.Security, map[string][]string{name: {}})
+	}
+
+	path := Path(ginPath)
+	if b.document.Paths[path] == nil {
+		b.document.Paths[path] = make(PathItem)
+	}
+	b.document.Paths[path][strings.ToLower(method)] = operation
+}
+
+func (b *Builder) Document() *Document {
+	return b.document
+}
+
+// Path는 gin 경로의 :name과 *name을 {name}으로 바꿉니다.
+func Path(ginPath string) string {
+	return ginParameter.ReplaceAllString(ginPath, "{$1}")
+}
+
+func (b *Builder) parameters(ginPath string, endpoint Endpoint) []Parameter {
+	parameters := append([]Parameter(nil), endpoint.Parameters...)
+	for _, match := range ginParameter.FindAllStringSubmatch(ginPath, -1) {
+		if !hasParameter(parameters, match[1], "path") {
+			parameters = append(parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: String()})
+		}
+	}
+	if endpoint.Query != nil {
+		parameters = append(parameters, b.schemas.queryParameters(endpoint.Query)...)
+	}
+	return parameters
+}
+
+func hasParameter(parameters []Parameter, name string, in string) bool {
+	for _, parameter := range parameters {
+		if parameter.Name == name && parameter.In == in {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Builder) response(statusCode int, body Body) Response {
+	description := body.Description
+	if description == "" {
+		description = http.StatusText(statusCode)
+	}
+	return Response{Description: description, Content: b.content(body, b.produces)}
+}
+
+func (b *Builder) content(body Body, alternates []string) map[string]MediaType {
+	if len(body.Content) == 0 {
+		return nil
+	}
+	content := make(map[string]MediaType, len(body.Content))
+	for contentType, value := range body.Content {
+		schema, ok := value.(*Schema)
+		if !ok {
+			schema = b.schemas.valueSchema(value)
+		}
+		content[contentType] = MediaType{Schema: schema}
+	}
+	if json, ok := content[jsonContentType]; ok && !body.Exact {
+		for _, contentType := range alternates {
+			if _, exists := content[contentType]; !exists {
+				content[contentType] = json
+			}
+		}
+	}
+	return content
+}
@@ -0,0 +1,266 @@
+package openapi
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const componentPrefix = "#/components/schemas/"
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	uuidType       = reflect.TypeOf(uuid.UUID{})
+	deletedAtType  = reflect.TypeOf(gorm.DeletedAt{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	byteSliceType  = reflect.TypeOf([]byte{})
+)
+
+// schemaGenerator는 Go 값을 encoding/json이 만드는 모양 그대로 스키마로 바꿉니다.
+// 이름 있는 구조체는 components에 한 번만 등록하고 $ref로 참조합니다.
+type schemaGenerator struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		components: make(map[string]*Schema),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// valueSchema는 interface{} 필드에 들어 있는 값의 실제 타입까지 따라갑니다.
+// 래퍼에 Data: []types.Product{} 처럼 예시 값을 넣어 넘기면 Data의 스키마가 []types.Product가 됩니다.
+func (g *schemaGenerator) valueSchema(v interface{}) *Schema {
+	if v == nil {
+		return &Schema{}
+	}
+	value := reflect.ValueOf(v)
+	return g.schema(value.Type(), value)
+}
+
+func (g *schemaGenerator) schema(t reflect.Type, value reflect.Value) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case deletedAtType:
+		return &Schema{Type: "string", Format: "date-time", Nullable: true}
+	case rawMessageType:
+		return &Schema{}
+	case byteSliceType:
+		return &Schema{Type: "string", Format: "byte"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Boolean()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return String()
+	case reflect.Interface:
+		if value.IsValid() && !value.IsNil() {
+			return g.schema(value.Elem().Type(), value.Elem())
+		}
+		return &Schema{}
+	case reflect.Ptr:
+		var elem reflect.Value
+		if value.IsValid() && !value.IsNil() {
+			elem = value.Elem()
+		}
+		schema := g.schema(t.Elem(), elem)
+		if schema.Ref != "" {
+			return schema
+		}
+		nullable := *schema
+		nullable.Nullable = true
+		return &nullable
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem(), reflect.Value{})}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem(), reflect.Value{})}
+	case reflect.Struct:
+		return g.structSchema(t, value)
+	}
+	return &Schema{}
+}
+
+// structSchema는 이름 있는 구조체를 컴포넌트로 만듭니다.
+// interface{} 필드가 있는 구조체(응답 래퍼)나 제네릭 구조체는 값마다 모양이 달라서 그 자리에 펼칩니다.
+func (g *schemaGenerator) structSchema(t reflect.Type, value reflect.Value) *Schema {
+	if t.Name() == "" || strings.Contains(t.Name(), "[") || hasInterfaceField(t) {
+		return g.objectSchema(t, value)
+	}
+
+	if name, ok := g.names[t]; ok {
+		return &Schema{Ref: componentPrefix + name}
+	}
+	name := t.Name()
+	if _, taken := g.components[name]; taken {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	// 자기 자신을 참조하는 타입이 끝없이 펼쳐지지 않도록 먼저 이름을 등록합니다.
+	g.names[t] = name
+	g.components[name] = &Schema{}
+	*g.components[name] = *g.objectSchema(t, reflect.Value{})
+	return &Schema{Ref: componentPrefix + name}
+}
+
+func (g *schemaGenerator) objectSchema(t reflect.Type, value reflect.Value) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(schema, t, value)
+	return schema
+}
+
+// addFields는 encoding/json처럼 이름 없는 임베디드 구조체의 필드를 바깥 객체로 올립니다.
+func (g *schemaGenerator) addFields(schema *Schema, t reflect.Type, value reflect.Value) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, skip := jsonName(field)
+		if skip {
+			continue
+		}
+
+		var fieldValue reflect.Value
+		if value.IsValid() {
+			fieldValue = value.Field(i)
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(schema, field.Type, fieldValue)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = withConstraints(g.schema(field.Type, fieldValue), field)
+		if isRequired(field) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+func jsonName(field reflect.StructField) (name string, skip bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	return strings.Split(tag, ",")[0], false
+}
+
+// isRequired는 gin 바인딩 검증(binding:"required")을 따릅니다.
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// withConstraints는 binding 태그의 길이, 범위 규칙을 문자열과 숫자 스키마에 옮깁니다. 나머지 규칙은 문서에 나오지 않습니다.
+func withConstraints(schema *Schema, field reflect.StructField) *Schema {
+	if schema.Type != "string" && schema.Type != "number" && schema.Type != "integer" {
+		return schema
+	}
+	constrained := *schema
+	for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+		if rule == "dive" {
+			break
+		}
+		tag, param, _ := strings.Cut(rule, "=")
+		value, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			continue
+		}
+		if constrained.Type == "string" {
+			length := int(value)
+			switch tag {
+			case "min":
+				constrained.MinLength = &length
+			case "max":
+				constrained.MaxLength = &length
+			case "len":
+				constrained.MinLength, constrained.MaxLength = &length, &length
+			}
+			continue
+		}
+		switch tag {
+		case "min", "gte":
+			constrained.Minimum = &value
+		case "gt":
+			constrained.Minimum, constrained.ExclusiveMinimum = &value, true
+		case "max", "lte":
+			constrained.Maximum = &value
+		case "lt":
+			constrained.Maximum, constrained.ExclusiveMaximum = &value, true
+		}
+	}
+	return &constrained
+}
+
+func hasInterfaceField(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, skip := jsonName(field); skip {
+			continue
+		}
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Interface {
+			return true
+		}
+		if field.Anonymous && fieldType.Kind() == reflect.Struct && hasInterfaceField(fieldType) {
+			return true
+		}
+	}
+	return false
+}
+
+// queryParameters는 gin의 ShouldBindQuery가 읽는 form 태그로 쿼리 파라미터를 만듭니다.
+func (g *schemaGenerator) queryParameters(v interface{}) []Parameter {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var parameters []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+		// 포인터는 값이 없을 수 있다는 뜻일 뿐이라 쿼리에서는 nullable로 표시하지 않습니다.
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		parameters = append(parameters, Parameter{
+			Name:     name,
+			In:       "query",
+			Required: isRequired(field),
+			Schema:   g.schema(fieldType, reflect.Value{}),
+		})
+	}
+	return parameters
+}
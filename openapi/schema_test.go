@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBase struct {
+	ID       uuid.UUID
+	CreateAt time.Time
+}
+
+type testItem struct {
+	testBase
+	Name   string   `json:"name" binding:"required"`
+	Price  *float64 `json:"price,omitempty"`
+	Secret string   `json:"-"`
+	Parent *testItem
+}
+
+type testEnvelope struct {
+	Status int         `json:"status"`
+	Data   interface{} `json:"data"`
+}
+
+func TestSchemaGenerator_NamedStructBecomesComponent(t *testing.T) {
+	// 테스트 설정
+	g := newSchemaGenerator()
+
+	// 테스트 실행
+	schema := g.valueSchema(testItem{})
+
+	// 검증 - 임베디드 구조체 필드는 펼치고, json:"-" 필드는 빠집니다.
+	assert.Equal(t, componentPrefix+"testItem", schema.Ref)
+	component := g.components["testItem"]
+	require.NotNil(t, component)
+	assert.ElementsMatch(t, []string{"ID", "CreateAt", "name", "price", "Parent"}, keys(component.Properties))
+	assert.Equal(t, &Schema{Type: "string", Format: "uuid"}, component.Properties["ID"])
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, component.Properties["CreateAt"])
+	assert.Equal(t, &Schema{Type: "number", Format: "double", Nullable: true}, component.Properties["price"])
+	assert.Equal(t, &Schema{Ref: componentPrefix + "testItem"}, component.Properties["Parent"])
+	assert.Equal(t, []string{"name"}, component.Required)
+}
+
+func TestSchemaGenerator_InterfaceFieldFollowsValue(t *testing.T) {
+	// 테스트 설정
+	g := newSchemaGenerator()
+
+	// 테스트 실행
+	schema := g.valueSchema(testEnvelope{Data: []testItem{}})
+
+	// 검증 - 래퍼는 값마다 모양이 달라서 컴포넌트로 만들지 않습니다.
+	assert.Empty(t, schema.Ref)
+	assert.NotContains(t, g.components, "testEnvelope")
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: componentPrefix + "testItem"}}, schema.Properties["data"])
+	assert.Equal(t, &Schema{Type: "integer", Format: "int32"}, schema.Properties["status"])
+}
+
+func TestBuilder_AddConvertsPathAndParameters(t *testing.T) {
+	// 테스트 설정
+	b := NewBuilder(Info{Title: "test", Version: "1"})
+	b.SetDefaultResponse(JSON("오류", testEnvelope{}))
+
+	// 테스트 실행
+	b.Add("GET", "/items/:id/children/:child", Endpoint{
+		Parameters: []Parameter{{Name: "child", In: "path", Required: true, Schema: Integer()}},
+		Query: struct {
+			Name     string   `form:"name"`
+			MinPrice *float64 `form:"min_price"`
+		}{},
+		Responses: map[int]Body{200: JSON("", testItem{})},
+	})
+	document := b.Document()
+
+	// 검증
+	operation := document.Paths["/items/{id}/children/{child}"]["get"]
+	require.NotNil(t, operation)
+	assert.Equal(t, []Parameter{
+		{Name: "child", In: "path", Required: true, Schema: Integer()},
+		{Name: "id", In: "path", Required: true, Schema: String()},
+		{Name: "name", In: "query", Schema: String()},
+		{Name: "min_price", In: "query", Schema: &Schema{Type: "number", Format: "double"}},
+	}, operation.Parameters)
+	assert.Equal(t, "OK", operation.Responses["200"].Description)
+	assert.Contains(t, operation.Responses, "default")
+	assert.Contains(t, document.Components.Schemas, "testItem")
+}
+
+func keys(m map[string]*Schema) []string {
+	var out []string
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
+
+func TestSchemaGenerator_BindingConstraints(t *testing.T) {
+	// 테스트 설정
+	g := newSchemaGenerator()
+	type constrained struct {
+		Name  string   `json:"name" binding:"required,max=100"`
+		Code  string   `json:"code" binding:"len=3"`
+		Price float64  `json:"price" binding:"gt=0"`
+		Cost  *float64 `json:"cost" binding:"omitempty,gte=0,lt=1000"`
+		Tags  []string `json:"tags" binding:"max=5,dive,max=10"`
+	}
+
+	// 테스트 실행
+	schema := g.valueSchema(constrained{})
+
+	// 검증
+	properties := g.components["constrained"].Properties
+	require.NotEmpty(t, schema.Ref)
+	hundred, three, zero, thousand := 100, 3, 0.0, 1000.0
+	assert.Equal(t, &Schema{Type: "string", MaxLength: &hundred}, properties["name"])
+	assert.Equal(t, &Schema{Type: "string", MinLength: &three, MaxLength: &three}, properties["code"])
+	assert.Equal(t, &Schema{Type: "number", Format: "double", Minimum: &zero, ExclusiveMinimum: true}, properties["price"])
+	assert.Equal(t, &Schema{Type: "number", Format: "double", Nullable: true, Minimum: &zero, Maximum: &thousand, ExclusiveMaximum: true}, properties["cost"])
+	assert.Equal(t, &Schema{Type: "array", Items: String()}, properties["tags"])
+}
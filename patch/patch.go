@@ -0,0 +1,309 @@
+package patch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MIMEMergePatch는 RFC 7396 JSON Merge Patch입니다.
+	MIMEMergePatch = "application/merge-patch+json"
+	// MIMEJSONPatch는 RFC 6902 JSON Patch입니다.
+	MIMEJSONPatch = "application/json-patch+json"
+)
+
+var (
+	// ErrInvalidPatch는 patch 문서 자체가 잘못된 경우입니다. (400)
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrPathNotFound는 patch는 올바르지만 대상 문서에 경로가 없는 경우입니다. (422)
+	ErrPathNotFound = errors.New("path not found")
+	// ErrTestFailed는 test 연산의 값이 현재 문서와 다른 경우입니다. (409)
+	ErrTestFailed = errors.New("test operation failed")
+)
+
+// Operation은 RFC 6902 연산 하나입니다. Value는 null과 빠진 값을 구분하려고 원래 JSON 그대로 둡니다.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// Merge는 document에 RFC 7396 merge patch를 적용합니다. patch의 null은 그 필드를 지웁니다.
+func Merge(document []byte, patch []byte) ([]byte, error) {
+	var target, changes interface{}
+	if err := json.Unmarshal(document, &target); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(mergeValue(target, changes))
+}
+
+func mergeValue(target interface{}, patch interface{}) interface{} {
+	changes, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]interface{})
+	if !ok {
+		object = map[string]interface{}{}
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(object, key)
+			continue
+		}
+		object[key] = mergeValue(object[key], value)
+	}
+	return object
+}
+
+// Apply는 document에 RFC 6902 연산을 순서대로 적용합니다. 하나라도 실패하면 아무것도 적용하지 않은 것으로 봅니다.
+func Apply(document []byte, patch []byte) ([]byte, error) {
+	var operations []Operation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	var target interface{}
+	if err := json.Unmarshal(document, &target); err != nil {
+		return nil, err
+	}
+
+	for i, operation := range operations {
+		var err error
+		if target, err = operation.apply(target); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, operation.Op, operation.Path, err)
+		}
+	}
+	return json.Marshal(target)
+}
+
+func (o Operation) apply(target interface{}) (interface{}, error) {
+	path, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch o.Op {
+	case "add", "replace", "test":
+		value, err := o.value()
+		if err != nil {
+			return nil, err
+		}
+		switch o.Op {
+		case "add":
+			return add(target, path, value)
+		case "replace":
+			if target, _, err = remove(target, path); err != nil {
+				return nil, err
+			}
+			return add(target, path, value)
+		default:
+			current, err := get(target, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrTestFailed
+			}
+			return target, nil
+		}
+	case "remove":
+		target, _, err = remove(target, path)
+		return target, err
+	case "move", "copy":
+		from, err := parsePointer(o.From)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if o.Op == "move" {
+			if len(path) > len(from) && reflect.DeepEqual(path[:len(from)], from) {
+				return nil, fmt.Errorf("%w: cannot move %q into its own child", ErrInvalidPatch, o.From)
+			}
+			target, value, err = remove(target, from)
+		} else {
+			value, err = get(target, from)
+			value = clone(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return add(target, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown op %q", ErrInvalidPatch, o.Op)
+	}
+}
+
+func (o Operation) value() (interface{}, error) {
+	if len(o.Value) == 0 {
+		return nil, fmt.Errorf("%w: %q requires a value", ErrInvalidPatch, o.Op)
+	}
+	var value interface{}
+	if err := json.Unmarshal(o.Value, &value); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return value, nil
+}
+
+// parsePointer는 RFC 6901 JSON Pointer를 토큰으로 나눕니다. 빈 문자열은 문서 전체입니다.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: pointer %q must start with /", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func get(target interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := target.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+			}
+			target = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node)-1)
+			if err != nil {
+				return nil, err
+			}
+			target = node[index]
+		default:
+			return nil, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+		}
+	}
+	return target, nil
+}
+
+// add는 path의 부모를 찾아 값을 넣습니다. 배열이면 그 자리에 끼워 넣고 "-"는 맨 뒤입니다.
+func add(target interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(target, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[token] = value
+		return target, nil
+	case []interface{}:
+		index := len(node)
+		if token != "-" {
+			if index, err = arrayIndex(token, len(node)); err != nil {
+				return nil, err
+			}
+		}
+		node = append(node, nil)
+		copy(node[index+1:], node[index:])
+		node[index] = value
+		return set(target, path[:len(path)-1], node)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+	}
+}
+
+// remove는 path의 값을 지우고 지운 값을 돌려줍니다.
+func remove(target interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, target, nil
+	}
+	parent, err := get(target, path[:len(path)-1])
+	if err != nil {
+		return nil, nil, err
+	}
+	token := path[len(path)-1]
+
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		value, ok := node[token]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+		}
+		delete(node, token)
+		return target, value, nil
+	case []interface{}:
+		index, err := arrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := node[index]
+		node = append(node[:index:index], node[index+1:]...)
+		target, err = set(target, path[:len(path)-1], node)
+		return target, value, err
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrPathNotFound, token)
+	}
+}
+
+// set은 길이가 바뀐 배열을 부모에 다시 넣습니다.
+func set(target interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	parent, err := get(target, path[:len(path)-1])
+	if err != nil {
+		return nil, err
+	}
+	token := path[len(path)-1]
+	switch node := parent.(type) {
+	case map[string]interface{}:
+		node[token] = value
+	case []interface{}:
+		index, err := arrayIndex(token, len(node)-1)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = value
+	}
+	return target, nil
+}
+
+// arrayIndex는 0부터 max까지의 배열 인덱스를 읽습니다. 앞에 0을 붙인 값은 RFC 6901에서 허용하지 않습니다.
+func arrayIndex(token string, max int) (int, error) {
+	if token == "-" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%w: index %q", ErrPathNotFound, token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > max {
+		return 0, fmt.Errorf("%w: index %q", ErrPathNotFound, token)
+	}
+	return index, nil
+}
+
+// clone은 copy 연산이 같은 map, slice를 두 곳에서 공유하지 않도록 값을 복사합니다.
+func clone(value interface{}) interface{} {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(node))
+		for key, child := range node {
+			copied[key] = clone(child)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(node))
+		for i, child := range node {
+			copied[i] = clone(child)
+		}
+		return copied
+	default:
+		return value
+	}
+}
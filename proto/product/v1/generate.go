@@ -0,0 +1,4 @@
+package productv1
+
+// product.pb.go와 product_grpc.pb.go는 product.proto에서 만듭니다. protoc, protoc-gen-go, protoc-gen-go-grpc가 PATH에 있어야 합니다.
+//go:generate protoc --proto_path=../../.. --go_out=../../.. --go_opt=paths=source_relative --go-grpc_out=../../.. --go-grpc_opt=paths=source_relative ../../../proto/product/v1/product.proto
@@ -0,0 +1,60 @@
+package rateLimit
+
+import (
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"math"
+	"time"
+)
+
+// Limit은 Period 동안 Requests번까지 허용하는 토큰 버킷입니다. 버킷 크기가 Requests이므로 그만큼은 한꺼번에 보낼 수 있고,
+// 토큰은 Period/Requests마다 하나씩 다시 찹니다.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Result는 토큰을 하나 꺼낸 결과입니다. Reset은 버킷이 다시 가득 찰 때까지, RetryAfter는 거절되었을 때 다음 토큰이 찰 때까지의 시간입니다.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Store는 버킷을 보관합니다. 여러 인스턴스가 한도를 나누려면 Postgres에 보관하는 repository.RateLimitRepository를 씁니다.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// Take는 now까지 찬 토큰을 더한 뒤 하나를 꺼내고 bucket을 갱신합니다. 처음 보는 버킷(TakenAt이 0)은 가득 찬 상태입니다.
+func Take(bucket *types.RateLimitBucket, limit Limit, now time.Time) Result {
+	capacity := float64(limit.Requests)
+	perSecond := capacity / limit.Period.Seconds()
+
+	tokens := capacity
+	if !bucket.TakenAt.IsZero() {
+		elapsed := math.Max(now.Sub(bucket.TakenAt).Seconds(), 0)
+		tokens = math.Min(capacity, bucket.Tokens+elapsed*perSecond)
+	}
+
+	result := Result{Limit: limit.Requests}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / perSecond)
+	}
+	result.Remaining = int(tokens)
+	result.Reset = seconds((capacity - tokens) / perSecond)
+
+	bucket.Tokens = tokens
+	bucket.TakenAt = now
+	bucket.FullAt = now.Add(result.Reset)
+	return result
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
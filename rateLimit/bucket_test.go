@@ -0,0 +1,80 @@
+package rateLimit
+
+import (
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTake(t *testing.T) {
+	// 테스트 설정
+	limit := Limit{Requests: 3, Period: 3 * time.Second}
+	bucket := &types.RateLimitBucket{}
+	now := time.Now()
+
+	// 테스트 실행 - 버킷 크기만큼 한꺼번에 보낸 뒤 한 번 더 보냅니다.
+	var results []Result
+	for i := 0; i < 4; i++ {
+		results = append(results, Take(bucket, limit, now))
+	}
+	refilled := Take(bucket, limit, now.Add(time.Second))
+
+	// 검증
+	assert.True(t, results[0].Allowed)
+	assert.Equal(t, 2, results[0].Remaining)
+	assert.Equal(t, time.Second, results[0].Reset)
+	assert.True(t, results[2].Allowed)
+	assert.Equal(t, 0, results[2].Remaining)
+	assert.False(t, results[3].Allowed)
+	assert.Equal(t, time.Second, results[3].RetryAfter)
+	assert.Equal(t, 3*time.Second, results[3].Reset)
+	assert.True(t, refilled.Allowed, "1초에 토큰 하나가 찹니다")
+	assert.Equal(t, now.Add(time.Second+3*time.Second), bucket.FullAt)
+}
+
+func TestMemoryStore_Take(t *testing.T) {
+	// 테스트 설정
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Period: time.Minute}
+	now := time.Now()
+
+	// 테스트 실행
+	first, err := store.Take(context.Background(), "a", limit, now)
+	require.NoError(t, err)
+	second, _ := store.Take(context.Background(), "a", limit, now)
+	other, _ := store.Take(context.Background(), "b", limit, now)
+	later, _ := store.Take(context.Background(), "c", limit, now.Add(2*time.Minute))
+
+	// 검증 - 키마다 따로 세고, 가득 찬 버킷은 지워도 결과가 같습니다.
+	assert.True(t, first.Allowed)
+	assert.False(t, second.Allowed)
+	assert.True(t, other.Allowed)
+	assert.True(t, later.Allowed)
+	assert.NotContains(t, store.buckets, "a")
+}
+
+func TestParseRules(t *testing.T) {
+	// 테스트 설정
+	rules, err := ParseRules("GET /product=100/1m, /auth/login = 5/1m, GET /product/events=off, *=600/1m")
+	require.NoError(t, err)
+
+	// 테스트 실행 및 검증
+	limit, ok := rules.For("GET", "/product")
+	assert.True(t, ok)
+	assert.Equal(t, Limit{Requests: 100, Period: time.Minute}, limit)
+	limit, _ = rules.For("POST", "/auth/login")
+	assert.Equal(t, Limit{Requests: 5, Period: time.Minute}, limit)
+	limit, _ = rules.For("POST", "/product")
+	assert.Equal(t, Limit{Requests: 600, Period: time.Minute}, limit)
+	_, ok = rules.For("GET", "/product/events")
+	assert.False(t, ok)
+
+	for _, spec := range []string{"GET /product", "GET /product=0/1m", "GET /product=10/soon", "=10/1m"} {
+		_, err = ParseRules(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,47 @@
+package rateLimit
+
+import (
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+// MemoryStore는 프로세스 안에 버킷을 보관합니다. 인스턴스마다 따로 세므로 복제본이 N개면 실제 한도는 N배입니다.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*types.RateLimitBucket
+	sweptAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*types.RateLimitBucket{}}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.sweptAt) >= sweepInterval {
+		s.sweep(now)
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &types.RateLimitBucket{Key: key}
+		s.buckets[key] = bucket
+	}
+	return Take(bucket, limit, now), nil
+}
+
+// sweep은 가득 찬 버킷을 지웁니다. 없는 버킷은 가득 찬 것으로 보므로 결과는 달라지지 않습니다.
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, bucket := range s.buckets {
+		if !bucket.FullAt.After(now) {
+			delete(s.buckets, key)
+		}
+	}
+	s.sweptAt = now
+}
@@ -0,0 +1,110 @@
+package rateLimit
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultRules는 RATE_LIMITS가 없을 때 모든 라우트에 적용하는 한도입니다.
+	defaultRules = "*=600/1m"
+	// defaultIPRules는 RATE_LIMITS_IP가 없을 때 인증 전에 IP마다 적용하는 한도입니다.
+	defaultIPRules = "*=1200/1m"
+	wildcard       = "*"
+	unlimited      = "off"
+
+	StoreMemory   = "memory"
+	StorePostgres = "postgres"
+)
+
+// Rules는 라우트별 한도입니다. "GET /product/:id"처럼 메서드와 gin 라우트 경로로 찾고,
+// 없으면 메서드 없는 경로, 그다음 "*" 순서로 찾습니다.
+type Rules struct {
+	limits map[string]*Limit
+}
+
+// LoadRules는 RATE_LIMITS를 읽습니다. 예: "GET /product=100/1m,POST /auth/login=10/1m,*=600/1m"
+func LoadRules() (*Rules, error) {
+	spec := os.Getenv("RATE_LIMITS")
+	if spec == "" {
+		spec = defaultRules
+	}
+	return ParseRules(spec)
+}
+
+// LoadIPRules는 인증하기 전에 IP마다 적용하는 RATE_LIMITS_IP를 읽습니다. 형식은 RATE_LIMITS와 같습니다.
+// 잘못된 자격 증명을 계속 보내는 클라이언트도 이 한도에 걸립니다.
+func LoadIPRules() (*Rules, error) {
+	spec := os.Getenv("RATE_LIMITS_IP")
+	if spec == "" {
+		spec = defaultIPRules
+	}
+	return ParseRules(spec)
+}
+
+// ParseRules는 쉼표로 구분한 "라우트=요청 수/기간" 목록을 읽습니다. 한도 대신 "off"를 쓰면 그 라우트는 제한하지 않습니다.
+func ParseRules(spec string) (*Rules, error) {
+	rules := &Rules{limits: map[string]*Limit{}}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		route, value, ok := strings.Cut(entry, "=")
+		route = strings.Join(strings.Fields(route), " ")
+		if !ok || route == "" {
+			return nil, fmt.Errorf("invalid rate limit %q: expected route=requests/period", entry)
+		}
+
+		if strings.TrimSpace(value) == unlimited {
+			rules.limits[route] = nil
+			continue
+		}
+		limit, err := parseLimit(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit %q: %w", entry, err)
+		}
+		rules.limits[route] = limit
+	}
+	return rules, nil
+}
+
+func parseLimit(value string) (*Limit, error) {
+	requests, period, ok := strings.Cut(strings.TrimSpace(value), "/")
+	if !ok {
+		return nil, fmt.Errorf("expected requests/period")
+	}
+	count, err := strconv.Atoi(requests)
+	if err != nil || count <= 0 {
+		return nil, fmt.Errorf("requests must be a positive number")
+	}
+	duration, err := time.ParseDuration(period)
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("period must be a positive duration")
+	}
+	return &Limit{Requests: count, Period: duration}, nil
+}
+
+// For는 라우트의 한도입니다. 제한하지 않는 라우트면 false입니다.
+func (r *Rules) For(method string, path string) (Limit, bool) {
+	for _, route := range []string{method + " " + path, path, wildcard} {
+		if limit, ok := r.limits[route]; ok {
+			if limit == nil {
+				return Limit{}, false
+			}
+			return *limit, true
+		}
+	}
+	return Limit{}, false
+}
+
+// StoreKind는 RATE_LIMIT_STORE(memory 또는 postgres)이고 기본값은 memory입니다.
+func StoreKind() string {
+	if kind := os.Getenv("RATE_LIMIT_STORE"); kind != "" {
+		return kind
+	}
+	return StoreMemory
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// lastUsedInterval보다 자주 쓰인 키는 last_used_at을 매번 갱신하지 않습니다.
+const lastUsedInterval = time.Minute
+
+// APIKeyRepository는 관리자 API와 인증에서만 쓰므로 테넌트 범위를 쓰지 않습니다.
+type APIKeyRepository struct {
+	DB *gorm.DB
+}
+
+func (r *APIKeyRepository) Insert(ctx context.Context, input *requestTypes.APIKeyRequest, prefix string, hash string) (dbRecord *types.APIKey, err error) {
+	dbRecord = &types.APIKey{
+		ID:        uuid.New(),
+		TenantID:  input.TenantID,
+		Name:      input.Name,
+		Prefix:    prefix,
+		Hash:      hash,
+		Scopes:    input.Scopes,
+		ExpiresAt: input.ExpiresAt,
+		CreateAt:  time.Now(),
+	}
+
+	if err = r.DB.WithContext(tenancy.WithoutScope(ctx)).Create(dbRecord).Error; err != nil {
+		return nil, err
+	}
+
+	return dbRecord, nil
+}
+
+func (r *APIKeyRepository) GetAll(ctx context.Context, filter *requestTypes.APIKeyFilter) (apiKeys *[]types.APIKey, err error) {
+	query := r.DB.WithContext(tenancy.WithoutScope(ctx))
+	if filter.TenantID != "" {
+		query = query.Where("tenant_id = ?", filter.TenantID)
+	}
+	if err = query.Order("create_at").Find(&apiKeys).Error; err != nil {
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+func (r *APIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (dbRecord *types.APIKey, err error) {
+	dbRecord = &types.APIKey{}
+	if err = r.DB.WithContext(tenancy.WithoutScope(ctx)).Where("prefix = ?", prefix).First(dbRecord).Error; err != nil {
+		return nil, notFound(err, ErrAPIKeyNotFound)
+	}
+
+	return dbRecord, nil
+}
+
+// Rotate는 폐기되지 않은 키의 원문을 바꿉니다. 이전 키는 바로 쓸 수 없게 됩니다.
+func (r *APIKeyRepository) Rotate(ctx context.Context, id string, prefix string, hash string) (dbRecord *types.APIKey, err error) {
+	dbRecord = &types.APIKey{}
+	err = r.DB.WithContext(tenancy.WithoutScope(ctx)).Transaction(func(tx *gorm.DB) error {
+		if err = tx.Where("id = ? AND revoked_at IS NULL", id).First(dbRecord).Error; err != nil {
+			return err
+		}
+
+		dbRecord.Prefix = prefix
+		dbRecord.Hash = hash
+		dbRecord.UpdateAt = time.Now()
+		return tx.Save(dbRecord).Error
+	})
+	if err != nil {
+		return nil, notFound(err, ErrAPIKeyNotFound)
+	}
+
+	return dbRecord, nil
+}
+
+func (r *APIKeyRepository) Revoke(ctx context.Context, id string) error {
+	now := time.Now()
+	result := r.DB.WithContext(tenancy.WithoutScope(ctx)).Model(&types.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{"revoked_at": now, "update_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed는 마지막 사용 시각을 기록합니다. 같은 키로 요청이 몰려도 lastUsedInterval에 한 번만 씁니다.
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, now time.Time) error {
+	return r.DB.WithContext(tenancy.WithoutScope(ctx)).Model(&types.APIKey{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, now.Add(-lastUsedInterval)).
+		Update("last_used_at", now).Error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// AuditLogRepository는 TenantID를 호출하는 쪽에서 채웁니다. 테넌트가 정해지기 전의 요청도 기록하기 때문입니다.
+type AuditLogRepository struct {
+	DB *gorm.DB
+}
+
+func (r *AuditLogRepository) Insert(ctx context.Context, entry *types.AuditLog) error {
+	entry.ID = uuid.New()
+	entry.CreateAt = time.Now()
+	return r.DB.WithContext(tenancy.WithoutScope(ctx)).Create(entry).Error
+}
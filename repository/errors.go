@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/domainErrors"
+	"errors"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// uniqueViolation은 Postgres의 unique_violation SQLSTATE입니다.
+const uniqueViolation = "23505"
+
+var (
+	ErrProductNotFound  = domainErrors.NotFound("product.not_found", "상품을 찾을 수 없습니다")
+	ErrRevisionNotFound = domainErrors.NotFound("product.revision_not_found", "리비전을 찾을 수 없습니다")
+	ErrTenantExists     = domainErrors.Conflict("tenant.already_exists", "같은 ID의 테넌트가 이미 있습니다")
+	ErrUserExists       = domainErrors.Conflict("user.already_exists", "같은 이메일의 사용자가 이미 있습니다")
+	ErrUserNotFound     = domainErrors.NotFound("user.not_found", "사용자를 찾을 수 없습니다")
+	ErrAPIKeyNotFound   = domainErrors.NotFound("api_key.not_found", "API 키를 찾을 수 없습니다")
+	ErrWebhookNotFound  = domainErrors.NotFound("webhook.not_found", "웹훅 구독을 찾을 수 없습니다")
+)
+
+// notFound는 gorm.ErrRecordNotFound를 도메인 오류로 바꿉니다. 다른 오류는 그대로 돌려줍니다.
+func notFound(err error, domainError *domainErrors.Error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domainError.Wrap(err)
+	}
+	return err
+}
+
+// conflict는 unique 제약 위반을 도메인 오류로 바꿉니다. 다른 오류는 그대로 돌려줍니다.
+func conflict(err error, domainError *domainErrors.Error) error {
+	var pgError *pgconn.PgError
+	if errors.As(err, &pgError) && pgError.Code == uniqueViolation {
+		return domainError.Wrap(err)
+	}
+	return err
+}
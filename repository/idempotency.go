@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type IdempotencyRepository struct {
+	DB *gorm.DB
+}
+
+// Reserve는 키를 선점합니다. 이미 같은 키가 있으면 저장된 레코드를 돌려주고, 선점에 성공하면 nil을 돌려줍니다.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *types.IdempotencyRecord) (existing *types.IdempotencyRecord, err error) {
+	for attempt := 0; attempt < 2; attempt++ {
+		result := r.scoped(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil, nil
+		}
+
+		existing = &types.IdempotencyRecord{}
+		err = r.scoped(ctx).Where("key = ?", record.Key).First(existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if existing.ExpireAt.After(time.Now()) {
+			return existing, nil
+		}
+
+		if err = r.scoped(ctx).Where("key = ? AND expire_at <= ?", record.Key, time.Now()).Delete(&types.IdempotencyRecord{}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return existing, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *types.IdempotencyRecord) error {
+	return r.scoped(ctx).Model(&types.IdempotencyRecord{}).
+		Where("key = ?", record.Key).
+		Updates(map[string]interface{}{
+			"completed":    true,
+			"status_code":  record.StatusCode,
+			"content_type": record.ContentType,
+			"body":         record.Body,
+		}).Error
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, key string) error {
+	return r.scoped(ctx).Where("key = ?", key).Delete(&types.IdempotencyRecord{}).Error
+}
+
+func (r *IdempotencyRepository) PurgeExpired(ctx context.Context) (int64, error) {
+	result := r.DB.WithContext(tenancy.WithoutScope(ctx)).Where("expire_at <= ?", time.Now()).Delete(&types.IdempotencyRecord{})
+	return result.RowsAffected, result.Error
+}
+
+// scoped는 테넌트 범위를 벗어난 관리자 요청의 키가 테넌트 키와 섞이지 않도록 빈 테넌트로 고정합니다.
+func (r *IdempotencyRepository) scoped(ctx context.Context) *gorm.DB {
+	db := r.DB.WithContext(ctx)
+	if tenancy.Skipped(ctx) {
+		db = db.Where("tenant_id = ?", "")
+	}
+	return db
+}
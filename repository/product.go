@@ -1,77 +1,285 @@
 package repository
 
 import (
+	"Go-Gin-Basic-Template/encryption"
+	"Go-Gin-Basic-Template/tenancy"
 	"Go-Gin-Basic-Template/types"
 	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
 	"time"
 )
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type ProductRepository struct {
 	DB *gorm.DB
 }
 
-func (r *ProductRepository) Insert(input *requestTypes.ProductRequest) (err error) {
-	dbRecord := &types.Product{
+func (r *ProductRepository) Insert(ctx context.Context, input *requestTypes.ProductRequest) (dbRecord *types.Product, err error) {
+	dbRecord = &types.Product{
 		BasicModel: types.BasicModel{
 			ID:       uuid.New(),
 			CreateAt: time.Now(),
 		},
-		Name:  input.Name,
-		Price: input.Price,
+		Name:     input.Name,
+		Price:    input.Price,
+		Category: input.Category,
+		SKU:      input.SKU,
+
+		SupplierCost:  input.SupplierCost,
+		InternalNotes: input.InternalNotes,
 	}
 
-	if err = r.DB.Create(&dbRecord).Error; err != nil {
-		return err
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err = tx.Create(&dbRecord).Error; err != nil {
+			return err
+		}
+
+		return r.insertRevision(tx, dbRecord)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return dbRecord, nil
 }
 
-func (r *ProductRepository) Update(id string, input *requestTypes.ProductRequest) (err error) {
-	dbRecord := &types.Product{}
+func (r *ProductRepository) Update(ctx context.Context, id string, input *requestTypes.ProductRequest) (dbRecord *types.Product, err error) {
+	return r.update(ctx, id, func(dbRecord *types.Product) error {
+		applyProductRequest(dbRecord, input)
+		return nil
+	})
+}
 
-	if err = r.DB.Where("id = ?", id).First(dbRecord).Error; err != nil {
-		return err
+// Patch는 잠근 현재 상품을 ProductRequest로 patch에 넘기고, patch가 바꾼 값을 같은 트랜잭션에서 저장합니다.
+// patch가 오류를 돌려주면 저장하지 않습니다.
+func (r *ProductRepository) Patch(ctx context.Context, id string, patch func(input *requestTypes.ProductRequest) error) (dbRecord *types.Product, err error) {
+	return r.update(ctx, id, func(dbRecord *types.Product) error {
+		input := &requestTypes.ProductRequest{
+			Name:          dbRecord.Name,
+			Price:         dbRecord.Price,
+			Category:      dbRecord.Category,
+			SKU:           dbRecord.SKU,
+			SupplierCost:  dbRecord.SupplierCost,
+			InternalNotes: dbRecord.InternalNotes,
+		}
+		if err := patch(input); err != nil {
+			return err
+		}
+		applyProductRequest(dbRecord, input)
+		return nil
+	})
+}
+
+func (r *ProductRepository) update(ctx context.Context, id string, change func(dbRecord *types.Product) error) (dbRecord *types.Product, err error) {
+	dbRecord = &types.Product{}
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+
+		if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(dbRecord).Error; err != nil {
+			return notFound(err, ErrProductNotFound)
+		}
+
+		if err = change(dbRecord); err != nil {
+			return err
+		}
+		dbRecord.UpdateAt = time.Now()
+
+		if err = tx.Save(dbRecord).Error; err != nil {
+			return err
+		}
+
+		return r.insertRevision(tx, dbRecord)
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	return dbRecord, nil
+}
+
+func applyProductRequest(dbRecord *types.Product, input *requestTypes.ProductRequest) {
 	dbRecord.Name = input.Name
 	dbRecord.Price = input.Price
-	dbRecord.UpdateAt = time.Now()
+	dbRecord.Category = input.Category
+	dbRecord.SKU = input.SKU
+	dbRecord.SupplierCost = input.SupplierCost
+	dbRecord.InternalNotes = input.InternalNotes
+}
 
-	if err = r.DB.Save(dbRecord).Error; err != nil {
-		return err
+// Delete는 삭제된 상품을 RETURNING으로 돌려줍니다. 지울 상품이 없으면 ErrProductNotFound입니다.
+func (r *ProductRepository) Delete(ctx context.Context, id string) (dbRecord *types.Product, err error) {
+	dbRecord = &types.Product{}
+
+	if err = r.DB.WithContext(ctx).Clauses(clause.Returning{}).Where("id = ?", id).Delete(dbRecord).Error; err != nil {
+		return nil, err
+	}
+	if dbRecord.ID == uuid.Nil {
+		return nil, ErrProductNotFound
 	}
 
-	return nil
+	return dbRecord, nil
 }
 
-func (r *ProductRepository) Delete(id string) error {
-	dbRecord := &types.Product{}
+func (r *ProductRepository) GetAll(ctx context.Context, filter *requestTypes.ProductFilter) (product *[]types.Product, err error) {
+	if err = r.DB.WithContext(ctx).Scopes(productFilterScope(filter)).Find(&product).Error; err != nil {
+		return nil, err
+	}
 
-	if err := r.DB.Where("id = ?", id).Delete(dbRecord).Error; err != nil {
-		return err
+	return product, nil
+}
+
+func (r *ProductRepository) FindInBatches(ctx context.Context, filter *requestTypes.ProductFilter, batchSize int, fn func(products []types.Product) error) error {
+	var products []types.Product
+
+	return r.DB.WithContext(ctx).Scopes(productFilterScope(filter)).FindInBatches(&products, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(products)
+	}).Error
+}
+
+// GetByIDs는 여러 상품을 한 번의 쿼리로 읽습니다. 없는 ID는 결과에서 빠집니다.
+func (r *ProductRepository) GetByIDs(ctx context.Context, ids []string) (products []types.Product, err error) {
+	if err = r.DB.WithContext(ctx).Where("id IN ?", ids).Find(&products).Error; err != nil {
+		return nil, err
 	}
 
-	return nil
+	return products, nil
 }
 
-func (r *ProductRepository) GetAll() (product *[]types.Product, err error) {
-	if err = r.DB.Find(&product).Error; err != nil {
+// GetPage는 생성 순서대로 정렬한 한 페이지와 필터에 맞는 전체 개수를 돌려줍니다.
+func (r *ProductRepository) GetPage(ctx context.Context, filter *requestTypes.ProductFilter, limit int, offset int) (products []types.Product, total int64, err error) {
+	db := r.DB.WithContext(ctx).Model(&types.Product{}).Scopes(productFilterScope(filter))
+	if err = db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err = db.Order("create_at, id").Limit(limit).Offset(offset).Find(&products).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return products, total, nil
+}
+
+func (r *ProductRepository) GetRevisions(ctx context.Context, id string) (revisions *[]types.ProductRevision, err error) {
+	if err = r.DB.WithContext(ctx).Where("product_id = ?", id).Order("revision").Find(&revisions).Error; err != nil {
 		return nil, err
 	}
 
-	return product, nil
+	return revisions, nil
 }
 
-func (r *ProductRepository) GetByID(id string) (product *types.Product, err error) {
-	if err = r.DB.Where("id =?", id).Find(&product).Error; err != nil {
+func (r *ProductRepository) GetRevisionsByProductIDs(ctx context.Context, ids []uuid.UUID) (revisions []types.ProductRevision, err error) {
+	if err = r.DB.WithContext(ctx).Where("product_id IN ?", ids).Order("product_id, revision").Find(&revisions).Error; err != nil {
 		return nil, err
 	}
-	if !product.DeleteAt.Valid {
-		return nil, gorm.ErrRecordNotFound
+
+	return revisions, nil
+}
+
+func (r *ProductRepository) GetRevision(ctx context.Context, id string, revision int) (dbRecord *types.ProductRevision, err error) {
+	dbRecord = &types.ProductRevision{}
+	if err = r.DB.WithContext(ctx).Where("product_id = ? AND revision = ?", id, revision).First(dbRecord).Error; err != nil {
+		return nil, notFound(err, ErrRevisionNotFound)
 	}
 
-	return product, nil
+	return dbRecord, nil
+}
+
+func (r *ProductRepository) Revert(ctx context.Context, id string, revision int) (dbRecord *types.Product, err error) {
+	dbRecord = &types.Product{}
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(dbRecord).Error; err != nil {
+			return notFound(err, ErrProductNotFound)
+		}
+
+		target := &types.ProductRevision{}
+		if err = tx.Where("product_id = ? AND revision = ?", id, revision).First(target).Error; err != nil {
+			return notFound(err, ErrRevisionNotFound)
+		}
+
+		target.Snapshot.Apply(dbRecord)
+		dbRecord.UpdateAt = time.Now()
+
+		if err = tx.Save(dbRecord).Error; err != nil {
+			return err
+		}
+
+		return r.insertRevision(tx, dbRecord)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbRecord, nil
+}
+
+// Reencrypt는 활성 키가 아닌 키로 암호화된 민감 필드를 모든 테넌트에 걸쳐 활성 키로 다시 암호화합니다.
+// all이면 활성 키로 암호화된 값도 모두 다시 암호화합니다. 행의 기본 키를 묶지 않은 예전 암호문을 바꿀 때 씁니다.
+func (r *ProductRepository) Reencrypt(ctx context.Context, batchSize int, all bool) (updated int64, err error) {
+	keyRing := encryption.CurrentKeyRing()
+	if keyRing == nil {
+		return 0, encryption.ErrKeyRingNotConfigured
+	}
+
+	db := r.DB.WithContext(tenancy.WithoutScope(ctx)).Unscoped()
+	activePrefix := keyRing.ActiveKeyID() + ":%"
+	var products []types.Product
+
+	query := db.Where("supplier_cost NOT LIKE ? OR internal_notes NOT LIKE ?", activePrefix, activePrefix)
+	if all {
+		query = db.Where("supplier_cost IS NOT NULL OR internal_notes IS NOT NULL")
+	}
+	err = query.
+		FindInBatches(&products, batchSize, func(tx *gorm.DB, batch int) error {
+			for i := range products {
+				if err := db.Model(&products[i]).Select("SupplierCost", "InternalNotes").Updates(&products[i]).Error; err != nil {
+					return err
+				}
+				updated++
+			}
+			return nil
+		}).Error
+
+	return updated, err
+}
+
+func productFilterScope(filter *requestTypes.ProductFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter == nil {
+			return db
+		}
+		if filter.Name != "" {
+			db = db.Where("name ILIKE ?", "%"+likeEscaper.Replace(filter.Name)+"%")
+		}
+		if filter.MinPrice != nil {
+			db = db.Where("price >= ?", *filter.MinPrice)
+		}
+		if filter.MaxPrice != nil {
+			db = db.Where("price <= ?", *filter.MaxPrice)
+		}
+		return db
+	}
+}
+
+func (r *ProductRepository) insertRevision(tx *gorm.DB, product *types.Product) (err error) {
+	var latest int
+	if err = tx.Model(&types.ProductRevision{}).
+		Where("product_id = ?", product.ID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&latest).Error; err != nil {
+		return err
+	}
+
+	revision := &types.ProductRevision{
+		ID:        uuid.New(),
+		TenantID:  product.TenantID,
+		ProductID: product.ID,
+		Revision:  latest + 1,
+		Snapshot:  types.NewProductSnapshot(product),
+		CreateAt:  time.Now(),
+	}
+
+	return tx.Create(revision).Error
 }
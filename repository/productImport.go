@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+var errCopyUnsupported = errors.New("connection does not support COPY")
+
+func (r *ProductRepository) InsertBatch(ctx context.Context, inputs []requestTypes.ProductRequest) (products []types.Product, err error) {
+	now := time.Now()
+	products = make([]types.Product, 0, len(inputs))
+	revisions := make([]types.ProductRevision, 0, len(inputs))
+	for i := range inputs {
+		product := types.Product{
+			BasicModel: types.BasicModel{
+				ID:       uuid.New(),
+				CreateAt: now,
+			},
+			Name:     inputs[i].Name,
+			Price:    inputs[i].Price,
+			Category: inputs[i].Category,
+			SKU:      inputs[i].SKU,
+
+			SupplierCost:  inputs[i].SupplierCost,
+			InternalNotes: inputs[i].InternalNotes,
+		}
+		products = append(products, product)
+		revisions = append(revisions, types.ProductRevision{
+			ID:        uuid.New(),
+			ProductID: product.ID,
+			Revision:  1,
+			Snapshot:  types.NewProductSnapshot(&product),
+			CreateAt:  now,
+		})
+	}
+
+	db := r.DB.WithContext(ctx)
+	err = r.copyBatch(ctx, db, &products, &revisions)
+	if errors.Is(err, errCopyUnsupported) {
+		err = db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.CreateInBatches(&products, len(products)).Error; err != nil {
+				return err
+			}
+
+			return tx.CreateInBatches(&revisions, len(revisions)).Error
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+// copyBatch는 pgx 연결에서만 COPY FROM으로 적재하고, 그 외 드라이버에서는 errCopyUnsupported를 돌려줍니다.
+func (r *ProductRepository) copyBatch(ctx context.Context, db *gorm.DB, tables ...interface{}) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return errCopyUnsupported
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn interface{}) error {
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errCopyUnsupported
+		}
+
+		tx, err := pgxConn.Conn().Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+
+		for _, records := range tables {
+			table, values, err := r.copyValues(db, records)
+			if err != nil {
+				return err
+			}
+			if _, err = tx.CopyFrom(ctx, pgx.Identifier{table}, values.columns, pgx.CopyFromRows(values.rows)); err != nil {
+				return err
+			}
+		}
+
+		return tx.Commit(ctx)
+	})
+}
+
+type copyRows struct {
+	columns []string
+	rows    [][]interface{}
+}
+
+// copyValues는 DryRun으로 INSERT 문을 만들어서 GORM 콜백(테넌트 지정 등)이 적용된 컬럼과 값을 그대로 가져옵니다.
+func (r *ProductRepository) copyValues(db *gorm.DB, records interface{}) (string, *copyRows, error) {
+	stmt := db.Session(&gorm.Session{DryRun: true, SkipDefaultTransaction: true}).Create(records).Statement
+	if stmt.Error != nil {
+		return "", nil, stmt.Error
+	}
+
+	values, ok := stmt.Clauses["VALUES"].Expression.(clause.Values)
+	if !ok {
+		return "", nil, errors.New("failed to build COPY values for " + stmt.Table)
+	}
+
+	columns := make([]string, 0, len(values.Columns))
+	for _, column := range values.Columns {
+		columns = append(columns, column.Name)
+	}
+
+	return stmt.Table, &copyRows{columns: columns, rows: values.Values}, nil
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"Go-Gin-Basic-Template/domainErrors"
 	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
 	"database/sql"
 	"regexp"
 	"testing"
@@ -34,6 +36,23 @@ func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, *gorm.DB, error) {
 	return mockDB, mock, db, err
 }
 
+// expectRevisionInsert는 상품 저장 후 기록되는 리비전 쿼리를 설정합니다.
+func expectRevisionInsert(mock sqlmock.Sqlmock, revision int) {
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(revision), 0) FROM "product_revisions" WHERE product_id = $1`)).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(revision - 1))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "product_revisions"`)).
+		WithArgs(
+			sqlmock.AnyArg(), // ID
+			sqlmock.AnyArg(), // TenantID
+			sqlmock.AnyArg(), // ProductID
+			revision,
+			sqlmock.AnyArg(), // Snapshot
+			sqlmock.AnyArg(), // CreateAt
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
 func TestProductRepository_Insert(t *testing.T) {
 	// 테스트 설정
 	mockDB, mock, db, err := setupMockDB(t)
@@ -44,8 +63,9 @@ func TestProductRepository_Insert(t *testing.T) {
 
 	// 테스트 데이터
 	productReq := &requestTypes.ProductRequest{
-		Name:  "테스트 상품",
-		Price: 10000.0,
+		Name:     "테스트 상품",
+		Price:    10000.0,
+		Category: "식품",
 	}
 
 	// SQL 쿼리 모의 설정
@@ -53,20 +73,27 @@ func TestProductRepository_Insert(t *testing.T) {
 	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).
 		WithArgs(
 			sqlmock.AnyArg(), // ID
+			sqlmock.AnyArg(), // TenantID
 			sqlmock.AnyArg(), // CreateAt
 			sqlmock.AnyArg(), // UpdateAt
 			sqlmock.AnyArg(), // DeleteAt
 			productReq.Name,
 			productReq.Price,
+			productReq.Category,
+			"",  // SKU
+			nil, // SupplierCost
+			nil, // InternalNotes
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectRevisionInsert(mock, 1)
 	mock.ExpectCommit()
 
 	// 테스트 실행
-	err = repo.Insert(productReq)
+	product, err := repo.Insert(context.Background(), productReq)
 
 	// 검증
 	assert.NoError(t, err)
+	assert.Equal(t, productReq.Name, product.Name)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -82,34 +109,43 @@ func TestProductRepository_Update(t *testing.T) {
 	testUUID := uuid.New()
 	testIDStr := testUUID.String()
 	productReq := &requestTypes.ProductRequest{
-		Name:  "업데이트된 상품",
-		Price: 15000.0,
+		Name:     "업데이트된 상품",
+		Price:    15000.0,
+		Category: "생활",
 	}
 
 	// SQL 쿼리 모의 설정
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1 AND "products"."delete_at" IS NULL ORDER BY "products"."id" LIMIT $2`)).
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1 AND "products"."delete_at" IS NULL ORDER BY "products"."id" LIMIT $2 FOR UPDATE`)).
 		WithArgs(testIDStr, 1).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "create_at", "update_at", "delete_at", "name", "price"}).
-			AddRow(testUUID, time.Now(), time.Now(), nil, "원래 상품", 10000.0))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "create_at", "update_at", "delete_at", "name", "price"}).
+			AddRow(testUUID, "tenant-a", time.Now(), time.Now(), nil, "원래 상품", 10000.0))
 
-	mock.ExpectBegin()
 	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET`)).
 		WithArgs(
-			sqlmock.AnyArg(), // CreateAt
-			sqlmock.AnyArg(), // UpdateAt
-			sqlmock.AnyArg(), // DeleteAt
-			productReq.Name,  // Name
-			productReq.Price, // Price
-			sqlmock.AnyArg(), // WHERE 조건의 ID
+			sqlmock.AnyArg(),    // TenantID
+			sqlmock.AnyArg(),    // CreateAt
+			sqlmock.AnyArg(),    // UpdateAt
+			sqlmock.AnyArg(),    // DeleteAt
+			productReq.Name,     // Name
+			productReq.Price,    // Price
+			productReq.Category, // Category
+			"",                  // SKU
+			nil,                 // SupplierCost
+			nil,                 // InternalNotes
+			sqlmock.AnyArg(),    // WHERE 조건의 ID
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectRevisionInsert(mock, 2)
 	mock.ExpectCommit()
 
 	// 테스트 실행
-	err = repo.Update(testIDStr, productReq)
+	product, err := repo.Update(context.Background(), testIDStr, productReq)
 
 	// 검증
 	assert.NoError(t, err)
+	assert.Equal(t, testUUID, product.ID)
+	assert.Equal(t, productReq.Category, product.Category)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -122,20 +158,24 @@ func TestProductRepository_Delete(t *testing.T) {
 	repo := &ProductRepository{DB: db}
 
 	// 테스트 데이터
-	testIDStr := uuid.New().String()
+	testUUID := uuid.New()
+	testIDStr := testUUID.String()
 
 	// SQL 쿼리 모의 설정
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "delete_at"=$1 WHERE id = $2 AND "products"."delete_at" IS NULL`)).
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE "products" SET "delete_at"=$1 WHERE id = $2 AND "products"."delete_at" IS NULL RETURNING *`)).
 		WithArgs(sqlmock.AnyArg(), testIDStr).
-		WillReturnResult(sqlmock.NewResult(1, 1))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name", "category"}).
+			AddRow(testUUID, "tenant-a", "삭제된 상품", "식품"))
 	mock.ExpectCommit()
 
 	// 테스트 실행
-	err = repo.Delete(testIDStr)
+	product, err := repo.Delete(context.Background(), testIDStr)
 
 	// 검증
 	assert.NoError(t, err)
+	assert.Equal(t, testUUID, product.ID)
+	assert.Equal(t, "식품", product.Category)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -154,12 +194,12 @@ func TestProductRepository_GetAll(t *testing.T) {
 
 	// SQL 쿼리 모의 설정
 	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE "products"."delete_at" IS NULL`)).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "create_at", "update_at", "delete_at", "name", "price"}).
-			AddRow(testUUID1, testTime, testTime, nil, "상품1", 10000.0).
-			AddRow(testUUID2, testTime, testTime, nil, "상품2", 20000.0))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "create_at", "update_at", "delete_at", "name", "price"}).
+			AddRow(testUUID1, "tenant-a", testTime, testTime, nil, "상품1", 10000.0).
+			AddRow(testUUID2, "tenant-a", testTime, testTime, nil, "상품2", 20000.0))
 
 	// 테스트 실행
-	products, err := repo.GetAll()
+	products, err := repo.GetAll(context.Background(), nil)
 
 	// 검증
 	assert.NoError(t, err)
@@ -168,7 +208,7 @@ func TestProductRepository_GetAll(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestProductRepository_GetByID(t *testing.T) {
+func TestProductRepository_Revert(t *testing.T) {
 	// 테스트 설정
 	mockDB, mock, db, err := setupMockDB(t)
 	require.NoError(t, err)
@@ -179,49 +219,68 @@ func TestProductRepository_GetByID(t *testing.T) {
 	// 테스트 데이터
 	testUUID := uuid.New()
 	testIDStr := testUUID.String()
-	testTime := time.Now()
-	deletedAt := sql.NullTime{Time: time.Now(), Valid: true}
 
 	// SQL 쿼리 모의 설정
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id =$1 AND "products"."delete_at" IS NULL`)).
-		WithArgs(testIDStr).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "create_at", "update_at", "delete_at", "name", "price"}).
-			AddRow(testUUID, testTime, testTime, deletedAt, "테스트 상품", 10000.0))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1 AND "products"."delete_at" IS NULL ORDER BY "products"."id" LIMIT $2 FOR UPDATE`)).
+		WithArgs(testIDStr, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "create_at", "update_at", "delete_at", "name", "price"}).
+			AddRow(testUUID, "tenant-a", time.Now(), time.Now(), nil, "잘못 바뀐 상품", 1.0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "product_revisions" WHERE product_id = $1 AND revision = $2 ORDER BY "product_revisions"."id" LIMIT $3`)).
+		WithArgs(testIDStr, 1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "product_id", "revision", "snapshot", "create_at"}).
+			AddRow(uuid.New(), "tenant-a", testUUID, 1, `{"name":"원래 상품","price":10000,"category":"식품"}`, time.Now()))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET`)).
+		WithArgs(
+			sqlmock.AnyArg(), // TenantID
+			sqlmock.AnyArg(), // CreateAt
+			sqlmock.AnyArg(), // UpdateAt
+			sqlmock.AnyArg(), // DeleteAt
+			"원래 상품",          // Name
+			10000.0,          // Price
+			"식품",             // Category
+			"",               // SKU
+			nil,              // SupplierCost
+			nil,              // InternalNotes
+			sqlmock.AnyArg(), // WHERE 조건의 ID
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectRevisionInsert(mock, 3)
+	mock.ExpectCommit()
 
 	// 테스트 실행
-	product, err := repo.GetByID(testIDStr)
+	product, err := repo.Revert(context.Background(), testIDStr, 1)
 
 	// 검증
 	assert.NoError(t, err)
-	assert.NotNil(t, product)
-	assert.Equal(t, testUUID, product.ID)
-	assert.Equal(t, "테스트 상품", product.Name)
-	assert.Equal(t, 10000.0, product.Price)
+	assert.Equal(t, "원래 상품", product.Name)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestProductRepository_GetByID_NotFound(t *testing.T) {
+func TestProductRepository_Update_NotFound(t *testing.T) {
 	// 테스트 설정
 	mockDB, mock, db, err := setupMockDB(t)
 	require.NoError(t, err)
 	defer mockDB.Close()
 
 	repo := &ProductRepository{DB: db}
-
-	// 테스트 데이터
 	testIDStr := uuid.New().String()
 
 	// SQL 쿼리 모의 설정
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id =$1 AND "products"."delete_at" IS NULL`)).
-		WithArgs(testIDStr).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "create_at", "update_at", "delete_at", "name", "price"}))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE id = $1`)).
+		WithArgs(testIDStr, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
 
 	// 테스트 실행
-	product, err := repo.GetByID(testIDStr)
+	product, err := repo.Update(context.Background(), testIDStr, &requestTypes.ProductRequest{Name: "상품"})
 
-	// 검증
-	assert.Error(t, err)
-	assert.Equal(t, gorm.ErrRecordNotFound, err)
+	// 검증 - 도메인 오류로 바뀌어도 원인 오류는 errors.Is로 찾을 수 있습니다.
 	assert.Nil(t, product)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	domainError, ok := domainErrors.As(err)
+	require.True(t, ok)
+	assert.Equal(t, ErrProductNotFound.Code, domainError.Code)
 	assert.NoError(t, mock.ExpectationsWereMet())
-} 
\ No newline at end of file
+}
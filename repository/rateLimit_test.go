@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/rateLimit"
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitRepository_Take(t *testing.T) {
+	// 테스트 설정
+	mockDB, mock, db, err := setupMockDB(t)
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	repo := &RateLimitRepository{DB: db}
+	now := time.Now()
+	limit := rateLimit.Limit{Requests: 10, Period: time.Minute}
+
+	// SQL 쿼리 모의 설정 - 버킷 행을 잠그고 읽은 뒤 갱신합니다. 20초 전에 비었으므로 세 개가 찼습니다.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "rate_limit_buckets" WHERE key = $1 ORDER BY "rate_limit_buckets"."key" LIMIT $2 FOR UPDATE`)).
+		WithArgs("GET /product ip:10.0.0.1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "tokens", "taken_at", "full_at"}).
+			AddRow("GET /product ip:10.0.0.1", 0.0, now.Add(-20*time.Second), now.Add(40*time.Second)))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "rate_limit_buckets" ("key","tokens","taken_at","full_at") VALUES ($1,$2,$3,$4) ON CONFLICT ("key") DO UPDATE SET "tokens"="excluded"."tokens","taken_at"="excluded"."taken_at","full_at"="excluded"."full_at"`)).
+		WithArgs("GET /product ip:10.0.0.1", sqlmock.AnyArg(), now, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	result, err := repo.Take(context.Background(), "GET /product ip:10.0.0.1", limit, now)
+
+	// 검증
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, result.Remaining)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/database"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"gorm.io/gorm"
+)
+
+type ReportRepository struct {
+	DB *gorm.DB
+}
+
+func (r *ReportRepository) ProductSummary(ctx context.Context, materialized bool) (summary *types.ProductReportSummary, err error) {
+	summary = &types.ProductReportSummary{}
+
+	var db *gorm.DB
+	if materialized {
+		if db, err = r.view(ctx, database.ProductSummaryView); err != nil {
+			return nil, err
+		}
+		db = db.Select("count, min_price, max_price, avg_price, median_price")
+	} else {
+		db = r.products(ctx).Select("COUNT(*) AS count, MIN(price) AS min_price, MAX(price) AS max_price, " +
+			"AVG(price) AS avg_price, percentile_cont(0.5) WITHIN GROUP (ORDER BY price) AS median_price")
+	}
+
+	if err = db.Scan(summary).Error; err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func (r *ReportRepository) ProductsByCategory(ctx context.Context, materialized bool) (counts []types.ProductCategoryCount, err error) {
+	var db *gorm.DB
+	if materialized {
+		if db, err = r.view(ctx, database.ProductCategoryView); err != nil {
+			return nil, err
+		}
+		db = db.Select("category, count")
+	} else {
+		db = r.products(ctx).Select("category, COUNT(*) AS count").Group("category")
+	}
+
+	counts = []types.ProductCategoryCount{}
+	if err = db.Order("count DESC, category").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *ReportRepository) ProductsByPeriod(ctx context.Context, interval string, materialized bool) (counts []types.ProductPeriodCount, err error) {
+	var db *gorm.DB
+	if materialized {
+		if db, err = r.view(ctx, database.ProductPeriodView); err != nil {
+			return nil, err
+		}
+		db = db.Select("period, count").Where("interval = ?", interval)
+	} else {
+		db = r.products(ctx).Select("date_trunc(?, create_at) AS period, COUNT(*) AS count", interval).Group("period")
+	}
+
+	counts = []types.ProductPeriodCount{}
+	if err = db.Order("period").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *ReportRepository) RefreshViews(ctx context.Context) error {
+	for _, view := range []string{database.ProductSummaryView, database.ProductCategoryView, database.ProductPeriodView} {
+		if err := r.DB.WithContext(ctx).Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY " + view).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// products는 모델을 통해 조회하므로 테넌트 콜백과 soft delete 조건이 자동으로 붙습니다.
+func (r *ReportRepository) products(ctx context.Context) *gorm.DB {
+	return r.DB.WithContext(ctx).Model(&types.Product{})
+}
+
+// view는 모델이 없는 머티리얼라이즈드 뷰라서 테넌트 조건을 직접 붙입니다.
+func (r *ReportRepository) view(ctx context.Context, name string) (*gorm.DB, error) {
+	tenantID, ok := tenancy.FromContext(ctx)
+	if !ok {
+		return nil, tenancy.ErrMissingTenant
+	}
+	return r.DB.WithContext(ctx).Table(name).Where("tenant_id = ?", tenantID), nil
+}
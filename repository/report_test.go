@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/tenancy"
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportRepository_ProductSummary_Live(t *testing.T) {
+	// 테스트 설정
+	mockDB, mock, db, err := setupMockDB(t)
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	repo := &ReportRepository{DB: db}
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) AS count, MIN(price) AS min_price, MAX(price) AS max_price, AVG(price) AS avg_price, percentile_cont(0.5) WITHIN GROUP (ORDER BY price) AS median_price FROM "products" WHERE "products"."delete_at" IS NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "min_price", "max_price", "avg_price", "median_price"}).
+			AddRow(3, 1000.0, 3000.0, 2000.0, 2000.0))
+
+	// 테스트 실행
+	summary, err := repo.ProductSummary(context.Background(), false)
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), summary.Count)
+	assert.Equal(t, 1000.0, *summary.MinPrice)
+	assert.Equal(t, 2000.0, *summary.MedianPrice)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepository_ProductSummary_Materialized(t *testing.T) {
+	// 테스트 설정
+	mockDB, mock, db, err := setupMockDB(t)
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	repo := &ReportRepository{DB: db}
+	ctx := tenancy.WithTenant(context.Background(), "tenant-a")
+
+	// SQL 쿼리 모의 설정 - 뷰에는 테넌트 조건을 직접 붙여야 합니다.
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count, min_price, max_price, avg_price, median_price FROM "product_report_summary_mv" WHERE tenant_id = $1`)).
+		WithArgs("tenant-a").
+		WillReturnRows(sqlmock.NewRows([]string{"count", "min_price", "max_price", "avg_price", "median_price"}))
+
+	// 테스트 실행
+	summary, err := repo.ProductSummary(ctx, true)
+
+	// 검증 - 상품이 없는 테넌트는 0건으로 돌려줍니다.
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), summary.Count)
+	assert.Nil(t, summary.MinPrice)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepository_ProductSummary_MaterializedWithoutTenant(t *testing.T) {
+	// 테스트 설정
+	mockDB, mock, db, err := setupMockDB(t)
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	repo := &ReportRepository{DB: db}
+
+	// 테스트 실행
+	summary, err := repo.ProductSummary(context.Background(), true)
+
+	// 검증
+	assert.ErrorIs(t, err, tenancy.ErrMissingTenant)
+	assert.Nil(t, summary)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepository_ProductsByCategory(t *testing.T) {
+	// 테스트 설정
+	mockDB, mock, db, err := setupMockDB(t)
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	repo := &ReportRepository{DB: db}
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT category, COUNT(*) AS count FROM "products" WHERE "products"."delete_at" IS NULL GROUP BY "category" ORDER BY count DESC, category`)).
+		WillReturnRows(sqlmock.NewRows([]string{"category", "count"}).
+			AddRow("식품", 2).
+			AddRow("생활", 1))
+
+	// 테스트 실행
+	counts, err := repo.ProductsByCategory(context.Background(), false)
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Len(t, counts, 2)
+	assert.Equal(t, "식품", counts[0].Category)
+	assert.Equal(t, int64(2), counts[0].Count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReportRepository_ProductsByPeriod(t *testing.T) {
+	// 테스트 설정
+	mockDB, mock, db, err := setupMockDB(t)
+	require.NoError(t, err)
+	defer mockDB.Close()
+
+	repo := &ReportRepository{DB: db}
+	testTime := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT date_trunc($1, create_at) AS period, COUNT(*) AS count FROM "products" WHERE "products"."delete_at" IS NULL GROUP BY "period" ORDER BY period`)).
+		WithArgs("week").
+		WillReturnRows(sqlmock.NewRows([]string{"period", "count"}).AddRow(testTime, 4))
+
+	// 테스트 실행
+	counts, err := repo.ProductsByPeriod(context.Background(), "week", false)
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Len(t, counts, 1)
+	assert.Equal(t, testTime, counts[0].Period)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type RevokedTokenRepository struct {
+	DB *gorm.DB
+}
+
+// Revoke는 jti를 폐기 목록에 넣습니다. 이미 폐기된 토큰이면 false입니다.
+func (r *RevokedTokenRepository) Revoke(ctx context.Context, id string, expiresAt time.Time) (revoked bool, err error) {
+	result := r.DB.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&types.RevokedToken{
+		ID:        id,
+		ExpiresAt: expiresAt,
+		CreateAt:  time.Now(),
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *RevokedTokenRepository) IsRevoked(ctx context.Context, id string) (bool, error) {
+	err := r.DB.WithContext(ctx).Select("id").Where("id = ?", id).First(&types.RevokedToken{}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// PurgeExpired는 이미 만료되어 폐기 목록에 둘 필요가 없는 토큰을 지웁니다.
+func (r *RevokedTokenRepository) PurgeExpired(ctx context.Context) (int64, error) {
+	result := r.DB.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&types.RevokedToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type TenantRepository struct {
+	DB *gorm.DB
+}
+
+func (r *TenantRepository) Insert(ctx context.Context, input *requestTypes.TenantRequest) (err error) {
+	dbRecord := &types.Tenant{
+		ID:       input.ID,
+		Name:     input.Name,
+		CreateAt: time.Now(),
+	}
+
+	if err = r.DB.WithContext(ctx).Create(dbRecord).Error; err != nil {
+		return conflict(err, ErrTenantExists)
+	}
+
+	return nil
+}
+
+func (r *TenantRepository) GetAll(ctx context.Context) (tenants *[]types.Tenant, err error) {
+	if err = r.DB.WithContext(ctx).Order("create_at").Find(&tenants).Error; err != nil {
+		return nil, err
+	}
+
+	return tenants, nil
+}
+
+func (r *TenantRepository) Exists(ctx context.Context, id string) (bool, error) {
+	err := r.DB.WithContext(ctx).Select("id").Where("id = ?", id).First(&types.Tenant{}).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
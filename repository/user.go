@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// UserRepository는 로그인 전에 테넌트를 모르는 상태로 조회하므로 테넌트 범위를 쓰지 않습니다.
+type UserRepository struct {
+	DB *gorm.DB
+}
+
+func (r *UserRepository) Insert(ctx context.Context, input *requestTypes.UserRequest, passwordHash string) (dbRecord *types.User, err error) {
+	dbRecord = &types.User{
+		BasicModel: types.BasicModel{
+			ID:       uuid.New(),
+			TenantID: input.TenantID,
+			CreateAt: time.Now(),
+		},
+		Email:        normalizeEmail(input.Email),
+		PasswordHash: passwordHash,
+		Roles:        input.Roles,
+	}
+
+	if err = r.DB.WithContext(tenancy.WithoutScope(ctx)).Create(dbRecord).Error; err != nil {
+		return nil, conflict(err, ErrUserExists)
+	}
+
+	return dbRecord, nil
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (dbRecord *types.User, err error) {
+	dbRecord = &types.User{}
+	if err = r.DB.WithContext(tenancy.WithoutScope(ctx)).Where("email = ?", normalizeEmail(email)).First(dbRecord).Error; err != nil {
+		return nil, notFound(err, ErrUserNotFound)
+	}
+
+	return dbRecord, nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id string) (dbRecord *types.User, err error) {
+	dbRecord = &types.User{}
+	if err = r.DB.WithContext(tenancy.WithoutScope(ctx)).Where("id = ?", id).First(dbRecord).Error; err != nil {
+		return nil, notFound(err, ErrUserNotFound)
+	}
+
+	return dbRecord, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
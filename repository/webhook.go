@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"Go-Gin-Basic-Template/encryption"
+	"Go-Gin-Basic-Template/tenancy"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+const (
+	defaultDeliveryLimit = 100
+	deliveryInsertBatch  = 500
+)
+
+type WebhookRepository struct {
+	DB *gorm.DB
+}
+
+func (r *WebhookRepository) Insert(ctx context.Context, input *requestTypes.WebhookRequest) (dbRecord *types.WebhookSubscription, err error) {
+	dbRecord = &types.WebhookSubscription{
+		ID:         uuid.New(),
+		URL:        input.URL,
+		EventTypes: input.EventTypes,
+		Secret:     input.Secret,
+		Active:     true,
+		CreateAt:   time.Now(),
+	}
+	if input.Active != nil {
+		dbRecord.Active = *input.Active
+	}
+
+	if err = r.DB.WithContext(ctx).Create(dbRecord).Error; err != nil {
+		return nil, err
+	}
+
+	return dbRecord, nil
+}
+
+// Update는 요청에 있는 필드만 바꿉니다.
+func (r *WebhookRepository) Update(ctx context.Context, id string, input *requestTypes.WebhookPatchRequest) (dbRecord *types.WebhookSubscription, err error) {
+	dbRecord = &types.WebhookSubscription{}
+	err = r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(dbRecord).Error; err != nil {
+			return notFound(err, ErrWebhookNotFound)
+		}
+
+		if input.URL != nil {
+			dbRecord.URL = *input.URL
+		}
+		if input.EventTypes != nil {
+			dbRecord.EventTypes = *input.EventTypes
+		}
+		if input.Secret != nil && *input.Secret != "" {
+			dbRecord.Secret = *input.Secret
+		}
+		if input.Active != nil {
+			dbRecord.Active = *input.Active
+			if dbRecord.Active {
+				dbRecord.ConsecutiveFailures = 0
+				dbRecord.DisabledAt = nil
+			}
+		}
+		dbRecord.UpdateAt = time.Now()
+
+		return tx.Save(dbRecord).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbRecord, nil
+}
+
+// Delete는 지운 구독이 없으면 ErrWebhookNotFound입니다.
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	result := r.DB.WithContext(ctx).Where("id = ?", id).Delete(&types.WebhookSubscription{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// GetAll과 GetByID는 서명 키를 복호화하지 않도록 secret 컬럼을 읽지 않습니다.
+func (r *WebhookRepository) GetAll(ctx context.Context) (subscriptions *[]types.WebhookSubscription, err error) {
+	if err = r.DB.WithContext(ctx).Omit("secret").Order("create_at").Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, id string) (dbRecord *types.WebhookSubscription, err error) {
+	dbRecord = &types.WebhookSubscription{}
+	if err = r.DB.WithContext(ctx).Omit("secret").Where("id = ?", id).First(dbRecord).Error; err != nil {
+		return nil, notFound(err, ErrWebhookNotFound)
+	}
+
+	return dbRecord, nil
+}
+
+// GetActive는 새 이벤트를 받을 구독만 돌려줍니다. 전송 대상만 고르면 되므로 id와 event_types만 읽습니다.
+func (r *WebhookRepository) GetActive(ctx context.Context) (subscriptions []types.WebhookSubscription, err error) {
+	if err = r.DB.WithContext(ctx).Select("id", "event_types").Where("active = ?", true).Find(&subscriptions).Error; err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// Reencrypt는 ProductRepository.Reencrypt와 같이 구독의 서명 키를 모든 테넌트에 걸쳐 활성 키로 다시 암호화합니다.
+func (r *WebhookRepository) Reencrypt(ctx context.Context, batchSize int, all bool) (updated int64, err error) {
+	keyRing := encryption.CurrentKeyRing()
+	if keyRing == nil {
+		return 0, encryption.ErrKeyRingNotConfigured
+	}
+
+	db := r.DB.WithContext(tenancy.WithoutScope(ctx)).Unscoped()
+	var subscriptions []types.WebhookSubscription
+
+	query := db.Where("secret NOT LIKE ?", keyRing.ActiveKeyID()+":%")
+	if all {
+		query = db.Where("secret IS NOT NULL")
+	}
+	err = query.FindInBatches(&subscriptions, batchSize, func(tx *gorm.DB, batch int) error {
+		for i := range subscriptions {
+			if err := db.Model(&subscriptions[i]).Select("Secret").Updates(&subscriptions[i]).Error; err != nil {
+				return err
+			}
+			updated++
+		}
+		return nil
+	}).Error
+
+	return updated, err
+}
+
+func (r *WebhookRepository) InsertDeliveries(ctx context.Context, deliveries []types.WebhookDelivery) error {
+	return r.DB.WithContext(ctx).Omit(clause.Associations).CreateInBatches(&deliveries, deliveryInsertBatch).Error
+}
+
+// ClaimDue는 모든 테넌트에서 시도할 때가 된 전송을 limit 개까지 가져오고, next_attempt_at을 lease만큼 미뤄서
+// 다른 인스턴스나 다음 주기가 같은 전송을 중복으로 가져가지 않게 합니다. 처리 중에 프로세스가 죽으면 lease가 지난 뒤 다시 시도됩니다.
+func (r *WebhookRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) (deliveries []types.WebhookDelivery, err error) {
+	now := time.Now()
+	err = r.DB.WithContext(tenancy.WithoutScope(ctx)).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Preload("Subscription", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+			Where("status = ? AND next_attempt_at <= ?", types.WebhookDeliveryPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].ID
+		}
+		return tx.Model(&types.WebhookDelivery{}).Where("id IN ?", ids).
+			Updates(map[string]interface{}{"next_attempt_at": now.Add(lease), "update_at": now}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// RecordAttempt는 전송 결과와 구독의 연속 실패 횟수를 함께 저장합니다.
+// 실패 횟수가 disableAfter에 닿으면 같은 UPDATE 안에서 구독을 비활성화해서 여러 워커가 동시에 실패를 기록해도 어긋나지 않습니다.
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, delivery *types.WebhookDelivery, succeeded bool, disableAfter int) error {
+	return r.DB.WithContext(tenancy.WithoutScope(ctx)).Transaction(func(tx *gorm.DB) error {
+		if err := r.saveDelivery(tx, delivery); err != nil {
+			return err
+		}
+
+		subscription := tx.Model(&types.WebhookSubscription{}).Where("id = ?", delivery.SubscriptionID)
+		if succeeded {
+			return subscription.Update("consecutive_failures", 0).Error
+		}
+		return subscription.Updates(map[string]interface{}{
+			"consecutive_failures": gorm.Expr("consecutive_failures + 1"),
+			"active":               gorm.Expr("CASE WHEN consecutive_failures + 1 >= ? THEN false ELSE active END", disableAfter),
+			"disabled_at":          gorm.Expr("CASE WHEN consecutive_failures + 1 >= ? AND active THEN ? ELSE disabled_at END", disableAfter, time.Now()),
+		}).Error
+	})
+}
+
+// SaveDelivery는 구독 상태를 건드리지 않고 전송 결과만 저장합니다. 비활성화된 구독의 전송을 정리할 때 씁니다.
+func (r *WebhookRepository) SaveDelivery(ctx context.Context, delivery *types.WebhookDelivery) error {
+	return r.saveDelivery(r.DB.WithContext(tenancy.WithoutScope(ctx)), delivery)
+}
+
+func (r *WebhookRepository) saveDelivery(tx *gorm.DB, delivery *types.WebhookDelivery) error {
+	delivery.UpdateAt = time.Now()
+	return tx.Model(delivery).
+		Select("Status", "Attempts", "NextAttemptAt", "LastStatusCode", "LastError", "DeliveredAt", "UpdateAt").
+		Updates(delivery).Error
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, subscriptionID string, filter *requestTypes.WebhookDeliveryFilter) (deliveries *[]types.WebhookDelivery, err error) {
+	limit := defaultDeliveryLimit
+	if filter != nil && filter.Limit > 0 && filter.Limit < limit {
+		limit = filter.Limit
+	}
+
+	db := r.DB.WithContext(ctx).Where("subscription_id = ?", subscriptionID)
+	if filter != nil && filter.Status != "" {
+		db = db.Where("status = ?", filter.Status)
+	}
+	if err = db.Order("create_at DESC").Limit(limit).Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
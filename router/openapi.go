@@ -0,0 +1,427 @@
+package router
+
+import (
+	"Go-Gin-Basic-Template/httpHandler"
+	"Go-Gin-Basic-Template/middleware"
+	"Go-Gin-Basic-Template/openapi"
+	"Go-Gin-Basic-Template/patch"
+	"Go-Gin-Basic-Template/types"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"Go-Gin-Basic-Template/types/responseTypes"
+	"Go-Gin-Basic-Template/utils"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"net/http"
+)
+
+const (
+	adminTokenScheme = "adminToken"
+	bearerScheme     = "bearerAuth"
+	apiKeyScheme     = "apiKey"
+)
+
+var (
+	tenantHeader = openapi.Parameter{
+		Name:        middleware.TenantHeader,
+		In:          "header",
+		Description: "테넌트 ID. 인증 토큰에 테넌트 클레임이 있으면 생략할 수 있습니다.",
+		Schema:      openapi.String(),
+	}
+	idempotencyHeader = openapi.Parameter{
+		Name:        middleware.IdempotencyKeyHeader,
+		In:          "header",
+		Description: "같은 키로 재시도하면 저장된 응답을 돌려줍니다.",
+		Schema:      openapi.String(),
+	}
+	limitParameter = openapi.Parameter{
+		Name:        "limit",
+		In:          "query",
+		Description: "페이지 크기 (1~1000). 없으면 전체 목록을 돌려주고 meta.pagination, links.next, links.prev가 없습니다.",
+		Schema:      openapi.Integer(),
+	}
+	offsetParameter   = openapi.Parameter{Name: "offset", In: "query", Schema: openapi.Integer()}
+	revisionParameter = openapi.Parameter{Name: "rev", In: "path", Required: true, Schema: openapi.Integer()}
+
+	productTags  = []string{"product"}
+	webhookTags  = []string{"webhook"}
+	graphqlTags  = []string{"graphql"}
+	docsTags     = []string{"docs"}
+	authTags     = []string{"auth"}
+	adminTags    = []string{"admin"}
+	tokenBody    = openapi.JSON("", utils.Envelope[responseTypes.Token]{})
+	successBody  = openapi.JSON("", utils.Response{})
+	graphqlReply = openapi.Body{
+		Description: "실행 결과. 실행 중 오류는 200과 함께 errors에 담깁니다.",
+		Content: map[string]interface{}{"application/json": struct {
+			Data   interface{}             `json:"data,omitempty"`
+			Errors []*gqlerrors.QueryError `json:"errors,omitempty"`
+		}{}},
+		Exact: true,
+	}
+
+	// authenticated는 access 토큰이나 API 키 중 하나로 인증하는 라우트입니다.
+	authenticated = []string{bearerScheme, apiKeyScheme}
+)
+
+// endpoints는 SetupRoutes에서 등록하는 라우트의 문서입니다. 키는 "메서드 gin경로"입니다.
+// 라우트를 추가할 때 여기에도 추가하지 않으면 TestRouter_OpenAPIDocumentsAllRoutes가 실패합니다.
+var endpoints = map[string]openapi.Endpoint{
+	"POST /product": {
+		Summary:    "상품 등록",
+		Tags:       productTags,
+		Parameters: []openapi.Parameter{tenantHeader, idempotencyHeader},
+		Request:    &openapi.Body{Content: map[string]interface{}{"application/json": requestTypes.ProductRequest{}}},
+		Responses:  map[int]openapi.Body{http.StatusCreated: successBody},
+		Security:   authenticated,
+	},
+	"POST /product/import": {
+		Summary:     "CSV/NDJSON 상품 가져오기",
+		Description: "행마다 검증해서 실패한 행은 건너뛰고 행 단위 오류 리포트를 돌려줍니다. 본문을 스트리밍으로 읽으므로 Idempotency-Key는 무시합니다.",
+		Tags:        productTags,
+		Parameters: []openapi.Parameter{
+			tenantHeader,
+			{Name: "format", In: "query", Schema: openapi.Enum("csv", "ndjson")},
+			{Name: "dry_run", In: "query", Schema: openapi.Boolean()},
+		},
+		Request: &openapi.Body{Content: map[string]interface{}{
+			"multipart/form-data":  &openapi.Schema{Type: "object", Properties: map[string]*openapi.Schema{"file": openapi.Binary()}},
+			"text/csv":             openapi.Binary(),
+			"application/x-ndjson": openapi.Binary(),
+		}},
+		Responses: map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[types.ImportReport]{})},
+		Security:  authenticated,
+	},
+	"PUT /product/:id": {
+		Summary:     "상품 교체",
+		Description: "본문의 값으로 모든 필드를 바꿉니다. 보내지 않은 필드는 기본값이 됩니다.",
+		Tags:        productTags,
+		Parameters:  []openapi.Parameter{tenantHeader, idempotencyHeader},
+		Request:     &openapi.Body{Content: map[string]interface{}{"application/json": requestTypes.ProductRequest{}}},
+		Responses:   map[int]openapi.Body{http.StatusOK: successBody},
+		Security:    authenticated,
+	},
+	"PATCH /product/:id": {
+		Summary: "상품 일부 수정",
+		Description: "application/merge-patch+json(RFC 7396, application/json도 같음)은 보낸 필드만 바꾸고 null이면 기본값으로 되돌립니다. " +
+			"application/json-patch+json(RFC 6902)은 연산 목록입니다. 결과는 PUT과 같은 규칙으로 검증하고, test 연산이 맞지 않으면 409입니다.",
+		Tags:       productTags,
+		Parameters: []openapi.Parameter{tenantHeader, idempotencyHeader},
+		Request: &openapi.Body{Content: map[string]interface{}{
+			patch.MIMEMergePatch: &openapi.Schema{Type: "object", Description: "바꿀 필드만 담은 ProductRequest"},
+			patch.MIMEJSONPatch:  []patch.Operation{},
+		}},
+		Responses: map[int]openapi.Body{http.StatusOK: successBody},
+		Security:  authenticated,
+	},
+	"DELETE /product/:id": {
+		Summary:    "상품 삭제",
+		Tags:       productTags,
+		Parameters: []openapi.Parameter{tenantHeader, idempotencyHeader},
+		Responses:  map[int]openapi.Body{http.StatusOK: openapi.JSON("message에 삭제한 상품 ID가 들어 있습니다.", utils.Response{})},
+		Security:   authenticated,
+	},
+	"GET /product": {
+		Summary:     "상품 목록",
+		Description: "Accept: text/csv 면 data를 CSV로 받을 수 있습니다.",
+		Tags:        productTags,
+		Parameters:  []openapi.Parameter{tenantHeader, limitParameter, offsetParameter},
+		Query:       requestTypes.ProductFilter{},
+		Responses:   map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[[]responseTypes.Product]{})},
+		Security:    authenticated,
+	},
+	"GET /product/export": {
+		Summary:    "상품 목록 내려받기",
+		Tags:       productTags,
+		Parameters: []openapi.Parameter{tenantHeader, {Name: "format", In: "query", Schema: openapi.Enum("csv", "ndjson", "xlsx")}},
+		Query:      requestTypes.ProductFilter{},
+		Responses: map[int]openapi.Body{http.StatusOK: {Content: map[string]interface{}{
+			"text/csv":             openapi.Binary(),
+			"application/x-ndjson": openapi.Binary(),
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": openapi.Binary(),
+		}}},
+		Security: authenticated,
+	},
+	"GET /product/events": {
+		Summary:     "상품 변경 이벤트 (Server-Sent Events)",
+		Description: "이벤트 이름은 product.created, product.updated, product.deleted 이고 data는 events.Event 입니다.",
+		Tags:        productTags,
+		Parameters: []openapi.Parameter{
+			tenantHeader,
+			{Name: "Last-Event-ID", In: "header", Description: "마지막으로 받은 이벤트 ID", Schema: openapi.Integer()},
+			{Name: "last_event_id", In: "query", Description: "Last-Event-ID 헤더를 보낼 수 없을 때 씁니다.", Schema: openapi.Integer()},
+		},
+		Query:     requestTypes.ProductEventFilter{},
+		Responses: map[int]openapi.Body{http.StatusOK: {Content: map[string]interface{}{"text/event-stream": openapi.String()}}},
+		Security:  authenticated,
+	},
+	"GET /product/:id": {
+		Summary:     "상품 조회",
+		Description: "LEGACY_PRODUCT_RESPONSE=true 면 한 릴리스 동안 예전처럼 data가 배열입니다.",
+		Tags:        productTags,
+		Parameters:  []openapi.Parameter{tenantHeader},
+		Responses:   map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[responseTypes.Product]{})},
+		Security:    authenticated,
+	},
+	"GET /product/:id/revisions": {
+		Summary:    "상품 리비전 목록",
+		Tags:       productTags,
+		Parameters: []openapi.Parameter{tenantHeader},
+		Responses:  map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[[]responseTypes.ProductRevision]{})},
+		Security:   authenticated,
+	},
+	"GET /product/:id/revisions/:rev": {
+		Summary:    "상품 리비전 조회",
+		Tags:       productTags,
+		Parameters: []openapi.Parameter{tenantHeader, revisionParameter},
+		Responses:  map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[responseTypes.ProductRevision]{})},
+		Security:   authenticated,
+	},
+	"GET /product/:id/revisions/:rev/diff": {
+		Summary: "두 리비전 사이의 필드 변경 내역",
+		Tags:    productTags,
+		Parameters: []openapi.Parameter{
+			tenantHeader,
+			revisionParameter,
+			{Name: "to", In: "query", Required: true, Schema: openapi.Integer()},
+		},
+		Responses: map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[[]types.FieldChange]{})},
+		Security:  authenticated,
+	},
+	"POST /product/:id/revisions/:rev/revert": {
+		Summary:    "예전 리비전으로 되돌리기",
+		Tags:       productTags,
+		Parameters: []openapi.Parameter{tenantHeader, idempotencyHeader, revisionParameter},
+		Responses:  map[int]openapi.Body{http.StatusOK: successBody},
+		Security:   authenticated,
+	},
+
+	"GET /graphql": {
+		Summary: "GraphQL query",
+		Tags:    graphqlTags,
+		Parameters: []openapi.Parameter{
+			tenantHeader,
+			{Name: "query", In: "query", Required: true, Schema: openapi.String()},
+			{Name: "operationName", In: "query", Schema: openapi.String()},
+			{Name: "variables", In: "query", Description: "JSON 객체", Schema: openapi.String()},
+		},
+		Responses: map[int]openapi.Body{http.StatusOK: graphqlReply},
+		Security:  authenticated,
+	},
+	"POST /graphql": {
+		Summary:     "GraphQL query/mutation",
+		Description: "query는 product:read, mutation은 product:write 권한이 필요합니다.",
+		Tags:        graphqlTags,
+		Parameters:  []openapi.Parameter{tenantHeader, idempotencyHeader},
+		Request: &openapi.Body{Content: map[string]interface{}{
+			"application/json":    httpHandler.GraphQLRequest{},
+			"application/graphql": openapi.String(),
+		}, Exact: true},
+		Responses: map[int]openapi.Body{http.StatusOK: graphqlReply},
+		Security:  authenticated,
+	},
+	"GET /graphiql": {
+		Summary:     "GraphiQL",
+		Description: "디버그 모드에서만 등록됩니다.",
+		Tags:        graphqlTags,
+		Responses:   map[int]openapi.Body{http.StatusOK: {Content: map[string]interface{}{"text/html": openapi.String()}}},
+	},
+
+	"GET /reports/products": {
+		Summary: "상품 통계",
+		Tags:    []string{"report"},
+		Parameters: []openapi.Parameter{
+			tenantHeader,
+			{Name: "interval", In: "query", Schema: openapi.Enum(types.ReportIntervalWeek, types.ReportIntervalMonth)},
+			{Name: "fresh", In: "query", Description: "머티리얼라이즈드 뷰 대신 원본 테이블에서 계산합니다.", Schema: openapi.Boolean()},
+		},
+		Responses: map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[types.ProductReport]{})},
+		Security:  authenticated,
+	},
+
+	"POST /webhooks": {
+		Summary:    "웹훅 구독 등록",
+		Tags:       webhookTags,
+		Parameters: []openapi.Parameter{tenantHeader, idempotencyHeader},
+		Request:    &openapi.Body{Content: map[string]interface{}{"application/json": requestTypes.WebhookRequest{}}},
+		Responses: map[int]openapi.Body{
+			http.StatusCreated: openapi.JSON("secret은 이 응답에서만 볼 수 있습니다.", utils.Envelope[responseTypes.WebhookSubscriptionCreated]{}),
+		},
+		Security: authenticated,
+	},
+	"GET /webhooks": {
+		Summary:    "웹훅 구독 목록",
+		Tags:       webhookTags,
+		Parameters: []openapi.Parameter{tenantHeader},
+		Responses:  map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[[]types.WebhookSubscription]{})},
+		Security:   authenticated,
+	},
+	"GET /webhooks/:id": {
+		Summary:    "웹훅 구독 조회",
+		Tags:       webhookTags,
+		Parameters: []openapi.Parameter{tenantHeader},
+		Responses:  map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[types.WebhookSubscription]{})},
+		Security:   authenticated,
+	},
+	"PATCH /webhooks/:id": {
+		Summary:    "웹훅 구독 수정",
+		Tags:       webhookTags,
+		Parameters: []openapi.Parameter{tenantHeader, idempotencyHeader},
+		Request:    &openapi.Body{Content: map[string]interface{}{"application/json": requestTypes.WebhookPatchRequest{}}},
+		Responses:  map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[types.WebhookSubscription]{})},
+		Security:   authenticated,
+	},
+	"DELETE /webhooks/:id": {
+		Summary:    "웹훅 구독 삭제",
+		Tags:       webhookTags,
+		Parameters: []openapi.Parameter{tenantHeader, idempotencyHeader},
+		Responses:  map[int]openapi.Body{http.StatusOK: successBody},
+		Security:   authenticated,
+	},
+	"GET /webhooks/:id/deliveries": {
+		Summary:    "웹훅 전송 기록",
+		Tags:       webhookTags,
+		Parameters: []openapi.Parameter{tenantHeader},
+		Query:      requestTypes.WebhookDeliveryFilter{},
+		Responses:  map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[[]types.WebhookDelivery]{})},
+		Security:   authenticated,
+	},
+
+	"POST /auth/login": {
+		Summary:   "로그인",
+		Tags:      authTags,
+		Request:   &openapi.Body{Content: map[string]interface{}{"application/json": requestTypes.LoginRequest{}}},
+		Responses: map[int]openapi.Body{http.StatusOK: tokenBody},
+	},
+	"POST /auth/refresh": {
+		Summary:     "토큰 갱신",
+		Description: "refresh 토큰은 한 번만 쓸 수 있습니다. 새 access 토큰과 refresh 토큰을 돌려줍니다.",
+		Tags:        authTags,
+		Request:     &openapi.Body{Content: map[string]interface{}{"application/json": requestTypes.RefreshRequest{}}},
+		Responses:   map[int]openapi.Body{http.StatusOK: tokenBody},
+	},
+	"POST /auth/revoke": {
+		Summary:     "토큰 폐기",
+		Description: "access 토큰이나 refresh 토큰을 만료 전까지 쓸 수 없게 합니다.",
+		Tags:        authTags,
+		Request:     &openapi.Body{Content: map[string]interface{}{"application/json": requestTypes.RevokeRequest{}}},
+		Responses:   map[int]openapi.Body{http.StatusOK: successBody},
+	},
+
+	"POST /admin/tenants": {
+		Summary:    "테넌트 등록",
+		Tags:       adminTags,
+		Parameters: []openapi.Parameter{idempotencyHeader},
+		Request:    &openapi.Body{Content: map[string]interface{}{"application/json": requestTypes.TenantRequest{}}},
+		Responses:  map[int]openapi.Body{http.StatusCreated: successBody},
+		Security:   []string{adminTokenScheme},
+	},
+	"GET /admin/tenants": {
+		Summary:   "테넌트 목록",
+		Tags:      adminTags,
+		Responses: map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[[]types.Tenant]{})},
+		Security:  []string{adminTokenScheme},
+	},
+
+	"POST /admin/users": {
+		Summary:    "사용자 등록",
+		Tags:       adminTags,
+		Parameters: []openapi.Parameter{idempotencyHeader},
+		Request:    &openapi.Body{Content: map[string]interface{}{"application/json": requestTypes.UserRequest{}}},
+		Responses:  map[int]openapi.Body{http.StatusCreated: openapi.JSON("", utils.Envelope[responseTypes.User]{})},
+		Security:   []string{adminTokenScheme},
+	},
+
+	"POST /admin/api-keys": {
+		Summary:    "API 키 발급",
+		Tags:       adminTags,
+		Parameters: []openapi.Parameter{idempotencyHeader},
+		Request:    &openapi.Body{Content: map[string]interface{}{"application/json": requestTypes.APIKeyRequest{}}},
+		Responses: map[int]openapi.Body{
+			http.StatusCreated: openapi.JSON("key는 이 응답에서만 볼 수 있습니다.", utils.Envelope[responseTypes.APIKeyCreated]{}),
+		},
+		Security: []string{adminTokenScheme},
+	},
+	"GET /admin/api-keys": {
+		Summary:   "API 키 목록",
+		Tags:      adminTags,
+		Query:     requestTypes.APIKeyFilter{},
+		Responses: map[int]openapi.Body{http.StatusOK: openapi.JSON("", utils.Envelope[[]types.APIKey]{})},
+		Security:  []string{adminTokenScheme},
+	},
+	"POST /admin/api-keys/:id/rotate": {
+		Summary:     "API 키 교체",
+		Description: "범위와 만료는 그대로 두고 새 키를 발급합니다. 이전 키는 바로 쓸 수 없습니다.",
+		Tags:        adminTags,
+		Parameters:  []openapi.Parameter{idempotencyHeader},
+		Responses: map[int]openapi.Body{
+			http.StatusOK: openapi.JSON("key는 이 응답에서만 볼 수 있습니다.", utils.Envelope[responseTypes.APIKeyCreated]{}),
+		},
+		Security: []string{adminTokenScheme},
+	},
+	"DELETE /admin/api-keys/:id": {
+		Summary:    "API 키 폐기",
+		Tags:       adminTags,
+		Parameters: []openapi.Parameter{idempotencyHeader},
+		Responses:  map[int]openapi.Body{http.StatusOK: successBody},
+		Security:   []string{adminTokenScheme},
+	},
+
+	"GET /openapi.json": {
+		Summary:   "OpenAPI 문서",
+		Tags:      docsTags,
+		Responses: map[int]openapi.Body{http.StatusOK: {Content: map[string]interface{}{"application/json": &openapi.Schema{Type: "object"}}, Exact: true}},
+	},
+	"GET /docs": {
+		Summary:   "Swagger UI",
+		Tags:      docsTags,
+		Responses: map[int]openapi.Body{http.StatusOK: {Content: map[string]interface{}{"text/html": openapi.String()}}},
+	},
+	"GET /docs/*filepath": {
+		Summary:   "Swagger UI 정적 파일",
+		Tags:      docsTags,
+		Responses: map[int]openapi.Body{http.StatusOK: {Content: map[string]interface{}{"*/*": openapi.Binary()}}},
+	},
+}
+
+// OpenAPI는 Engine에 등록된 라우트로 문서를 만듭니다. endpoints에 없는 라우트는 문서에서 빠지고 undocumented로 돌려줍니다.
+func (r *Router) OpenAPI() (document *openapi.Document, undocumented []string) {
+	builder := openapi.NewBuilder(openapi.Info{
+		Title:       "Go-Gin-Basic-Template",
+		Description: "Go Gin을 사용하는 3 tier 아키텍처를 사용한 웹 어플리케이션 템플릿 입니다.",
+		Version:     "1.0.0",
+	})
+	builder.AlsoProduces(utils.ResponseContentTypes...)
+	builder.AlsoConsumes(utils.RequestContentTypes...)
+	builder.SetDefaultResponse(openapi.Body{Description: "RFC 7807 오류", Content: map[string]interface{}{
+		utils.MIMEProblemJSON: utils.Problem{},
+		utils.MIMEProblemXML:  utils.Problem{},
+	}})
+	builder.AddSecurityScheme(adminTokenScheme, openapi.SecurityScheme{
+		Type: "apiKey",
+		In:   "header",
+		Name: middleware.AdminTokenHeader,
+	})
+	builder.AddSecurityScheme(bearerScheme, openapi.SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+		Description:  "POST /auth/login으로 받은 access 토큰",
+	})
+	builder.AddSecurityScheme(apiKeyScheme, openapi.SecurityScheme{
+		Type:        "apiKey",
+		In:          "header",
+		Name:        "Authorization",
+		Description: "Authorization: ApiKey <키>. 키의 범위(scopes) 밖의 요청은 403입니다.",
+	})
+
+	for _, route := range r.Engine.Routes() {
+		key := route.Method + " " + route.Path
+		endpoint, ok := endpoints[key]
+		if !ok {
+			undocumented = append(undocumented, key)
+			continue
+		}
+		builder.Add(route.Method, route.Path, endpoint)
+	}
+
+	return builder.Document(), undocumented
+}
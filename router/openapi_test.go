@@ -0,0 +1,90 @@
+package router
+
+import (
+	"Go-Gin-Basic-Template/openapi"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupRouter(t *testing.T) *Router {
+	gin.SetMode(gin.TestMode)
+
+	// SQL 모의 객체 생성 - 라우트 등록만 하므로 쿼리는 실행되지 않습니다.
+	mockDB, _, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: mockDB, PreferSimpleProtocol: true}), &gorm.Config{})
+	require.NoError(t, err)
+
+	r := NewRouter(db)
+	r.SetupRoutes()
+	return r
+}
+
+func TestRouter_OpenAPIDocumentsAllRoutes(t *testing.T) {
+	// 테스트 설정
+	r := setupRouter(t)
+
+	// 테스트 실행
+	document, undocumented := r.OpenAPI()
+
+	// 검증 - 라우트를 추가했다면 router/openapi.go의 endpoints에도 문서를 추가해야 합니다.
+	assert.Empty(t, undocumented, "문서가 없는 라우트")
+	for _, route := range r.Engine.Routes() {
+		item, ok := document.Paths[openapi.Path(route.Path)]
+		if assert.True(t, ok, route.Path) {
+			assert.Contains(t, item, strings.ToLower(route.Method), route.Path)
+		}
+	}
+}
+
+func TestRouter_ServesOpenAPIDocument(t *testing.T) {
+	// 테스트 설정
+	r := setupRouter(t)
+
+	// 테스트 실행
+	w := httptest.NewRecorder()
+	r.Engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	// 검증
+	assert.Equal(t, http.StatusOK, w.Code)
+	var document struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &document))
+	assert.Equal(t, openapi.Version, document.OpenAPI)
+	assert.Contains(t, document.Paths["/product/{id}"], "patch")
+	assert.Contains(t, string(document.Paths["/product"]["get"]), `"#/components/schemas/Product"`)
+}
+
+func TestRouter_ServesSwaggerUI(t *testing.T) {
+	// 테스트 설정
+	r := setupRouter(t)
+
+	for _, path := range []string{"/docs", "/docs/"} {
+		// 테스트 실행
+		w := httptest.NewRecorder()
+		r.Engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+
+		// 검증
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		assert.Contains(t, w.Body.String(), "/openapi.json", path)
+	}
+
+	// 정적 파일은 바이너리에 포함된 swagger-ui에서 읽습니다.
+	w := httptest.NewRecorder()
+	r.Engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/swagger-ui-bundle.js", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "javascript")
+}
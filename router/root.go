@@ -1,28 +1,150 @@
 package router
 
 import (
+	"Go-Gin-Basic-Template/auth"
 	"Go-Gin-Basic-Template/controller"
+	"Go-Gin-Basic-Template/database"
+	"Go-Gin-Basic-Template/events"
 	"Go-Gin-Basic-Template/httpHandler"
+	"Go-Gin-Basic-Template/middleware"
+	"Go-Gin-Basic-Template/rateLimit"
 	"Go-Gin-Basic-Template/repository"
+	"Go-Gin-Basic-Template/webhook"
+	"context"
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
+	"log/slog"
 	"os"
 )
 
 type Router struct {
 	Engine *gin.Engine
 
+	TenantRepository      *repository.TenantRepository
+	IdempotencyRepository *repository.IdempotencyRepository
+	ReportRepository      *repository.ReportRepository
+	WebhookController     *controller.WebhookController
+	AuthController        *controller.AuthController
+	APIKeyController      *controller.APIKeyController
+	OIDCVerifier          *auth.OIDCVerifier
+	Policies              *auth.PolicyStore
+	Authorizer            *middleware.Authorizer
+	RateLimitStore        rateLimit.Store
+	RateLimitRules        *rateLimit.Rules
+	RateLimitIPRules      *rateLimit.Rules
+
 	ProductHandler *httpHandler.ProductHandler
+	AuthHandler    *httpHandler.AuthHandler
+	UserHandler    *httpHandler.UserHandler
+	APIKeyHandler  *httpHandler.APIKeyHandler
+	TenantHandler  *httpHandler.TenantHandler
+	ReportHandler  *httpHandler.ReportHandler
+	WebhookHandler *httpHandler.WebhookHandler
+	GraphQLHandler *httpHandler.GraphQLHandler
+	DocsHandler    *httpHandler.DocsHandler
 }
 
 func NewRouter(db *gorm.DB) *Router {
+	broker := events.NewBroker(events.BufferSize())
 	productRepository := &repository.ProductRepository{DB: db}
-	productController := &controller.ProductController{ProductRepository: productRepository}
-	productHandler := &httpHandler.ProductHandler{ProductController: productController}
+	productController := &controller.ProductController{
+		ProductRepository: productRepository,
+		Events:            broker,
+	}
+	productHandler := &httpHandler.ProductHandler{
+		ProductController: productController,
+		LegacyResponse:    httpHandler.LegacyProductResponse(),
+	}
+
+	tenantRepository := &repository.TenantRepository{DB: db}
+	tenantController := &controller.TenantController{TenantRepository: tenantRepository}
+	tenantHandler := &httpHandler.TenantHandler{TenantController: tenantController}
+
+	reportRepository := &repository.ReportRepository{DB: db}
+	reportController := &controller.ReportController{
+		ReportRepository:  reportRepository,
+		MaterializedViews: database.ReportRefreshInterval() > 0,
+	}
+	reportHandler := &httpHandler.ReportHandler{ReportController: reportController}
+
+	webhookController := controller.NewWebhookController(&repository.WebhookRepository{DB: db}, webhook.NewSender())
+	broker.OnPublish(webhookController.Enqueue)
+	webhookHandler := &httpHandler.WebhookHandler{WebhookController: webhookController}
+
+	keys, err := auth.LoadKeySet()
+	if err != nil {
+		panic(err)
+	}
+	if keys == nil {
+		slog.Warn("JWT_KEYS is not set; authenticated routes will fail until signing keys are configured")
+	}
+	userRepository := &repository.UserRepository{DB: db}
+	authController := controller.NewAuthController(userRepository, &repository.RevokedTokenRepository{DB: db}, keys)
+	userController := &controller.UserController{
+		UserRepository:   userRepository,
+		TenantRepository: tenantRepository,
+	}
+	apiKeyController := &controller.APIKeyController{
+		APIKeyRepository: &repository.APIKeyRepository{DB: db},
+		TenantRepository: tenantRepository,
+	}
+	oidcVerifier, err := auth.LoadOIDCVerifier(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	policies, err := auth.LoadPolicyStore()
+	if err != nil {
+		panic(err)
+	}
+	authorizer := &middleware.Authorizer{
+		Policies:           policies,
+		AuditLogRepository: &repository.AuditLogRepository{DB: db},
+	}
+
+	rateLimitRules, err := rateLimit.LoadRules()
+	if err != nil {
+		panic(err)
+	}
+	rateLimitIPRules, err := rateLimit.LoadIPRules()
+	if err != nil {
+		panic(err)
+	}
+	var rateLimitStore rateLimit.Store
+	switch kind := rateLimit.StoreKind(); kind {
+	case rateLimit.StoreMemory:
+		rateLimitStore = rateLimit.NewMemoryStore()
+	case rateLimit.StorePostgres:
+		rateLimitStore = &repository.RateLimitRepository{DB: db}
+	default:
+		panic("unknown RATE_LIMIT_STORE " + kind)
+	}
+
+	graphQLHandler := httpHandler.NewGraphQLHandler(productController)
+	graphQLHandler.Authorize = authorizer.Allow
 
 	r := &Router{
-		Engine:         gin.Default(),
-		ProductHandler: productHandler,
+		Engine:                gin.New(),
+		TenantRepository:      tenantRepository,
+		IdempotencyRepository: &repository.IdempotencyRepository{DB: db},
+		ReportRepository:      reportRepository,
+		WebhookController:     webhookController,
+		AuthController:        authController,
+		APIKeyController:      apiKeyController,
+		OIDCVerifier:          oidcVerifier,
+		Policies:              policies,
+		Authorizer:            authorizer,
+		RateLimitStore:        rateLimitStore,
+		RateLimitRules:        rateLimitRules,
+		RateLimitIPRules:      rateLimitIPRules,
+		ProductHandler:        productHandler,
+		AuthHandler:           &httpHandler.AuthHandler{AuthController: authController},
+		UserHandler:           &httpHandler.UserHandler{UserController: userController},
+		APIKeyHandler:         &httpHandler.APIKeyHandler{APIKeyController: apiKeyController},
+		TenantHandler:         tenantHandler,
+		ReportHandler:         reportHandler,
+		WebhookHandler:        webhookHandler,
+		GraphQLHandler:        graphQLHandler,
+		DocsHandler:           &httpHandler.DocsHandler{},
 	}
 
 	return r
@@ -33,12 +155,87 @@ func (r *Router) ServerStart() error {
 }
 
 func (r *Router) SetupRoutes() {
-	product := r.Engine.Group("/product")
+	// gin.Default의 텍스트 로그 대신 요청 ID가 붙은 JSON 로그를 남깁니다.
+	r.Engine.Use(middleware.RequestMeta(), middleware.AccessLog(), middleware.Recovery(), middleware.Locale())
+	// OIDC는 외부 IdP의 토큰만 맡고 나머지는 authenticate가 확인합니다.
+	oidc := middleware.OIDC(r.OIDCVerifier)
+	authenticate := middleware.Authenticate(r.AuthController, r.APIKeyController)
+	// 인증 전에 IP마다 제한해야 잘못된 자격 증명을 계속 보내는 클라이언트도 막습니다.
+	ipLimited := middleware.RateLimitByIP(r.RateLimitStore, r.RateLimitIPRules)
+	// 인증한 다음에 제한해야 같은 IP 뒤의 사용자나 API 키를 따로 셉니다.
+	rateLimited := middleware.RateLimit(r.RateLimitStore, r.RateLimitRules)
+	// 라우트마다 필요한 권한을 선언합니다. 역할별 권한은 RBAC_POLICY_FILE의 정책에서 정합니다.
+	require := r.Authorizer.Require
+
+	// 가져오기는 파일을 스트리밍으로 읽으므로 Idempotency가 본문을 버퍼링하지 않게 뺍니다.
+	product := r.Engine.Group("/product", ipLimited, oidc, authenticate, rateLimited, middleware.Tenant(r.TenantRepository), middleware.Idempotency(r.IdempotencyRepository, "/product/import"))
+	{
+		product.POST("", require(auth.PermissionProductWrite), r.ProductHandler.Insert)
+		product.POST("/import", require(auth.PermissionProductWrite), r.ProductHandler.Import)
+		product.PUT("/:id", require(auth.PermissionProductWrite), r.ProductHandler.Update)
+		product.PATCH("/:id", require(auth.PermissionProductWrite), r.ProductHandler.Patch)
+		product.DELETE("/:id", require(auth.PermissionProductDelete), r.ProductHandler.Delete)
+		product.GET("", require(auth.PermissionProductRead), r.ProductHandler.GetAll)
+		product.GET("/export", require(auth.PermissionProductRead), r.ProductHandler.Export)
+		product.GET("/events", require(auth.PermissionProductRead), r.ProductHandler.Events)
+		product.GET("/:id", require(auth.PermissionProductRead), r.ProductHandler.GetByID)
+		product.GET("/:id/revisions", require(auth.PermissionProductRead), r.ProductHandler.GetRevisions)
+		product.GET("/:id/revisions/:rev", require(auth.PermissionProductRead), r.ProductHandler.GetRevision)
+		product.GET("/:id/revisions/:rev/diff", require(auth.PermissionProductRead), r.ProductHandler.DiffRevisions)
+		product.POST("/:id/revisions/:rev/revert", require(auth.PermissionProductWrite), r.ProductHandler.Revert)
+	}
+
+	graphql := r.Engine.Group("/graphql", ipLimited, oidc, authenticate, rateLimited, middleware.Tenant(r.TenantRepository), middleware.Idempotency(r.IdempotencyRepository))
+	{
+		// mutation이 필요한 product:write는 핸들러가 operation을 읽은 뒤 확인합니다.
+		graphql.GET("", require(auth.PermissionProductRead), r.GraphQLHandler.Query)
+		graphql.POST("", require(auth.PermissionProductRead), r.GraphQLHandler.Query)
+	}
+	if gin.IsDebugging() {
+		r.Engine.GET("/graphiql", r.GraphQLHandler.GraphiQL)
+	}
+
+	reports := r.Engine.Group("/reports", ipLimited, oidc, authenticate, rateLimited, middleware.Tenant(r.TenantRepository))
+	{
+		reports.GET("/products", require(auth.PermissionReportRead), r.ReportHandler.Products)
+	}
+
+	webhooks := r.Engine.Group("/webhooks", ipLimited, oidc, authenticate, rateLimited, middleware.Tenant(r.TenantRepository), middleware.Idempotency(r.IdempotencyRepository))
+	{
+		webhooks.POST("", require(auth.PermissionWebhookWrite), r.WebhookHandler.Insert)
+		webhooks.GET("", require(auth.PermissionWebhookRead), r.WebhookHandler.GetAll)
+		webhooks.GET("/:id", require(auth.PermissionWebhookRead), r.WebhookHandler.GetByID)
+		webhooks.PATCH("/:id", require(auth.PermissionWebhookWrite), r.WebhookHandler.Update)
+		webhooks.DELETE("/:id", require(auth.PermissionWebhookWrite), r.WebhookHandler.Delete)
+		webhooks.GET("/:id/deliveries", require(auth.PermissionWebhookRead), r.WebhookHandler.GetDeliveries)
+	}
+
+	authentication := r.Engine.Group("/auth", rateLimited)
+	{
+		authentication.POST("/login", r.AuthHandler.Login)
+		authentication.POST("/refresh", r.AuthHandler.Refresh)
+		authentication.POST("/revoke", r.AuthHandler.Revoke)
+	}
+
+	admin := r.Engine.Group("/admin", middleware.AdminOnly(), middleware.Idempotency(r.IdempotencyRepository))
 	{
-		product.POST("", r.ProductHandler.Insert)
-		product.PATCH("/:id", r.ProductHandler.Update)
-		product.DELETE("/:id", r.ProductHandler.Delete)
-		product.GET("", r.ProductHandler.GetAll)
-		product.GET("/:id", r.ProductHandler.GetByID)
+		admin.POST("/tenants", r.TenantHandler.Insert)
+		admin.GET("/tenants", r.TenantHandler.GetAll)
+		admin.POST("/users", r.UserHandler.Insert)
+		admin.POST("/api-keys", r.APIKeyHandler.Insert)
+		admin.GET("/api-keys", r.APIKeyHandler.GetAll)
+		admin.POST("/api-keys/:id/rotate", r.APIKeyHandler.Rotate)
+		admin.DELETE("/api-keys/:id", r.APIKeyHandler.Revoke)
+	}
+
+	r.Engine.GET("/openapi.json", r.DocsHandler.OpenAPI)
+	r.Engine.GET("/docs", r.DocsHandler.SwaggerUI)
+	r.Engine.GET("/docs/*filepath", r.DocsHandler.SwaggerUIAssets)
+
+	// 문서는 모든 라우트를 등록한 다음에 만들어야 합니다.
+	document, undocumented := r.OpenAPI()
+	for _, route := range undocumented {
+		slog.Warn("openapi: route has no documentation", "route", route)
 	}
+	r.DocsHandler.Document = document
 }
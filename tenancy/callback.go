@@ -0,0 +1,85 @@
+package tenancy
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const tenantField = "TenantID"
+
+func Register(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("tenancy:create", assignTenant); err != nil {
+		return err
+	}
+	if err := db.Callback().Query().Before("gorm:query").Register("tenancy:query", scopeTenant); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().Before("gorm:update").Register("tenancy:update", scopeTenant); err != nil {
+		return err
+	}
+	if err := db.Callback().Delete().Before("gorm:delete").Register("tenancy:delete", scopeTenant); err != nil {
+		return err
+	}
+	return db.Callback().Row().Before("gorm:row").Register("tenancy:row", scopeTenant)
+}
+
+func lookupField(db *gorm.DB) (*schema.Field, bool) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return nil, false
+	}
+	field := db.Statement.Schema.LookUpField(tenantField)
+	return field, field != nil
+}
+
+func scopeTenant(db *gorm.DB) {
+	// Raw/Exec로 작성된 SQL은 호출하는 쪽에서 직접 테넌트 조건을 붙입니다.
+	if db.Statement.SQL.Len() > 0 {
+		return
+	}
+	field, ok := lookupField(db)
+	if !ok || Skipped(db.Statement.Context) {
+		return
+	}
+
+	tenantID, ok := FromContext(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(ErrMissingTenant)
+		return
+	}
+
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: tenantID},
+	}})
+}
+
+func assignTenant(db *gorm.DB) {
+	field, ok := lookupField(db)
+	if !ok || Skipped(db.Statement.Context) {
+		return
+	}
+
+	tenantID, ok := FromContext(db.Statement.Context)
+	if !ok {
+		_ = db.AddError(ErrMissingTenant)
+		return
+	}
+
+	ctx := db.Statement.Context
+	rv := db.Statement.ReflectValue
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := field.Set(ctx, reflect.Indirect(rv.Index(i)), tenantID); err != nil {
+				_ = db.AddError(err)
+				return
+			}
+		}
+	case reflect.Struct:
+		if err := field.Set(ctx, rv, tenantID); err != nil {
+			_ = db.AddError(err)
+		}
+	}
+}
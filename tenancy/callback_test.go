@@ -0,0 +1,124 @@
+package tenancy
+
+import (
+	"Go-Gin-Basic-Template/types"
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func setupMockDB(t *testing.T) (sqlmock.Sqlmock, *gorm.DB) {
+	// SQL 모의 객체 생성
+	mockDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { mockDB.Close() })
+
+	// GORM 설정
+	db, err := gorm.Open(postgres.New(postgres.Config{
+		DSN:                  "sqlmock_db_0",
+		DriverName:           "postgres",
+		Conn:                 mockDB,
+		PreferSimpleProtocol: true,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, Register(db))
+
+	return mock, db
+}
+
+func TestRegister_ScopesQueryToTenant(t *testing.T) {
+	mock, db := setupMockDB(t)
+	ctx := WithTenant(context.Background(), "tenant-a")
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE "products"."tenant_id" = $1 AND "products"."delete_at" IS NULL`)).
+		WithArgs("tenant-a").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name"}))
+
+	// 테스트 실행
+	var products []types.Product
+	err := db.WithContext(ctx).Find(&products).Error
+
+	// 검증
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegister_ScopesDeleteToTenant(t *testing.T) {
+	mock, db := setupMockDB(t)
+	ctx := WithTenant(context.Background(), "tenant-a")
+	testID := uuid.New().String()
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "products" SET "delete_at"=$1 WHERE id = $2 AND "products"."tenant_id" = $3 AND "products"."delete_at" IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), testID, "tenant-a").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	err := db.WithContext(ctx).Where("id = ?", testID).Delete(&types.Product{}).Error
+
+	// 검증
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegister_AssignsTenantOnCreate(t *testing.T) {
+	mock, db := setupMockDB(t)
+	ctx := WithTenant(context.Background(), "tenant-a")
+
+	// 다른 테넌트 값을 넣어도 컨텍스트의 테넌트로 덮어써야 합니다.
+	product := &types.Product{BasicModel: types.BasicModel{ID: uuid.New(), TenantID: "tenant-b"}, Name: "상품"}
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "products"`)).
+		WithArgs(product.ID, "tenant-a", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "상품", 0.0, "", "", nil, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	// 테스트 실행
+	err := db.WithContext(ctx).Create(product).Error
+
+	// 검증
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant-a", product.TenantID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegister_MissingTenant(t *testing.T) {
+	mock, db := setupMockDB(t)
+
+	// 테스트 실행
+	var products []types.Product
+	err := db.WithContext(context.Background()).Find(&products).Error
+
+	// 검증 - 테넌트가 없으면 쿼리를 실행하지 않아야 합니다.
+	assert.ErrorIs(t, err, ErrMissingTenant)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegister_WithoutScope(t *testing.T) {
+	mock, db := setupMockDB(t)
+	ctx := WithoutScope(context.Background())
+
+	// SQL 쿼리 모의 설정
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "products" WHERE "products"."delete_at" IS NULL`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tenant_id", "name"}))
+
+	// 테스트 실행
+	var products []types.Product
+	err := db.WithContext(ctx).Find(&products).Error
+
+	// 검증
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,42 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+)
+
+type contextKey struct {
+	name string
+}
+
+var (
+	tenantKey = contextKey{name: "tenant"}
+	skipKey   = contextKey{name: "skip"}
+)
+
+var ErrMissingTenant = errors.New("tenant is not set on context")
+
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey, tenantID)
+}
+
+func FromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	tenantID, ok := ctx.Value(tenantKey).(string)
+	return tenantID, ok && tenantID != ""
+}
+
+// WithoutScope는 관리자 작업이나 백그라운드 작업처럼 모든 테넌트에 접근해야 하는 경우에만 사용합니다.
+func WithoutScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipKey, true)
+}
+
+func Skipped(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	skip, _ := ctx.Value(skipKey).(bool)
+	return skip
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// APIKey는 배치 작업이나 파트너용 장기 자격 증명입니다. 키 원문은 저장하지 않고 Prefix로 찾아서 Hash와 비교합니다.
+type APIKey struct {
+	ID       uuid.UUID `gorm:"primarykey" json:"id"`
+	TenantID string    `gorm:"index" json:"tenantId"`
+	Name     string    `json:"name"`
+	// Prefix는 키 앞부분의 공개 식별자입니다. 목록에서 어느 키인지 알아볼 때도 씁니다.
+	Prefix     string     `gorm:"uniqueIndex" json:"prefix"`
+	Hash       string     `json:"-"`
+	Scopes     []string   `gorm:"serializer:json;type:jsonb" json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreateAt   time.Time  `json:"createdAt"`
+	UpdateAt   time.Time  `json:"updatedAt"`
+}
+
+// Usable은 폐기되지 않았고 만료되지 않은 키인지 확인합니다.
+func (k *APIKey) Usable(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
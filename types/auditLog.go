@@ -0,0 +1,27 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	AuditActorUser   = "user"
+	AuditActorAPIKey = "api_key"
+
+	AuditActionAccessDenied = "access.denied"
+)
+
+// AuditLog는 보안 감사 기록입니다. 한 번 쓰면 바꾸거나 지우지 않습니다.
+type AuditLog struct {
+	ID         uuid.UUID `gorm:"primarykey" json:"id"`
+	TenantID   string    `gorm:"index" json:"tenantId"`
+	ActorType  string    `json:"actorType"`
+	ActorID    string    `gorm:"index" json:"actorId"`
+	Action     string    `gorm:"index" json:"action"`
+	Permission string    `json:"permission"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	RequestID  string    `json:"requestId"`
+	CreateAt   time.Time `gorm:"index" json:"createdAt"`
+}
@@ -8,6 +8,7 @@ import (
 
 type BasicModel struct {
 	ID       uuid.UUID `gorm:"primarykey"`
+	TenantID string    `gorm:"index"`
 	CreateAt time.Time
 	UpdateAt time.Time
 	DeleteAt gorm.DeletedAt `gorm:"index"`
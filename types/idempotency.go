@@ -0,0 +1,17 @@
+package types
+
+import (
+	"time"
+)
+
+type IdempotencyRecord struct {
+	TenantID    string `gorm:"primarykey"`
+	Key         string `gorm:"primarykey"`
+	Fingerprint string
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreateAt    time.Time
+	ExpireAt    time.Time `gorm:"index"`
+}
@@ -1,7 +1,17 @@
 package types
 
+import (
+	// `serializer:encrypted`를 등록합니다.
+	_ "Go-Gin-Basic-Template/encryption"
+)
+
 type Product struct {
 	BasicModel
-	Name  string  `gorm:"name"`
-	Price float64 `gorm:"price"`
+	Name     string  `gorm:"name"`
+	Price    float64 `gorm:"price"`
+	Category string  `gorm:"index"`
+	SKU      string  `gorm:"index"`
+
+	SupplierCost  *float64 `gorm:"type:text;serializer:encrypted" json:"-"`
+	InternalNotes string   `gorm:"type:text;serializer:encrypted" json:"-"`
 }
@@ -0,0 +1,16 @@
+package types
+
+type ImportReport struct {
+	DryRun    bool             `json:"dryRun"`
+	Total     int              `json:"total"`
+	Imported  int              `json:"imported"`
+	Failed    int              `json:"failed"`
+	Errors    []ImportRowError `json:"errors"`
+	Truncated bool             `json:"truncated"`
+}
+
+type ImportRowError struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
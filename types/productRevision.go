@@ -0,0 +1,75 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type ProductRevision struct {
+	ID        uuid.UUID       `gorm:"primarykey"`
+	TenantID  string          `gorm:"index"`
+	ProductID uuid.UUID       `gorm:"uniqueIndex:idx_product_revision"`
+	Revision  int             `gorm:"uniqueIndex:idx_product_revision"`
+	Snapshot  ProductSnapshot `gorm:"serializer:json;type:jsonb"`
+	CreateAt  time.Time
+}
+
+// ProductSnapshot은 리비전에 평문 JSON으로 남기는 상품 필드입니다. 암호화 필드(SupplierCost, InternalNotes)는
+// 평문으로 새지 않도록 일부러 넣지 않으므로 이력과 Diff에 없고, Revert해도 현재 값을 그대로 둡니다.
+type ProductSnapshot struct {
+	Name     string  `json:"name"`
+	Price    float64 `json:"price"`
+	Category string  `json:"category"`
+	SKU      string  `json:"sku,omitempty"`
+}
+
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+func NewProductSnapshot(product *Product) ProductSnapshot {
+	return ProductSnapshot{
+		Name:     product.Name,
+		Price:    product.Price,
+		Category: product.Category,
+		SKU:      product.SKU,
+	}
+}
+
+func (s ProductSnapshot) Apply(product *Product) {
+	product.Name = s.Name
+	product.Price = s.Price
+	product.Category = s.Category
+	product.SKU = s.SKU
+}
+
+// Diff는 두 스냅샷에서 값이 다른 필드를 json 이름 기준으로 돌려줍니다.
+func (s ProductSnapshot) Diff(to ProductSnapshot) []FieldChange {
+	changes := []FieldChange{}
+	from := reflect.ValueOf(s)
+	target := reflect.ValueOf(to)
+	for i := 0; i < from.NumField(); i++ {
+		field := from.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		a, b := from.Field(i).Interface(), target.Field(i).Interface()
+		if !reflect.DeepEqual(a, b) {
+			changes = append(changes, FieldChange{Field: name, From: a, To: b})
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductSnapshot_Diff(t *testing.T) {
+	// 테스트 데이터
+	from := ProductSnapshot{Name: "상품", Price: 10000.0}
+	to := ProductSnapshot{Name: "상품", Price: 12000.0}
+
+	// 테스트 실행
+	changes := from.Diff(to)
+
+	// 검증
+	assert.Equal(t, []FieldChange{{Field: "price", From: 10000.0, To: 12000.0}}, changes)
+}
+
+func TestProductSnapshot_Diff_NoChanges(t *testing.T) {
+	// 테스트 데이터
+	snapshot := ProductSnapshot{Name: "상품", Price: 10000.0}
+
+	// 테스트 실행
+	changes := snapshot.Diff(snapshot)
+
+	// 검증
+	assert.Empty(t, changes)
+	assert.NotNil(t, changes)
+}
+
+func TestProductSnapshot_Apply(t *testing.T) {
+	// 테스트 데이터
+	product := &Product{Name: "새 이름", Price: 1.0}
+	snapshot := ProductSnapshot{Name: "예전 이름", Price: 2.0}
+
+	// 테스트 실행
+	snapshot.Apply(product)
+
+	// 검증
+	assert.Equal(t, snapshot, NewProductSnapshot(product))
+}
+
+func TestProductSnapshot_ExcludesEncryptedFields(t *testing.T) {
+	// 테스트 데이터
+	cost := 700.0
+	product := &Product{Name: "상품", Price: 1000.0, SupplierCost: &cost, InternalNotes: "공급가 협상 중"}
+
+	// 테스트 실행
+	snapshot, err := json.Marshal(NewProductSnapshot(product))
+
+	// 검증 - jsonb 스냅샷에 암호화 필드가 평문으로 남지 않습니다.
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"상품","price":1000,"category":""}`, string(snapshot))
+}
+
+func TestProductSnapshot_ApplyKeepsEncryptedFields(t *testing.T) {
+	// 테스트 데이터
+	cost := 700.0
+	product := &Product{Name: "새 이름", SupplierCost: &cost, InternalNotes: "메모"}
+
+	// 테스트 실행
+	ProductSnapshot{Name: "예전 이름"}.Apply(product)
+
+	// 검증 - 되돌려도 암호화 필드는 현재 값 그대로입니다.
+	assert.Equal(t, "예전 이름", product.Name)
+	assert.Equal(t, &cost, product.SupplierCost)
+	assert.Equal(t, "메모", product.InternalNotes)
+}
@@ -0,0 +1,13 @@
+package types
+
+import (
+	"time"
+)
+
+// RateLimitBucket은 클라이언트와 라우트마다 남은 토큰 수입니다. FullAt이 지나면 버킷이 가득 찬 것과 같으므로 지웁니다.
+type RateLimitBucket struct {
+	Key     string `gorm:"primarykey"`
+	Tokens  float64
+	TakenAt time.Time
+	FullAt  time.Time `gorm:"index"`
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"time"
+)
+
+const (
+	ReportIntervalWeek  = "week"
+	ReportIntervalMonth = "month"
+
+	ReportSourceLive         = "live"
+	ReportSourceMaterialized = "materialized"
+)
+
+type ProductReport struct {
+	Source     string                 `json:"source"`
+	Interval   string                 `json:"interval"`
+	Summary    ProductReportSummary   `json:"summary"`
+	ByCategory []ProductCategoryCount `json:"byCategory"`
+	ByPeriod   []ProductPeriodCount   `json:"byPeriod"`
+}
+
+type ProductReportSummary struct {
+	Count       int64    `json:"count"`
+	MinPrice    *float64 `json:"minPrice"`
+	MaxPrice    *float64 `json:"maxPrice"`
+	AvgPrice    *float64 `json:"avgPrice"`
+	MedianPrice *float64 `json:"medianPrice"`
+}
+
+type ProductCategoryCount struct {
+	Category string `json:"category"`
+	Count    int64  `json:"count"`
+}
+
+type ProductPeriodCount struct {
+	Period time.Time `json:"period"`
+	Count  int64     `json:"count"`
+}
@@ -0,0 +1,15 @@
+package requestTypes
+
+import "time"
+
+type APIKeyRequest struct {
+	Name     string   `json:"name" xml:"name" binding:"required,notblank,max=100"`
+	TenantID string   `json:"tenantId" xml:"tenantId" binding:"required"`
+	Scopes   []string `json:"scopes" xml:"scopes" binding:"required,min=1,dive,required"`
+	// ExpiresAt이 없으면 폐기할 때까지 쓸 수 있습니다.
+	ExpiresAt *time.Time `json:"expiresAt" xml:"expiresAt"`
+}
+
+type APIKeyFilter struct {
+	TenantID string `form:"tenant_id"`
+}
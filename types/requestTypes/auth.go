@@ -0,0 +1,23 @@
+package requestTypes
+
+type LoginRequest struct {
+	Email    string `json:"email" xml:"email" binding:"required,email"`
+	Password string `json:"password" xml:"password" binding:"required"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refreshToken" xml:"refreshToken" binding:"required"`
+}
+
+// RevokeRequest의 Token은 access 토큰과 refresh 토큰 모두 받습니다.
+type RevokeRequest struct {
+	Token string `json:"token" xml:"token" binding:"required"`
+}
+
+// UserRequest의 Password는 bcrypt가 72바이트까지만 쓰므로 그보다 길면 거절합니다.
+type UserRequest struct {
+	Email    string   `json:"email" xml:"email" binding:"required,email,max=254"`
+	Password string   `json:"password" xml:"password" binding:"required,min=8,max=72"`
+	TenantID string   `json:"tenantId" xml:"tenantId" binding:"required"`
+	Roles    []string `json:"roles" xml:"roles"`
+}
@@ -1,6 +1,30 @@
 package requestTypes
 
+// ProductRequest는 binding 태그로 검증합니다. sku, notblank는 validation 패키지의 커스텀 태그입니다.
 type ProductRequest struct {
-	Name  string  `json:"name"`
-	Price float64 `json:"price"`
+	Name     string  `json:"name" xml:"name" binding:"required,notblank,max=100"`
+	Price    float64 `json:"price" xml:"price" binding:"gt=0"`
+	Category string  `json:"category" xml:"category" binding:"max=50"`
+	SKU      string  `json:"sku" xml:"sku" binding:"omitempty,sku"`
+
+	SupplierCost  *float64 `json:"supplierCost" xml:"supplierCost" binding:"omitempty,gte=0"`
+	InternalNotes string   `json:"internalNotes" xml:"internalNotes" binding:"max=1000"`
+}
+
+type ProductFilter struct {
+	Name     string   `form:"name"`
+	MinPrice *float64 `form:"min_price"`
+	MaxPrice *float64 `form:"max_price"`
+}
+
+// Page는 목록 조회의 offset 페이지입니다. Limit이 없으면 페이지를 나누지 않습니다.
+type Page struct {
+	Limit  *int `form:"limit" binding:"omitempty,min=1,max=1000"`
+	Offset int  `form:"offset" binding:"min=0"`
+}
+
+// ProductEventFilter는 같은 이름의 쿼리를 여러 번 넘겨서 여러 값을 지정할 수 있습니다. (?category=a&category=b)
+type ProductEventFilter struct {
+	ProductIDs []string `form:"product_id"`
+	Categories []string `form:"category"`
 }
@@ -0,0 +1,6 @@
+package requestTypes
+
+type TenantRequest struct {
+	ID   string `json:"id" xml:"id"`
+	Name string `json:"name" xml:"name"`
+}
@@ -0,0 +1,24 @@
+package requestTypes
+
+type WebhookRequest struct {
+	URL        string   `json:"url" xml:"url"`
+	EventTypes []string `json:"eventTypes" xml:"eventTypes"`
+	// Secret을 비워두면 서버가 만들어서 생성 응답에 한 번만 돌려줍니다.
+	Secret string `json:"secret" xml:"secret"`
+	// Active를 true로 바꾸면 비활성화된 구독의 실패 횟수를 초기화하고 다시 전송합니다.
+	Active *bool `json:"active" xml:"active"`
+}
+
+// WebhookPatchRequest는 PATCH 요청입니다. 보낸 필드만 바꾸고, eventTypes를 빈 배열로 보내면 모든 이벤트를 받습니다.
+type WebhookPatchRequest struct {
+	URL        *string   `json:"url" xml:"url"`
+	EventTypes *[]string `json:"eventTypes" xml:"eventTypes"`
+	// Secret을 보내면 서명 키를 바꿉니다.
+	Secret *string `json:"secret" xml:"secret"`
+	Active *bool   `json:"active" xml:"active"`
+}
+
+type WebhookDeliveryFilter struct {
+	Status string `form:"status"`
+	Limit  int    `form:"limit"`
+}
@@ -0,0 +1,9 @@
+package responseTypes
+
+import "Go-Gin-Basic-Template/types"
+
+// APIKeyCreated는 생성과 교체 응답에서만 키 원문을 보여줍니다. 이후 조회에서는 다시 볼 수 없습니다.
+type APIKeyCreated struct {
+	types.APIKey
+	Key string `json:"key"`
+}
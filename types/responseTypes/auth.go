@@ -0,0 +1,36 @@
+package responseTypes
+
+import (
+	"Go-Gin-Basic-Template/types"
+	"time"
+)
+
+// Token은 로그인과 갱신 응답입니다. ExpiresIn은 access 토큰의 남은 초입니다.
+type Token struct {
+	AccessToken  string `json:"accessToken" xml:"accessToken"`
+	RefreshToken string `json:"refreshToken" xml:"refreshToken"`
+	TokenType    string `json:"tokenType" xml:"tokenType"`
+	ExpiresIn    int64  `json:"expiresIn" xml:"expiresIn"`
+}
+
+type User struct {
+	ID        string    `json:"id" xml:"id"`
+	Email     string    `json:"email" xml:"email"`
+	TenantID  string    `json:"tenantId" xml:"tenantId"`
+	Roles     []string  `json:"roles" xml:"roles"`
+	CreatedAt time.Time `json:"createdAt" xml:"createdAt"`
+}
+
+func NewUser(user *types.User) User {
+	roles := user.Roles
+	if roles == nil {
+		roles = []string{}
+	}
+	return User{
+		ID:        user.ID.String(),
+		Email:     user.Email,
+		TenantID:  user.TenantID,
+		Roles:     roles,
+		CreatedAt: user.CreateAt,
+	}
+}
@@ -1 +1,37 @@
 package responseTypes
+
+import (
+	"Go-Gin-Basic-Template/types"
+	"time"
+)
+
+// Product는 상품 API 응답입니다. 모델 필드 이름이 바뀌어도 JSON 필드 이름은 바뀌지 않습니다.
+type Product struct {
+	ID        string    `json:"id" xml:"id"`
+	Name      string    `json:"name" xml:"name"`
+	Price     float64   `json:"price" xml:"price"`
+	Category  string    `json:"category" xml:"category"`
+	SKU       string    `json:"sku,omitempty" xml:"sku,omitempty"`
+	CreatedAt time.Time `json:"createdAt" xml:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" xml:"updatedAt"`
+}
+
+func NewProduct(product *types.Product) Product {
+	return Product{
+		ID:        product.ID.String(),
+		Name:      product.Name,
+		Price:     product.Price,
+		Category:  product.Category,
+		SKU:       product.SKU,
+		CreatedAt: product.CreateAt,
+		UpdatedAt: product.UpdateAt,
+	}
+}
+
+func NewProducts(products []types.Product) []Product {
+	response := make([]Product, 0, len(products))
+	for i := range products {
+		response = append(response, NewProduct(&products[i]))
+	}
+	return response
+}
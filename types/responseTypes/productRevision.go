@@ -0,0 +1,45 @@
+package responseTypes
+
+import (
+	"Go-Gin-Basic-Template/types"
+	"time"
+)
+
+// ProductRevision은 리비전 API 응답입니다. 스냅샷은 상품 응답과 같은 필드만 보여줍니다.
+type ProductRevision struct {
+	ID        string          `json:"id" xml:"id"`
+	ProductID string          `json:"productId" xml:"productId"`
+	Revision  int             `json:"revision" xml:"revision"`
+	Snapshot  ProductSnapshot `json:"snapshot" xml:"snapshot"`
+	CreatedAt time.Time       `json:"createdAt" xml:"createdAt"`
+}
+
+type ProductSnapshot struct {
+	Name     string  `json:"name" xml:"name"`
+	Price    float64 `json:"price" xml:"price"`
+	Category string  `json:"category" xml:"category"`
+	SKU      string  `json:"sku,omitempty" xml:"sku,omitempty"`
+}
+
+func NewProductRevision(revision *types.ProductRevision) ProductRevision {
+	return ProductRevision{
+		ID:        revision.ID.String(),
+		ProductID: revision.ProductID.String(),
+		Revision:  revision.Revision,
+		Snapshot: ProductSnapshot{
+			Name:     revision.Snapshot.Name,
+			Price:    revision.Snapshot.Price,
+			Category: revision.Snapshot.Category,
+			SKU:      revision.Snapshot.SKU,
+		},
+		CreatedAt: revision.CreateAt,
+	}
+}
+
+func NewProductRevisions(revisions []types.ProductRevision) []ProductRevision {
+	response := make([]ProductRevision, 0, len(revisions))
+	for i := range revisions {
+		response = append(response, NewProductRevision(&revisions[i]))
+	}
+	return response
+}
@@ -0,0 +1,9 @@
+package responseTypes
+
+import "Go-Gin-Basic-Template/types"
+
+// WebhookSubscriptionCreated는 생성 응답에서만 서명 키를 보여줍니다. 이후 조회에서는 다시 볼 수 없습니다.
+type WebhookSubscriptionCreated struct {
+	types.WebhookSubscription
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,12 @@
+package types
+
+import (
+	"time"
+)
+
+// RevokedToken은 만료 전에 폐기한 토큰의 jti입니다. 토큰이 만료되면 더 확인할 필요가 없으므로 지웁니다.
+type RevokedToken struct {
+	ID        string    `gorm:"primarykey"`
+	ExpiresAt time.Time `gorm:"index"`
+	CreateAt  time.Time
+}
@@ -0,0 +1,14 @@
+package types
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+type Tenant struct {
+	ID       string `gorm:"primarykey"`
+	Name     string `gorm:"name"`
+	CreateAt time.Time
+	UpdateAt time.Time
+	DeleteAt gorm.DeletedAt `gorm:"index"`
+}
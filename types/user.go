@@ -0,0 +1,9 @@
+package types
+
+// User는 로그인하는 사용자입니다. 사용자는 한 테넌트에 속하고, 발급한 토큰의 테넌트 클레임이 됩니다.
+type User struct {
+	BasicModel
+	Email        string   `gorm:"uniqueIndex"`
+	PasswordHash string   `json:"-"`
+	Roles        []string `gorm:"serializer:json;type:jsonb"`
+}
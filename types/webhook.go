@@ -0,0 +1,61 @@
+package types
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+type WebhookSubscription struct {
+	ID       uuid.UUID `gorm:"primarykey" json:"id"`
+	TenantID string    `gorm:"index" json:"-"`
+	URL      string    `json:"url"`
+	// EventTypes가 비어 있으면 모든 이벤트를 받습니다.
+	EventTypes []string `gorm:"serializer:json;type:jsonb" json:"eventTypes"`
+	// Secret은 서명을 만들 때 원문이 필요해서 해시 대신 암호화해서 저장하고, 생성할 때 한 번만 응답에 포함합니다.
+	Secret string `gorm:"type:text;serializer:encrypted" json:"-"`
+	Active bool   `json:"active"`
+	// ConsecutiveFailures가 WEBHOOK_DISABLE_AFTER에 닿으면 Active를 끄고 DisabledAt을 기록합니다.
+	ConsecutiveFailures int            `json:"consecutiveFailures"`
+	DisabledAt          *time.Time     `json:"disabledAt"`
+	CreateAt            time.Time      `json:"createdAt"`
+	UpdateAt            time.Time      `json:"updatedAt"`
+	DeleteAt            gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// Accepts는 구독이 이 이벤트 종류를 받도록 설정되어 있는지 확인합니다.
+func (s *WebhookSubscription) Accepts(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID           `gorm:"primarykey" json:"id"`
+	TenantID       string              `gorm:"index" json:"-"`
+	SubscriptionID uuid.UUID           `gorm:"index" json:"subscriptionId"`
+	Subscription   WebhookSubscription `gorm:"foreignKey:SubscriptionID" json:"-"`
+	EventType      string              `json:"eventType"`
+	Payload        json.RawMessage     `gorm:"type:jsonb" json:"payload"`
+	Status         string              `gorm:"index:idx_webhook_delivery_due,priority:1" json:"status"`
+	Attempts       int                 `json:"attempts"`
+	NextAttemptAt  time.Time           `gorm:"index:idx_webhook_delivery_due,priority:2" json:"nextAttemptAt"`
+	LastStatusCode int                 `json:"lastStatusCode"`
+	LastError      string              `json:"lastError"`
+	DeliveredAt    *time.Time          `json:"deliveredAt"`
+	CreateAt       time.Time           `json:"createdAt"`
+	UpdateAt       time.Time           `json:"updatedAt"`
+}
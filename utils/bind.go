@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"io"
+	"net/http"
+)
+
+// RequestContentTypes는 Bind가 읽을 수 있는 요청 본문 형식입니다. Content-Type이 없으면 JSON으로 읽습니다.
+var RequestContentTypes = []string{binding.MIMEJSON, binding.MIMEXML, binding.MIMEMSGPACK2, binding.MIMEPROTOBUF}
+
+// Bind는 Content-Type에 맞게 요청 본문을 읽고 binding 태그로 검증합니다.
+// 본문을 읽기 전에 Accept도 확인해서, 저장까지 마친 뒤에 406으로 끝나는 일이 없게 합니다.
+func Bind(c *gin.Context, obj interface{}) (statusCode int, err error) {
+	if _, ok := negotiateFormat(c.GetHeader("Accept"), false); !ok {
+		return http.StatusNotAcceptable, errNotAcceptable
+	}
+
+	switch contentType := c.ContentType(); contentType {
+	case "", binding.MIMEJSON:
+		err = c.ShouldBindJSON(obj)
+	case binding.MIMEXML, binding.MIMEXML2:
+		err = c.ShouldBindXML(obj)
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		err = c.ShouldBindWith(obj, binding.MsgPack)
+	case binding.MIMEPROTOBUF, MIMEProtobuf2:
+		err = bindProtobuf(c.Request.Body, obj)
+	default:
+		return http.StatusUnsupportedMediaType, fmt.Errorf("지원하지 않는 Content-Type %q", contentType)
+	}
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	return http.StatusOK, nil
+}
+
+// bindProtobuf는 응답과 같이 google.protobuf.Struct 본문을 JSON 필드 이름으로 읽습니다.
+func bindProtobuf(body io.Reader, obj interface{}) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	message := &structpb.Struct{}
+	if err = proto.Unmarshal(data, message); err != nil {
+		return err
+	}
+	data, err = json.Marshal(message.AsMap())
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(data, obj); err != nil {
+		return err
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"Go-Gin-Basic-Template/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// Locale은 middleware.Locale이 정한 언어이고, 미들웨어를 거치지 않았다면 Accept-Language로 바로 정합니다.
+func Locale(c *gin.Context) string {
+	if locale, ok := i18n.FromContext(c.Request.Context()); ok {
+		return locale
+	}
+	return i18n.Negotiate(c.GetHeader("Accept-Language"))
+}
+
+// T는 요청 언어의 메시지입니다.
+func T(c *gin.Context, key string, args ...interface{}) string {
+	return i18n.T(Locale(c), key, args...)
+}
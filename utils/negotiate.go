@@ -0,0 +1,311 @@
+package utils
+
+import (
+	"Go-Gin-Basic-Template/i18n"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	MIMECSV       = "text/csv"
+	MIMEProtobuf2 = "application/protobuf"
+)
+
+// ResponseContentTypes는 모든 JSON 응답을 대신할 수 있는 형식입니다. text/csv는 목록 응답에만 쓸 수 있습니다.
+var ResponseContentTypes = []string{binding.MIMEXML, binding.MIMEMSGPACK2, binding.MIMEPROTOBUF}
+
+var errNotAcceptable = errors.New("supported types: application/json, application/xml, application/msgpack, application/x-protobuf, text/csv")
+
+// format은 응답 콘텐츠 타입 하나와 인코더입니다. 같은 인코더를 쓰는 별칭도 따로 둡니다.
+type format struct {
+	contentType string
+	listOnly    bool
+	encode      func(response interface{}) ([]byte, error)
+}
+
+// formats의 순서는 Accept가 비어 있거나 */* 처럼 q 값이 같을 때의 우선순위입니다.
+var formats = []format{
+	{contentType: binding.MIMEJSON, encode: json.Marshal},
+	{contentType: binding.MIMEXML, encode: xml.Marshal},
+	{contentType: binding.MIMEXML2, encode: xml.Marshal},
+	{contentType: binding.MIMEMSGPACK2, encode: encodeMsgPack},
+	{contentType: binding.MIMEMSGPACK, encode: encodeMsgPack},
+	{contentType: binding.MIMEPROTOBUF, encode: encodeProtobuf},
+	{contentType: MIMEProtobuf2, encode: encodeProtobuf},
+	{contentType: MIMECSV, listOnly: true, encode: encodeCSV},
+}
+
+// respond는 Accept 헤더에 맞는 형식으로 응답합니다. 받을 수 있는 형식이 없으면 406입니다.
+// 오류 응답은 respond를 쓰지 않고 RespondWithProblem으로 보냅니다.
+func respond(c *gin.Context, status int, response interface{}) {
+	f, ok := negotiateFormat(c.GetHeader("Accept"), listData(response) != nil)
+	if !ok {
+		RespondWithError(c, http.StatusNotAcceptable, i18n.NotAcceptable, errNotAcceptable)
+		return
+	}
+
+	body, err := f.encode(response)
+	if err != nil {
+		RespondWithError(c, http.StatusInternalServerError, T(c, i18n.EncodeFailed, f.contentType), err)
+		return
+	}
+	c.Data(status, f.contentType, body)
+}
+
+func negotiateFormat(accept string, list bool) (format, bool) {
+	offered := make([]string, 0, len(formats))
+	for _, f := range formats {
+		if list || !f.listOnly {
+			offered = append(offered, f.contentType)
+		}
+	}
+
+	contentType, ok := negotiate(accept, offered)
+	if !ok {
+		return format{}, false
+	}
+	for _, f := range formats {
+		if f.contentType == contentType {
+			return f, true
+		}
+	}
+	return format{}, false
+}
+
+type mediaRange struct {
+	mainType string
+	subType  string
+	q        float64
+}
+
+func parseAccept(accept string) []mediaRange {
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		params := strings.Split(part, ";")
+		mainType, subType, ok := strings.Cut(strings.ToLower(strings.TrimSpace(params[0])), "/")
+		if !ok {
+			continue
+		}
+		r := mediaRange{mainType: mainType, subType: subType, q: 1}
+		for _, param := range params[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.ToLower(key) != "q" {
+				continue
+			}
+			if q, err := strconv.ParseFloat(value, 64); err == nil {
+				r.q = q
+			}
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges
+}
+
+// negotiate는 offered 중 Accept에서 q 값이 가장 큰 타입을 고릅니다. (RFC 9110 12.5.1)
+// 타입마다 가장 구체적으로 일치하는 범위의 q를 쓰므로 "*/*;q=0.1, application/xml" 이면 XML을 고릅니다.
+// q가 같으면 offered 순서를 따르고, Accept가 비어 있으면 첫 번째 타입을 고릅니다.
+func negotiate(accept string, offered []string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return offered[0], true
+	}
+	ranges := parseAccept(accept)
+
+	best, bestQ := "", 0.0
+	for _, offer := range offered {
+		mainType, subType, _ := strings.Cut(offer, "/")
+		q, specificity := 0.0, -1
+		for _, r := range ranges {
+			s := -1
+			switch {
+			case r.mainType == mainType && r.subType == subType:
+				s = 2
+			case r.mainType == mainType && r.subType == "*":
+				s = 1
+			case r.mainType == "*" && r.subType == "*":
+				s = 0
+			}
+			if s > specificity {
+				q, specificity = r.q, s
+			}
+		}
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best, bestQ > 0
+}
+
+// listData는 Envelope의 Data가 목록일 때만 돌려줍니다.
+func listData(response interface{}) interface{} {
+	list, ok := response.(interface{ list() interface{} })
+	if !ok {
+		return nil
+	}
+	return list.list()
+}
+
+func (e *Envelope[T]) list() interface{} {
+	value := reflect.ValueOf(e.Data)
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return nil
+	}
+	return e.Data
+}
+
+// jsonValue는 응답을 JSON으로 바꾼 값입니다. MessagePack, protobuf, CSV가 JSON과 같은 필드 이름과 모양을 갖게 합니다.
+func jsonValue(response interface{}) (interface{}, error) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	if err = decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return normalizeNumbers(value), nil
+}
+
+// normalizeNumbers는 정수는 int64로, 나머지는 float64로 바꿉니다. MessagePack에서 정수가 실수로 바뀌지 않게 합니다.
+func normalizeNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+	}
+	return value
+}
+
+func encodeMsgPack(response interface{}) ([]byte, error) {
+	value, err := jsonValue(response)
+	if err != nil {
+		return nil, err
+	}
+	var body []byte
+	err = codec.NewEncoderBytes(&body, new(codec.MsgpackHandle)).Encode(value)
+	return body, err
+}
+
+// encodeProtobuf는 응답을 JSON과 같은 모양의 google.protobuf.Struct로 보냅니다.
+func encodeProtobuf(response interface{}) ([]byte, error) {
+	value, err := jsonValue(response)
+	if err != nil {
+		return nil, err
+	}
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("protobuf 응답은 JSON 객체여야 합니다")
+	}
+	message, err := structpb.NewStruct(object)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(message)
+}
+
+// encodeCSV는 목록의 항목 하나를 한 행으로 씁니다. 열은 JSON 필드 이름이고, 중첩된 값은 JSON 문자열로 씁니다.
+func encodeCSV(response interface{}) ([]byte, error) {
+	body, err := json.Marshal(listData(response))
+	if err != nil {
+		return nil, err
+	}
+	var items []json.RawMessage
+	if err = json.Unmarshal(body, &items); err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	rows := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		keys, fields, err := orderedObject(item)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if !seen[key] {
+				seen[key] = true
+				columns = append(columns, key)
+			}
+		}
+		rows = append(rows, fields)
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err = writer.Write(columns); err != nil {
+		return nil, err
+	}
+	for _, fields := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = csvCell(fields[column])
+		}
+		if err = writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// orderedObject는 JSON 객체의 키를 나온 순서대로 돌려줍니다. 객체가 아니면 "value" 열 하나로 봅니다.
+func orderedObject(raw json.RawMessage) ([]string, map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return []string{"value"}, map[string]json.RawMessage{"value": raw}, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	}
+	keys := make([]string, 0, len(fields))
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, token.(string))
+		var skip json.RawMessage
+		if err = decoder.Decode(&skip); err != nil {
+			return nil, nil, err
+		}
+	}
+	return keys, fields, nil
+}
+
+func csvCell(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type testItem struct {
+	Name  string   `json:"name" xml:"name" binding:"required"`
+	Price float64  `json:"price" xml:"price"`
+	Tags  []string `json:"tags,omitempty" xml:"tags"`
+}
+
+func newTestContext(method string, body []byte, headers map[string]string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, "/", bytes.NewReader(body))
+	for key, value := range headers {
+		c.Request.Header.Set(key, value)
+	}
+	return c, w
+}
+
+func TestNegotiate(t *testing.T) {
+	offered := []string{"application/json", "application/xml", "text/csv"}
+	tests := []struct {
+		accept   string
+		expected string
+		ok       bool
+	}{
+		{"", "application/json", true},
+		{"*/*", "application/json", true},
+		{"application/xml", "application/xml", true},
+		{"text/*", "text/csv", true},
+		{"application/json;q=0.5, application/xml", "application/xml", true},
+		// 브라우저 기본값: application/xml;q=0.9 가 */*;q=0.8 보다 높습니다.
+		{"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", "application/xml", true},
+		{"*/*;q=0.1, application/json;q=0", "application/xml", true},
+		{"image/png", "", false},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행
+		contentType, ok := negotiate(tt.accept, offered)
+
+		// 검증
+		assert.Equal(t, tt.ok, ok, tt.accept)
+		assert.Equal(t, tt.expected, contentType, tt.accept)
+	}
+}
+
+func TestRespond_Formats(t *testing.T) {
+	// 테스트 설정
+	response := &Envelope[[]testItem]{Status: http.StatusOK, Data: []testItem{{Name: "사과", Price: 1000, Tags: []string{"a", "b"}}, {Name: "칫솔", Price: 2000.5}}}
+
+	t.Run("xml", func(t *testing.T) {
+		// 테스트 실행
+		c, w := newTestContext(http.MethodGet, nil, map[string]string{"Accept": "application/xml"})
+		respond(c, http.StatusOK, response)
+
+		// 검증
+		assert.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), "<response><status>200</status><data><name>사과</name>")
+	})
+
+	t.Run("msgpack", func(t *testing.T) {
+		// 테스트 실행
+		c, w := newTestContext(http.MethodGet, nil, map[string]string{"Accept": "application/msgpack"})
+		respond(c, http.StatusOK, response)
+
+		// 검증 - JSON과 같은 필드 이름을 쓰고 정수는 정수로 남습니다.
+		assert.Equal(t, "application/msgpack", w.Header().Get("Content-Type"))
+		var decoded map[string]interface{}
+		require.NoError(t, codec.NewDecoderBytes(w.Body.Bytes(), new(codec.MsgpackHandle)).Decode(&decoded))
+		assert.EqualValues(t, 200, decoded["status"])
+		assert.Len(t, decoded["data"], 2)
+	})
+
+	t.Run("protobuf", func(t *testing.T) {
+		// 테스트 실행
+		c, w := newTestContext(http.MethodGet, nil, map[string]string{"Accept": "application/x-protobuf"})
+		respond(c, http.StatusOK, response)
+
+		// 검증
+		assert.Equal(t, "application/x-protobuf", w.Header().Get("Content-Type"))
+		decoded := &structpb.Struct{}
+		require.NoError(t, proto.Unmarshal(w.Body.Bytes(), decoded))
+		assert.Equal(t, 200.0, decoded.Fields["status"].GetNumberValue())
+		assert.Equal(t, "사과", decoded.Fields["data"].GetListValue().Values[0].GetStructValue().Fields["name"].GetStringValue())
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		// 테스트 실행
+		c, w := newTestContext(http.MethodGet, nil, map[string]string{"Accept": "text/csv"})
+		respond(c, http.StatusOK, response)
+
+		// 검증 - 중첩된 값은 JSON 문자열로 씁니다.
+		assert.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+		assert.Equal(t, "name,price,tags\n사과,1000,\"[\"\"a\"\",\"\"b\"\"]\"\n칫솔,2000.5,\n", w.Body.String())
+	})
+}
+
+func TestRespond_NotAcceptable(t *testing.T) {
+	// 테스트 설정 - CSV는 목록 응답에만 쓸 수 있습니다.
+	c, w := newTestContext(http.MethodGet, nil, map[string]string{"Accept": "text/csv"})
+
+	// 테스트 실행
+	RespondWithSuccess(c, http.StatusOK, "성공")
+
+	// 검증
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	assert.Equal(t, MIMEProblemJSON, w.Header().Get("Content-Type"))
+}
+
+func TestRespond_ErrorKeepsStatusWhenNotAcceptable(t *testing.T) {
+	// 테스트 설정
+	c, w := newTestContext(http.MethodGet, nil, map[string]string{"Accept": "image/png"})
+
+	// 테스트 실행
+	RespondWithError(c, http.StatusNotFound, "SELECT 오류", assert.AnError)
+
+	// 검증
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, MIMEProblemJSON, w.Header().Get("Content-Type"))
+	var body Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "SELECT 오류: "+assert.AnError.Error(), body.Detail)
+}
+
+func TestBind(t *testing.T) {
+	// 테스트 설정
+	message, err := structpb.NewStruct(map[string]interface{}{"name": "사과", "price": 1000})
+	require.NoError(t, err)
+	protobufBody, err := proto.Marshal(message)
+	require.NoError(t, err)
+
+	var msgpackBody []byte
+	require.NoError(t, codec.NewEncoderBytes(&msgpackBody, new(codec.MsgpackHandle)).Encode(map[string]interface{}{"name": "사과", "price": 1000.0}))
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        []byte
+	}{
+		{"json", "application/json", []byte(`{"name":"사과","price":1000}`)},
+		{"없는 Content-Type은 JSON", "", []byte(`{"name":"사과","price":1000}`)},
+		{"xml", "application/xml; charset=utf-8", []byte(`<product><name>사과</name><price>1000</price></product>`)},
+		{"msgpack", "application/msgpack", msgpackBody},
+		{"protobuf", "application/x-protobuf", protobufBody},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행
+		c, _ := newTestContext(http.MethodPost, tt.body, map[string]string{"Content-Type": tt.contentType})
+		var item testItem
+		statusCode, err := Bind(c, &item)
+
+		// 검증
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, http.StatusOK, statusCode, tt.name)
+		assert.Equal(t, testItem{Name: "사과", Price: 1000}, item, tt.name)
+	}
+}
+
+func TestBind_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		body     string
+		expected int
+	}{
+		{"지원하지 않는 Content-Type", map[string]string{"Content-Type": "text/plain"}, "사과", http.StatusUnsupportedMediaType},
+		{"응답할 수 없는 Accept", map[string]string{"Accept": "text/csv"}, `{"name":"사과"}`, http.StatusNotAcceptable},
+		{"검증 실패", map[string]string{"Content-Type": "application/xml"}, `<product><price>1</price></product>`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행
+		c, _ := newTestContext(http.MethodPost, []byte(tt.body), tt.headers)
+		statusCode, err := Bind(c, &testItem{})
+
+		// 검증
+		assert.Error(t, err, tt.name)
+		assert.Equal(t, tt.expected, statusCode, tt.name)
+	}
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"Go-Gin-Basic-Template/domainErrors"
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/logging"
+	"Go-Gin-Basic-Template/validation"
+	"encoding/json"
+	"encoding/xml"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/google/uuid"
+	"net/http"
+	"strings"
+)
+
+const (
+	MIMEProblemJSON = "application/problem+json"
+	MIMEProblemXML  = "application/problem+xml"
+)
+
+// Problem은 RFC 7807 오류 응답입니다. Code는 클라이언트가 분기에 쓰는 안정적인 값이고, TraceID로 서버 로그를 찾을 수 있습니다.
+type Problem struct {
+	XMLName  xml.Name `json:"-" xml:"urn:ietf:rfc:7807 problem"`
+	Type     string   `json:"type" xml:"type"`
+	Title    string   `json:"title" xml:"title"`
+	Status   int      `json:"status" xml:"status"`
+	Detail   string   `json:"detail,omitempty" xml:"detail,omitempty"`
+	Instance string   `json:"instance" xml:"instance"`
+	Code     string   `json:"code" xml:"code"`
+	TraceID  string   `json:"traceId" xml:"traceId"`
+	// Errors는 검증 오류(422)일 때 필드마다 하나씩 들어갑니다.
+	Errors []validation.FieldError `json:"errors,omitempty" xml:"errors>error,omitempty"`
+}
+
+// NewProblem은 오류를 Problem으로 바꾸는 유일한 곳입니다.
+// 도메인 오류면 상태 코드, 코드, 설명을 도메인 오류에서 가져오고, 설명은 Code로 카탈로그에서 번역합니다.
+// 검증 오류면 status와 관계없이 422이고 필드별 오류를 Errors에 담습니다.
+// 그 밖의 오류는 status와 message(메시지 코드)를 쓰고, 5xx면 원인 오류를 응답에 넣지 않고 로그에만 남깁니다.
+func NewProblem(c *gin.Context, status int, message string, err error) *Problem {
+	problem := &Problem{
+		Type:     "about:blank",
+		Instance: c.Request.URL.RequestURI(),
+		TraceID:  traceID(c),
+	}
+
+	locale := Locale(c)
+	if fieldErrors, ok := validation.Errors(locale, err); ok {
+		problem.Status = http.StatusUnprocessableEntity
+		problem.Code = i18n.ValidationFailed
+		problem.Detail = i18n.T(locale, i18n.ValidationFailed)
+		problem.Errors = fieldErrors
+	} else if domainError, ok := domainErrors.As(err); ok {
+		problem.Status = domainError.StatusCode()
+		problem.Code = domainError.Code
+		problem.Detail = domainError.Detail
+		if detail, ok := i18n.Lookup(locale, domainError.Code); ok {
+			problem.Detail = detail
+		}
+	} else {
+		problem.Status = status
+		problem.Code = statusCode(status)
+		problem.Detail = i18n.T(locale, message)
+		if err != nil && status < http.StatusInternalServerError {
+			problem.Detail += ": " + err.Error()
+		}
+	}
+	problem.Title = http.StatusText(problem.Status)
+
+	if problem.Status >= http.StatusInternalServerError {
+		logging.FromContext(c.Request.Context()).Error(message, "method", c.Request.Method, "instance", problem.Instance, "trace_id", problem.TraceID, "error", err)
+	}
+	return problem
+}
+
+// RespondWithProblem은 Accept가 XML을 더 원하면 application/problem+xml, 아니면 application/problem+json으로 보냅니다.
+func RespondWithProblem(c *gin.Context, problem *Problem) {
+	contentType, encode := MIMEProblemJSON, json.Marshal
+	if f, ok := negotiateFormat(c.GetHeader("Accept"), false); ok && (f.contentType == binding.MIMEXML || f.contentType == binding.MIMEXML2) {
+		contentType, encode = MIMEProblemXML, xml.Marshal
+	}
+
+	body, err := encode(problem)
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	c.Data(problem.Status, contentType, body)
+}
+
+// statusCode는 도메인 오류가 아닐 때의 코드입니다. 예: 400 → "bad_request"
+func statusCode(status int) string {
+	return strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
+
+// traceID는 W3C traceparent의 trace-id, 요청 ID 순서로 찾고, 둘 다 없으면 새로 만듭니다.
+func traceID(c *gin.Context) string {
+	if parts := strings.Split(c.GetHeader("traceparent"), "-"); len(parts) == 4 && len(parts[1]) == 32 {
+		return parts[1]
+	}
+	if id := RequestID(c); id != "" {
+		return id
+	}
+	return uuid.NewString()
+}
@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"Go-Gin-Basic-Template/domainErrors"
+	"Go-Gin-Basic-Template/i18n"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRespondWithError_DomainError(t *testing.T) {
+	// 테스트 설정 - 핸들러가 넘긴 500과 메시지 대신 도메인 오류를 씁니다.
+	c, w := newTestContext(http.MethodGet, nil, map[string]string{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	})
+	c.Request.URL.Path = "/product/p1"
+	err := fmt.Errorf("조회: %w", domainErrors.NotFound("product.not_found", "상품을 찾을 수 없습니다").Wrap(errors.New("record not found")))
+
+	// 테스트 실행
+	RespondWithError(c, http.StatusInternalServerError, "SELECT 오류", err)
+
+	// 검증
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, MIMEProblemJSON, w.Header().Get("Content-Type"))
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, Problem{
+		Type:     "about:blank",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "상품을 찾을 수 없습니다",
+		Instance: "/product/p1",
+		Code:     "product.not_found",
+		TraceID:  "4bf92f3577b34da6a3ce929d0e0e4736",
+	}, problem)
+}
+
+func TestRespondWithError_InternalErrorHidesCause(t *testing.T) {
+	// 테스트 설정
+	c, w := newTestContext(http.MethodGet, nil, map[string]string{RequestIDHeader: "req-1"})
+
+	// 테스트 실행
+	RespondWithError(c, http.StatusInternalServerError, "SELECT 오류", errors.New(`pq: relation "products" does not exist`))
+
+	// 검증 - 원인 오류는 응답에 나가지 않습니다.
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "internal_server_error", problem.Code)
+	assert.Equal(t, "SELECT 오류", problem.Detail)
+	assert.Equal(t, "req-1", problem.TraceID)
+	assert.NotContains(t, w.Body.String(), "relation")
+}
+
+func TestRespondWithError_XML(t *testing.T) {
+	// 테스트 설정
+	c, w := newTestContext(http.MethodGet, nil, map[string]string{"Accept": "application/xml"})
+
+	// 테스트 실행
+	RespondWithError(c, http.StatusBadRequest, "Invalid query parameter", errors.New("limit"))
+
+	// 검증
+	assert.Equal(t, MIMEProblemXML, w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `<problem xmlns="urn:ietf:rfc:7807">`)
+	var problem Problem
+	require.NoError(t, xml.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "bad_request", problem.Code)
+	assert.Equal(t, "Invalid query parameter: limit", problem.Detail)
+	assert.NotEmpty(t, problem.TraceID)
+}
+
+func TestRespondWithError_Localized(t *testing.T) {
+	// 테스트 설정
+	c, w := newTestContext(http.MethodGet, nil, map[string]string{"Accept-Language": "en-US,en;q=0.9"})
+	err := domainErrors.NotFound("product.not_found", "상품을 찾을 수 없습니다")
+
+	// 테스트 실행
+	RespondWithError(c, http.StatusInternalServerError, i18n.SelectFailed, err)
+
+	// 검증 - 도메인 코드의 메시지를 요청 언어로 바꿉니다.
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, "product.not_found", problem.Code)
+	assert.Equal(t, i18n.T(i18n.English, "product.not_found"), problem.Detail)
+	assert.NotEqual(t, "상품을 찾을 수 없습니다", problem.Detail)
+}
@@ -1,33 +1,125 @@
 package utils
 
 import (
-	"Go-Gin-Basic-Template/types"
 	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
 )
 
-func RespondWithError(c *gin.Context, status int, message string, err error) {
-	response := &ErrorResponse{
-		Status: status,
-		Error:  message,
-		Reason: err.Error(),
-	}
+const (
+	RequestIDHeader = "X-Request-ID"
+	// RequestStartKey와 RequestIDKey는 미들웨어가 gin 컨텍스트에 넣어두는 값입니다. Envelope의 Meta가 됩니다.
+	RequestStartKey = "utils.requestStart"
+	RequestIDKey    = "utils.requestID"
+
+	warningsKey    = "utils.warnings"
+	deprecationKey = "utils.deprecation"
+)
 
-	c.JSON(status, response)
+// RespondWithError는 err를 application/problem+json으로 보냅니다. message는 메시지 코드이고, 도메인 오류면 status와 message 대신 도메인 오류를 씁니다.
+func RespondWithError(c *gin.Context, status int, message string, err error) {
+	RespondWithProblem(c, NewProblem(c, status, message, err))
 }
 
+// RespondWithSuccess의 message는 메시지 코드입니다. 카탈로그에 없는 값(예: 삭제한 상품 ID)은 그대로 나갑니다.
 func RespondWithSuccess(c *gin.Context, status int, message string) {
 	response := &Response{
 		Status:  status,
-		Message: message,
+		Message: T(c, message),
 	}
 
-	c.JSON(status, response)
+	respond(c, status, response)
+}
+
+// RespondData는 값 하나를 Envelope로 감싸서 응답합니다.
+func RespondData[T any](c *gin.Context, status int, data T) {
+	response := &Envelope[T]{
+		Status: status,
+		Data:   data,
+		Meta:   meta(c, nil),
+		Links:  Links{Self: c.Request.URL.RequestURI()},
+	}
+	attachNotices(c, response)
+	respond(c, status, response)
 }
 
-func RespondWithGet(c *gin.Context, status int, product []types.Product) {
-	response := &GetResponse{
+// RespondList는 목록을 Envelope로 감싸서 응답합니다. page가 있으면 offset, limit 쿼리로 next, prev 링크를 만듭니다.
+func RespondList[T any](c *gin.Context, status int, items []T, page *Pagination) {
+	if items == nil {
+		items = []T{}
+	}
+	response := &Envelope[[]T]{
 		Status: status,
-		Data:   product,
+		Data:   items,
+		Meta:   meta(c, page),
+		Links:  Links{Self: c.Request.URL.RequestURI()},
+	}
+	if page != nil && page.Limit > 0 {
+		if next := page.Offset + len(items); int64(next) < page.Total {
+			response.Links.Next = pageLink(c, next, page.Limit)
+		}
+		if page.Offset > 0 {
+			response.Links.Prev = pageLink(c, max(page.Offset-page.Limit, 0), page.Limit)
+		}
 	}
-	c.JSON(status, response)
+	attachNotices(c, response)
+	respond(c, status, response)
+}
+
+// AddWarning은 이번 응답의 warnings에 메시지를 추가합니다. 요청은 처리됐지만 클라이언트가 알아야 할 내용에 씁니다.
+func AddWarning(c *gin.Context, message string) {
+	warnings := c.GetStringSlice(warningsKey)
+	c.Set(warningsKey, append(warnings, message))
+}
+
+// Deprecate는 이번 응답에 deprecation을 넣고 Deprecation, Sunset, Link 헤더를 붙입니다.
+func Deprecate(c *gin.Context, deprecation Deprecation) {
+	c.Set(deprecationKey, &deprecation)
+	c.Header("Deprecation", "true")
+	if deprecation.Sunset != nil {
+		c.Header("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if deprecation.Link != "" {
+		c.Header("Link", "<"+deprecation.Link+`>; rel="deprecation"`)
+	}
+}
+
+// NoStore는 이번 응답에 다시 보여주면 안 되는 비밀(API 키 원문, 웹훅 서명 비밀 등)이 들어 있다고 표시합니다.
+// 프록시와 브라우저는 Cache-Control: no-store로, Idempotency 미들웨어는 같은 헤더를 보고 본문을 저장하지 않습니다.
+func NoStore(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+}
+
+// RequestID는 미들웨어가 넣어둔 요청 ID이고, 없으면 요청의 X-Request-ID 헤더입니다.
+func RequestID(c *gin.Context) string {
+	if id := c.GetString(RequestIDKey); id != "" {
+		return id
+	}
+	return c.GetHeader(RequestIDHeader)
+}
+
+func meta(c *gin.Context, page *Pagination) Meta {
+	m := Meta{RequestID: RequestID(c), Pagination: page}
+	if start, ok := c.Get(RequestStartKey); ok {
+		if start, ok := start.(time.Time); ok {
+			m.DurationMs = float64(time.Since(start).Microseconds()) / 1000
+		}
+	}
+	return m
+}
+
+func attachNotices[T any](c *gin.Context, response *Envelope[T]) {
+	response.Warnings = c.GetStringSlice(warningsKey)
+	if deprecation, ok := c.Get(deprecationKey); ok {
+		response.Deprecation = deprecation.(*Deprecation)
+	}
+}
+
+// pageLink는 현재 요청의 쿼리를 유지하고 offset, limit만 바꾼 URL입니다.
+func pageLink(c *gin.Context, offset int, limit int) string {
+	query := c.Request.URL.Query()
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+	return c.Request.URL.Path + "?" + query.Encode()
 }
@@ -1,17 +1,54 @@
 package utils
 
-type ErrorResponse struct {
-	Status int    `json:"status"`
-	Error  string `json:"error"`
-	Reason string `json:"reason"`
-}
+import (
+	"encoding/xml"
+	"time"
+)
 
+// 응답 구조체의 XMLName은 XML 응답의 루트 요소 이름입니다. JSON에는 나오지 않습니다.
+// 오류 응답은 problem.go의 Problem입니다.
 type Response struct {
-	Status  int    `json:"status"`
-	Message string `json:"message"`
+	XMLName xml.Name `json:"-" xml:"response"`
+	Status  int      `json:"status" xml:"status"`
+	Message string   `json:"message" xml:"message"`
+}
+
+// Envelope는 조회 응답입니다. RespondData, RespondList로 만듭니다.
+type Envelope[T any] struct {
+	XMLName     xml.Name     `json:"-" xml:"response"`
+	Status      int          `json:"status" xml:"status"`
+	Data        T            `json:"data" xml:"data"`
+	Meta        Meta         `json:"meta" xml:"meta"`
+	Links       Links        `json:"links" xml:"links"`
+	Warnings    []string     `json:"warnings,omitempty" xml:"warnings>warning,omitempty"`
+	Deprecation *Deprecation `json:"deprecation,omitempty" xml:"deprecation,omitempty"`
+}
+
+type Meta struct {
+	// RequestID는 X-Request-ID 헤더 값입니다.
+	RequestID string `json:"requestId,omitempty" xml:"requestId,omitempty"`
+	// DurationMs는 요청을 받은 뒤 응답을 만들기까지 걸린 시간입니다. middleware.RequestMeta가 없으면 0입니다.
+	DurationMs float64     `json:"durationMs" xml:"durationMs"`
+	Pagination *Pagination `json:"pagination,omitempty" xml:"pagination,omitempty"`
+}
+
+type Pagination struct {
+	Offset int   `json:"offset" xml:"offset"`
+	Limit  int   `json:"limit" xml:"limit"`
+	Total  int64 `json:"total" xml:"total"`
+}
+
+// Links는 요청 경로 기준의 상대 URL입니다. Next, Prev는 페이지가 있을 때만 있습니다.
+type Links struct {
+	Self string `json:"self" xml:"self"`
+	Next string `json:"next,omitempty" xml:"next,omitempty"`
+	Prev string `json:"prev,omitempty" xml:"prev,omitempty"`
 }
 
-type GetResponse struct {
-	Status int         `json:"status"`
-	Data   interface{} `json:"data"`
+type Deprecation struct {
+	Message string `json:"message" xml:"message"`
+	// Sunset 이후에는 제거될 수 있습니다. Sunset 헤더로도 보냅니다.
+	Sunset *time.Time `json:"sunset,omitempty" xml:"sunset,omitempty"`
+	// Link는 대신 쓸 API의 문서 주소입니다.
+	Link string `json:"link,omitempty" xml:"link,omitempty"`
 }
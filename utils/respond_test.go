@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRespondList_PageLinks(t *testing.T) {
+	// 테스트 설정
+	c, w := newTestContext(http.MethodGet, nil, map[string]string{RequestIDHeader: "req-1"})
+	c.Request.URL.RawQuery = "name=apple&limit=2&offset=2"
+	c.Set(RequestStartKey, time.Now().Add(-time.Second))
+
+	// 테스트 실행
+	RespondList(c, http.StatusOK, []testItem{{Name: "사과"}, {Name: "배"}}, &Pagination{Offset: 2, Limit: 2, Total: 5})
+
+	// 검증 - 다른 쿼리는 그대로 두고 offset, limit만 바꿉니다.
+	var body Envelope[[]testItem]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Len(t, body.Data, 2)
+	assert.Equal(t, "req-1", body.Meta.RequestID)
+	assert.GreaterOrEqual(t, body.Meta.DurationMs, 1000.0)
+	assert.Equal(t, &Pagination{Offset: 2, Limit: 2, Total: 5}, body.Meta.Pagination)
+	assert.Equal(t, Links{
+		Self: "/?name=apple&limit=2&offset=2",
+		Next: "/?limit=2&name=apple&offset=4",
+		Prev: "/?limit=2&name=apple&offset=0",
+	}, body.Links)
+}
+
+func TestRespondList_LastPageAndEmpty(t *testing.T) {
+	// 테스트 설정
+	c, w := newTestContext(http.MethodGet, nil, nil)
+
+	// 테스트 실행
+	RespondList[testItem](c, http.StatusOK, nil, &Pagination{Offset: 0, Limit: 10, Total: 0})
+
+	// 검증 - 빈 목록은 null이 아닌 []이고, 다음/이전 페이지가 없습니다.
+	assert.Contains(t, w.Body.String(), `"data":[]`)
+	var body Envelope[[]testItem]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Empty(t, body.Links.Next)
+	assert.Empty(t, body.Links.Prev)
+}
+
+func TestRespondData_WarningsAndDeprecation(t *testing.T) {
+	// 테스트 설정
+	c, w := newTestContext(http.MethodGet, nil, nil)
+	sunset := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	AddWarning(c, "첫 번째")
+	AddWarning(c, "두 번째")
+	Deprecate(c, Deprecation{Message: "v2를 쓰세요", Sunset: &sunset, Link: "https://example.com/v2"})
+
+	// 테스트 실행
+	RespondData(c, http.StatusOK, testItem{Name: "사과"})
+
+	// 검증
+	assert.Equal(t, "true", w.Header().Get("Deprecation"))
+	assert.Equal(t, "Tue, 01 Jan 2030 00:00:00 GMT", w.Header().Get("Sunset"))
+	assert.Equal(t, `<https://example.com/v2>; rel="deprecation"`, w.Header().Get("Link"))
+	var body Envelope[testItem]
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "사과", body.Data.Name)
+	assert.Equal(t, []string{"첫 번째", "두 번째"}, body.Warnings)
+	require.NotNil(t, body.Deprecation)
+	assert.Equal(t, "v2를 쓰세요", body.Deprecation.Message)
+	assert.Equal(t, "/", body.Links.Self)
+}
+
+func TestRespondData_NotListForCSV(t *testing.T) {
+	// 테스트 설정 - 단건 응답은 CSV로 보낼 수 없습니다.
+	c, w := newTestContext(http.MethodGet, nil, map[string]string{"Accept": "text/csv"})
+
+	// 테스트 실행
+	RespondData(c, http.StatusOK, testItem{Name: "사과"})
+
+	// 검증
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+}
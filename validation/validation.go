@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"Go-Gin-Basic-Template/i18n"
+	"errors"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/go-playground/validator/v10/non-standard/validators"
+	"log"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// skuPattern은 대문자와 숫자로 된 2~10자 마디를 하이픈으로 최대 5개까지 이은 값입니다. 예: FOOD-APPLE-001
+var skuPattern = regexp.MustCompile(`^[A-Z0-9]{2,10}(-[A-Z0-9]{2,10}){0,4}$`)
+
+// FieldError는 필드 하나의 검증 실패입니다. Field는 요청 본문 기준의 경로(예: "name", "items[0].price")입니다.
+type FieldError struct {
+	Field   string `json:"field" xml:"field"`
+	Rule    string `json:"rule" xml:"rule"`
+	Param   string `json:"param,omitempty" xml:"param,omitempty"`
+	Message string `json:"message" xml:"message"`
+}
+
+func init() {
+	// gin이 바인딩에 쓰는 Validate 인스턴스에 커스텀 태그와 번역을 등록합니다.
+	// 이 패키지를 import하면 utils.Bind와 Struct가 같은 규칙으로 검증합니다.
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	if err := register(v); err != nil {
+		log.Printf("validation: register validator: %v", err)
+	}
+}
+
+func register(v *validator.Validate) error {
+	v.RegisterTagNameFunc(fieldName)
+	if err := v.RegisterValidation("sku", isSKU); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation("notblank", validators.NotBlank); err != nil {
+		return err
+	}
+
+	if err := i18n.RegisterValidator(v); err != nil {
+		return err
+	}
+	if err := i18n.RegisterValidation(v, "sku", map[string]string{
+		i18n.Korean:   "{0}은(는) 대문자와 숫자로 된 SKU 형식이어야 합니다 (예: FOOD-APPLE-001)",
+		i18n.English:  "{0} must be a SKU of uppercase letters and digits (e.g. FOOD-APPLE-001)",
+		i18n.Japanese: "{0}は英大文字と数字のSKU形式でなければなりません (例: FOOD-APPLE-001)",
+	}); err != nil {
+		return err
+	}
+	return i18n.RegisterValidation(v, "notblank", map[string]string{
+		i18n.Korean:   "{0}은(는) 공백만으로 채울 수 없습니다",
+		i18n.English:  "{0} must not be blank",
+		i18n.Japanese: "{0}は空白だけにできません",
+	})
+}
+
+func isSKU(fl validator.FieldLevel) bool {
+	return skuPattern.MatchString(fl.Field().String())
+}
+
+// fieldName은 오류의 필드 이름으로 json 태그, form 태그, 필드 이름 순서로 씁니다.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// Struct는 obj의 binding 태그를 검증합니다. HTTP 바인딩을 거치지 않는 입력(gRPC, GraphQL, 가져오기)에 씁니다.
+func Struct(obj interface{}) error {
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// Errors는 err가 검증 오류면 필드마다 locale로 번역한 FieldError를 돌려줍니다.
+func Errors(locale string, err error) ([]FieldError, bool) {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil, false
+	}
+	fieldErrors := make([]FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   fieldPath(fieldError.Namespace()),
+			Rule:    fieldError.Tag(),
+			Param:   fieldError.Param(),
+			Message: i18n.Translate(locale, fieldError),
+		})
+	}
+	return fieldErrors, true
+}
+
+// fieldPath는 "ProductRequest.name"처럼 구조체 이름으로 시작하는 Namespace에서 구조체 이름을 뺍니다.
+func fieldPath(namespace string) string {
+	if _, path, found := strings.Cut(namespace, "."); found {
+		return path
+	}
+	return namespace
+}
+
+// Is는 err가 검증 오류인지 확인합니다.
+func Is(err error) bool {
+	var validationErrors validator.ValidationErrors
+	return errors.As(err, &validationErrors)
+}
@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"Go-Gin-Basic-Template/i18n"
+	"Go-Gin-Basic-Template/types/requestTypes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSKU(t *testing.T) {
+	tests := []struct {
+		sku   string
+		valid bool
+	}{
+		{"FOOD-APPLE-001", true},
+		{"AB", true},
+		{"A1-B2-C3-D4-E5", true},
+		{"food-apple", false},
+		{"FOOD_APPLE", false},
+		{"A", false},
+		{"FOOD-", false},
+		{"A1-B2-C3-D4-E5-F6", false},
+		{"ABCDEFGHIJK", false},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행
+		err := Struct(&requestTypes.ProductRequest{Name: "상품", Price: 1000, SKU: tt.sku})
+
+		// 검증
+		if tt.valid {
+			assert.NoError(t, err, tt.sku)
+		} else {
+			assert.Error(t, err, tt.sku)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	// 테스트 설정
+	supplierCost := -1.0
+	err := Struct(&requestTypes.ProductRequest{Name: strings.Repeat("가", 101), Price: 0, SupplierCost: &supplierCost})
+
+	// 테스트 실행
+	fieldErrors, ok := Errors(i18n.Korean, err)
+
+	// 검증
+	require.True(t, ok)
+	assert.Equal(t, []FieldError{
+		{Field: "name", Rule: "max", Param: "100", Message: "name은(는) 100자 이하여야 합니다"},
+		{Field: "price", Rule: "gt", Param: "0", Message: "price은(는) 0보다 커야 합니다"},
+		{Field: "supplierCost", Rule: "gte", Param: "0", Message: "supplierCost은(는) 0 이상이어야 합니다"},
+	}, fieldErrors)
+}
+
+func TestErrors_NotValidationError(t *testing.T) {
+	// 테스트 실행
+	fieldErrors, ok := Errors(i18n.Korean, assert.AnError)
+
+	// 검증
+	assert.False(t, ok)
+	assert.Nil(t, fieldErrors)
+}
+
+func TestFieldPath(t *testing.T) {
+	// 검증
+	assert.Equal(t, "name", fieldPath("ProductRequest.name"))
+	assert.Equal(t, "items[0].price", fieldPath("ImportRequest.items[0].price"))
+	assert.Equal(t, "name", fieldPath("name"))
+}
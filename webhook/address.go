@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// lookupTimeout은 구독을 저장할 때 받는 쪽 호스트를 확인하는 시간입니다.
+const lookupTimeout = 3 * time.Second
+
+var ErrForbiddenAddress = errors.New("webhook address is not public")
+
+// PublicAddress는 웹훅을 보내도 되는 주소인지 확인합니다. 루프백, 사설망, 링크 로컬(클라우드 메타데이터 포함),
+// 미지정(0.0.0.0, ::), 멀티캐스트 주소로는 보내지 않습니다. IPv4를 담은 IPv6 주소도 같이 확인합니다.
+func PublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() &&
+		!addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() &&
+		!addr.IsUnspecified()
+}
+
+// ValidateURL은 구독 URL이 http(s)이고 공개 주소를 가리키는지 확인합니다.
+// 호스트 이름은 지금 조회한 주소만 확인할 수 있으므로, 전송할 때 NewSender의 클라이언트가 연결하는 주소를 다시 확인합니다.
+func ValidateURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("url must be an absolute http or https URL")
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !PublicAddress(addr) {
+			return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+		}
+		return nil
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, lookupTimeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		// 받는 쪽 DNS가 잠시 안 될 수 있으므로 저장은 허용합니다. 주소는 전송할 때 확인합니다.
+		return nil
+	}
+	for _, addr := range addrs {
+		if !PublicAddress(addr) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrForbiddenAddress, host, addr)
+		}
+	}
+	return nil
+}
+
+// dialControl은 DNS 조회가 끝난 뒤 실제로 연결할 주소를 확인합니다. 저장할 때와 다른 주소를 돌려주는 DNS rebinding을 막습니다.
+func dialControl(allow func(netip.Addr) bool) func(network string, address string, conn syscall.RawConn) error {
+	return func(network string, address string, conn syscall.RawConn) error {
+		addrPort, err := netip.ParseAddrPort(address)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrForbiddenAddress, address)
+		}
+		if !allow(addrPort.Addr()) {
+			return fmt.Errorf("%w: %s", ErrForbiddenAddress, addrPort.Addr())
+		}
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicAddress(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.5", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"fd00::1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"::ffff:127.0.0.1", false},
+		{"224.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행 및 검증
+		assert.Equal(t, tt.public, PublicAddress(netip.MustParseAddr(tt.addr)), tt.addr)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := []struct {
+		url string
+		ok  bool
+	}{
+		{"https://93.184.216.34/hook", true},
+		{"ftp://93.184.216.34/hook", false},
+		{"https:///hook", false},
+		{"http://127.0.0.1:8080/hook", false},
+		{"http://[::1]/hook", false},
+		{"http://169.254.169.254/latest/meta-data", false},
+		{"http://10.1.2.3/hook", false},
+		{"http://0.0.0.0/hook", false},
+		{"http://localhost:8080/hook", false},
+		{"http://api.localhost./hook", false},
+	}
+
+	for _, tt := range tests {
+		// 테스트 실행
+		err := ValidateURL(context.Background(), tt.url)
+
+		// 검증
+		if tt.ok {
+			assert.NoError(t, err, tt.url)
+		} else {
+			assert.Error(t, err, tt.url)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultMaxAttempts  = 8
+	defaultDisableAfter = 20
+	defaultPollInterval = 5 * time.Second
+	baseBackoff         = 30 * time.Second
+	maxBackoff          = 6 * time.Hour
+	// 응답 본문은 오류 메시지에 일부만 남깁니다.
+	maxErrorBody = 512
+	// Dispatch가 전송을 동시에 여러 건 보내므로 같은 받는 쪽과의 연결을 몇 개 남겨 둡니다.
+	webhookIdleConnsPerHost = 8
+)
+
+type Request struct {
+	DeliveryID string
+	EventType  string
+	URL        string
+	Secret     string
+	Payload    []byte
+}
+
+type Result struct {
+	StatusCode int
+	Err        error
+}
+
+// Succeeded는 2xx 응답만 성공으로 봅니다. 리다이렉트도 실패로 취급해서 재시도합니다.
+func (r Result) Succeeded() bool {
+	return r.Err == nil && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+type Sender struct {
+	Client *http.Client
+}
+
+// NewSender는 공개 주소(PublicAddress)로만 연결합니다.
+func NewSender() *Sender {
+	return &Sender{Client: newClient(PublicAddress)}
+}
+
+// newClient의 allow는 연결 직전에 확인하므로 리다이렉트나 DNS rebinding으로도 막힌 주소에 닿지 않습니다.
+// 프록시를 거치면 프록시의 주소만 확인하게 되므로 환경 변수의 프록시 설정은 쓰지 않습니다.
+func newClient(allow func(netip.Addr) bool) *http.Client {
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: dialControl(allow)}
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConnsPerHost: webhookIdleConnsPerHost,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func (s *Sender) Send(ctx context.Context, req Request) Result {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return Result{Err: err}
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", "Go-Gin-Basic-Template-Webhook/1.0")
+	httpReq.Header.Set(HeaderID, req.DeliveryID)
+	httpReq.Header.Set(HeaderEvent, req.EventType)
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, time.Now(), req.Payload))
+
+	resp, err := s.Client.Do(httpReq)
+	if err != nil {
+		return Result{Err: err}
+	}
+	defer resp.Body.Close()
+
+	result := Result{StatusCode: resp.StatusCode}
+	if !result.Succeeded() {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		result.Err = fmt.Errorf("receiver responded %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return result
+}
+
+// Backoff는 attempt번째 실패 뒤 다음 시도까지 기다릴 시간입니다. 30초에서 시작해 두 배씩 늘리고 6시간에서 멈춥니다.
+func Backoff(attempt int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
+
+// MaxAttempts는 전송 한 건을 포기하기 전까지 시도할 횟수입니다.
+func MaxAttempts() int {
+	return envInt("WEBHOOK_MAX_ATTEMPTS", defaultMaxAttempts)
+}
+
+// DisableAfter는 구독을 비활성화하기 전까지 허용하는 연속 실패 횟수입니다.
+func DisableAfter() int {
+	return envInt("WEBHOOK_DISABLE_AFTER", defaultDisableAfter)
+}
+
+func envInt(key string, fallback int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return fallback
+}
+
+// PollInterval은 전송 워커가 시도할 때가 된 전송을 확인하는 주기입니다.
+func PollInterval() time.Duration {
+	if interval, err := time.ParseDuration(os.Getenv("WEBHOOK_POLL_INTERVAL")); err == nil && interval > 0 {
+		return interval
+	}
+	return defaultPollInterval
+}
@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newLoopbackSender는 httptest 서버(127.0.0.1)로 보낼 수 있는 Sender입니다.
+func newLoopbackSender() *Sender {
+	return &Sender{Client: newClient(func(netip.Addr) bool { return true })}
+}
+
+func TestSender_Send(t *testing.T) {
+	// 테스트 설정 - 받는 쪽에서 서명을 검증합니다.
+	var received *http.Request
+	var receivedBody []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		if Verify("secret", r.Header.Get(HeaderSignature), receivedBody, time.Minute, time.Now()) != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	// 테스트 실행
+	result := newLoopbackSender().Send(context.Background(), Request{
+		DeliveryID: "delivery-1",
+		EventType:  "product.created",
+		URL:        receiver.URL,
+		Secret:     "secret",
+		Payload:    []byte(`{"id":1}`),
+	})
+
+	// 검증
+	require.True(t, result.Succeeded(), result.Err)
+	assert.Equal(t, http.StatusNoContent, result.StatusCode)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "delivery-1", received.Header.Get(HeaderID))
+	assert.Equal(t, "product.created", received.Header.Get(HeaderEvent))
+	assert.JSONEq(t, `{"id":1}`, string(receivedBody))
+}
+
+func TestSender_SendFailure(t *testing.T) {
+	// 테스트 설정 - 리다이렉트는 따라가지 않고 실패로 봅니다.
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/moved" {
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+			return
+		}
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+	sender := newLoopbackSender()
+
+	// 테스트 실행
+	failed := sender.Send(context.Background(), Request{URL: receiver.URL, Secret: "secret"})
+	redirected := sender.Send(context.Background(), Request{URL: receiver.URL + "/moved", Secret: "secret"})
+
+	// 검증
+	assert.False(t, failed.Succeeded())
+	assert.Equal(t, http.StatusInternalServerError, failed.StatusCode)
+	assert.EqualError(t, failed.Err, "receiver responded 500: boom")
+	assert.False(t, redirected.Succeeded())
+	assert.Equal(t, http.StatusFound, redirected.StatusCode)
+}
+
+func TestSender_RefusesPrivateAddress(t *testing.T) {
+	// 테스트 설정 - 호스트 이름이 루프백으로 풀리는 경우(DNS rebinding)도 연결할 때 막아야 합니다.
+	hits := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer receiver.Close()
+
+	// 테스트 실행
+	result := NewSender().Send(context.Background(), Request{URL: receiver.URL, Secret: "secret"})
+
+	// 검증
+	assert.ErrorIs(t, result.Err, ErrForbiddenAddress)
+	assert.Zero(t, hits)
+}
+
+func TestBackoff(t *testing.T) {
+	// 검증
+	assert.Equal(t, 30*time.Second, Backoff(1))
+	assert.Equal(t, time.Minute, Backoff(2))
+	assert.Equal(t, 4*time.Minute, Backoff(4))
+	assert.Equal(t, 6*time.Hour, Backoff(20))
+}
@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	// 테스트 설정
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"type":"product.created"}`)
+
+	// 테스트 실행
+	signature := Sign("secret", now, body)
+
+	// 검증
+	assert.True(t, strings.HasPrefix(signature, "t=1700000000,v1="))
+	assert.NoError(t, Verify("secret", signature, body, time.Minute, now.Add(30*time.Second)))
+	assert.ErrorIs(t, Verify("other", signature, body, 0, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("secret", signature, []byte(`{}`), 0, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("secret", signature, body, time.Minute, now.Add(2*time.Minute)), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify("secret", "garbage", body, 0, now), ErrInvalidSignature)
+}
+
+func TestNewSecret(t *testing.T) {
+	// 테스트 실행
+	first, err := NewSecret()
+	require.NoError(t, err)
+	second, err := NewSecret()
+	require.NoError(t, err)
+
+	// 검증
+	assert.True(t, strings.HasPrefix(first, "whsec_"))
+	assert.NotEqual(t, first, second)
+}